@@ -1,47 +1,56 @@
 package main
 
 import (
+	achievements "TelTwBot/Internal/Achievements"
 	config "TelTwBot/Internal/Config"
 	constants "TelTwBot/Internal/Config/Constants"
 	database "TelTwBot/Internal/Database"
 	telegramBot "TelTwBot/Internal/Telegram"
 	bot "TelTwBot/Internal/TwitchBot"
-	"fmt"
+	"context"
+	"flag"
 	"log"
+	_ "time/tzdata"
 )
 
 func main() {
-
-	// Load database config
-	dbConfigFile, err := config.ConfigPath(constants.DbConfigFile)
-	if err != nil {
-		log.Fatalf("Error getting database config path: %v", err)
+	configFile := flag.String("config", "", "path to the YAML settings file (default: Internal/Config/config.yaml, legacy files if it doesn't exist)")
+	migrate := flag.Bool("migrate", false, "apply pending database migrations at startup")
+	flag.Parse()
+
+	//telTwBot migrate up|down|status only works with the database, so only the database settings are required
+	if flag.Arg(0) == "migrate" {
+		if err := migrateCommand(*configFile, flag.Args()[1:]); err != nil {
+			log.Fatalf("Migration failed: %v", err)
+		}
+		return
 	}
 
-	dbConfig, err := config.LoadFromJSON[config.DbConfig](dbConfigFile)
+	// Load settings, TELTW_* environment variables override the file
+	settings, err := config.LoadSettings(*configFile)
 	if err != nil {
-		log.Fatalf("Error loading database config: %v", err)
+		log.Fatalf("Invalid settings:\n%v", err)
 	}
 
-	// Create connection string
-	connStr := fmt.Sprintf("host=%s port=%d user=%s password=%s dbname=%s sslmode=%s",
-		dbConfig.Host, dbConfig.Port, dbConfig.User, dbConfig.Password, dbConfig.DBName, dbConfig.SSLMode)
-
 	// Initialize database
-	db, err := database.New(connStr)
+	db, err := openDatabase(settings.Database)
 	if err != nil {
 		log.Fatalf("Error initializing database: %v", err)
 	}
 	defer db.Close()
 
-	//Load telegramBot config
-	tgBotFile, err := config.ConfigPath(constants.TgSettingsFile)
-	if err != nil {
-		log.Fatalf("Error getting Telegram config path: %v", err)
+	if *migrate {
+		applied, err := db.MigrateUp(context.Background())
+		if err != nil {
+			log.Fatalf("Error applying migrations: %v", err)
+		}
+		log.Printf("%sApplied %d migrations.", constants.Green, len(applied))
 	}
 
+	repos := db.Repositories()
+
 	//Initialize telegramBot
-	tgBot, err := telegramBot.NewTelegramNotifierFromConfigFile(tgBotFile)
+	tgBot, err := telegramBot.NewTelegramNotifier(settings.Telegram.BotToken, settings.Telegram.ChatID, repos)
 	if err != nil {
 		log.Fatalf("Error initializing Telegram bot: %v", err)
 	}
@@ -68,13 +77,110 @@ func main() {
 		log.Fatalf("Error gettings duels file path: %v", err)
 	}
 
-	allDuels, err := config.LoadFromJSON[[]config.DuelMsg](duelFile)
+	allDuels, err := config.LoadDuels(duelFile)
+	if err != nil {
+		log.Fatalf("Error while loading duels file: %v", err)
+	}
+
+	//load friends for !who
+	friendsFile, err := config.ConfigPath(constants.FriendsFile)
+	if err != nil {
+		log.Fatalf("Error getting friends file path: %v", err)
+	}
+
+	friends, err := config.LoadFriends(friendsFile)
+	if err != nil {
+		log.Fatalf("Error while loading friends file: %v", err)
+	}
+
+	//load trivia question packs
+	triviaDir, err := config.ConfigPath(constants.TriviaDir)
+	if err != nil {
+		log.Fatalf("Error getting trivia directory path: %v", err)
+	}
+
+	triviaPacks, err := config.LoadTriviaPacks(triviaDir)
+	if err != nil {
+		log.Fatalf("Error while loading trivia packs: %v", err)
+	}
+
+	log.Printf("%sLoaded %d trivia packs.", constants.Green, len(triviaPacks))
+
+	//load bosses file
+	bossesFile, err := config.ConfigPath(constants.BossesFile)
+	if err != nil {
+		log.Fatalf("Error getting bosses file path: %v", err)
+	}
+
+	bosses, err := config.LoadBosses(bossesFile)
+	if err != nil {
+		log.Fatalf("Error while loading bosses file: %v", err)
+	}
+
+	//load level curve
+	levelsFile, err := config.ConfigPath(constants.LevelsFile)
+	if err != nil {
+		log.Fatalf("Error getting levels file path: %v", err)
+	}
+
+	levels, err := config.LoadFromJSON[config.LevelConfig](levelsFile)
+	if err != nil {
+		log.Fatalf("Error while parsing levels file: %v", err)
+	}
+
+	if err := levels.Validate(); err != nil {
+		log.Fatalf("Invalid levels file: %v", err)
+	}
+
+	//load shop items
+	itemsFile, err := config.ConfigPath(constants.ItemsFile)
+	if err != nil {
+		log.Fatalf("Error getting items file path: %v", err)
+	}
+
+	items, err := config.LoadFromJSON[config.ItemCatalog](itemsFile)
+	if err != nil {
+		log.Fatalf("Error while parsing items file: %v", err)
+	}
+
+	if err := items.Validate(); err != nil {
+		log.Fatalf("Invalid items file: %v", err)
+	}
+
+	log.Printf("%sLoaded %d shop items.", constants.Green, len(items))
+
+	//load achievements
+	achievementsFile, err := config.ConfigPath(constants.AchievementsFile)
 	if err != nil {
-		log.Fatalf("Error while parsing duels file: %v", err)
+		log.Fatalf("Error getting achievements file path: %v", err)
+	}
+
+	achievementDefs, err := config.LoadFromJSON[[]config.Achievement](achievementsFile)
+	if err != nil {
+		log.Fatalf("Error while parsing achievements file: %v", err)
+	}
+
+	if err := achievements.Validate(achievementDefs); err != nil {
+		log.Fatalf("Invalid achievements file: %v", err)
+	}
+
+	//load auto-greeting settings
+	welcomeFile, err := config.ConfigPath(constants.WelcomeFile)
+	if err != nil {
+		log.Fatalf("Error getting welcome file path: %v", err)
+	}
+
+	welcome, err := config.LoadFromJSON[config.WelcomeConfig](welcomeFile)
+	if err != nil {
+		log.Fatalf("Error while parsing welcome file: %v", err)
+	}
+
+	if err := welcome.Validate(); err != nil {
+		log.Fatalf("Invalid welcome file: %v", err)
 	}
 
 	//Initialize twitchBot
-	twBot, err := bot.New(greeter, allDuels, tgBot)
+	twBot, err := bot.New(settings, greeter, allDuels, friends, triviaPacks, bosses, levels, items, achievementDefs, welcome, repos, tgBot)
 
 	if err != nil {
 		log.Fatalf("Error creating bot %v", err)
@@ -90,3 +196,11 @@ func main() {
 
 	select {}
 }
+
+// openDatabase connects to the database selected by database.driver in the settings.
+func openDatabase(settings config.DbConfig) (*database.Database, error) {
+	if settings.Driver == config.DriverSQLite {
+		return database.NewSQLite(settings.Path)
+	}
+	return database.New(settings.ConnectionString())
+}
@@ -0,0 +1,79 @@
+package main
+
+import (
+	config "TelTwBot/Internal/Config"
+	database "TelTwBot/Internal/Database"
+	"context"
+	"errors"
+	"fmt"
+	"log"
+	"strconv"
+)
+
+const migrateUsage = "usage: telTwBot [--config <path>] migrate up|down [steps]|status"
+
+// migrateCommand connects to the database from the settings and runs the migrate subcommand.
+func migrateCommand(configFile string, args []string) error {
+	settings, err := config.LoadDatabaseSettings(configFile)
+	if err != nil {
+		return fmt.Errorf("invalid database settings:\n%w", err)
+	}
+
+	db, err := openDatabase(settings)
+	if err != nil {
+		return err
+	}
+	defer db.Close()
+
+	return runMigrate(db, args)
+}
+
+// runMigrate handles the migrate subcommand, steps of down default to 1.
+func runMigrate(db *database.Database, args []string) error {
+	ctx := context.Background()
+	if len(args) == 0 {
+		return errors.New(migrateUsage)
+	}
+
+	switch args[0] {
+	case "up":
+		applied, err := db.MigrateUp(ctx)
+		for _, migration := range applied {
+			log.Printf("✅Applied migration %04d_%s.", migration.Version, migration.Name)
+		}
+		if err == nil && len(applied) == 0 {
+			log.Printf("The database is up to date.")
+		}
+		return err
+
+	case "down":
+		steps := 1
+		if len(args) > 1 {
+			var err error
+			if steps, err = strconv.Atoi(args[1]); err != nil || steps < 1 {
+				return fmt.Errorf("steps should be a positive number, got '%s'", args[1])
+			}
+		}
+		reverted, err := db.MigrateDown(ctx, steps)
+		for _, migration := range reverted {
+			log.Printf("✅Reverted migration %04d_%s.", migration.Version, migration.Name)
+		}
+		return err
+
+	case "status":
+		statuses, err := db.MigrationStatus(ctx)
+		if err != nil {
+			return err
+		}
+		for _, status := range statuses {
+			applied := "pending"
+			if status.AppliedAt != nil {
+				applied = "applied " + status.AppliedAt.Local().Format("2006-01-02 15:04:05")
+			}
+			fmt.Printf("%04d_%-30s %s\n", status.Version, status.Name, applied)
+		}
+		return nil
+	}
+
+	return errors.New(migrateUsage)
+}
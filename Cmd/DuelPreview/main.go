@@ -0,0 +1,61 @@
+package main
+
+import (
+	config "TelTwBot/Internal/Config"
+	constants "TelTwBot/Internal/Config/Constants"
+	"flag"
+	"fmt"
+	"log"
+	"math/rand/v2"
+	"os"
+)
+
+// DuelPreview validates the duels file and prints every template rendered with sample names and stats,
+// so new duels can be reviewed before the bot picks them up.
+func main() {
+	file := flag.String("file", "", "path to the duels file (default: the bot's "+constants.DuelsFile+")")
+	samples := flag.Int("samples", 1, "how many times to render every template, random choices differ between samples")
+	flag.Parse()
+
+	path := *file
+	if path == "" {
+		var err error
+		if path, err = config.ConfigPath(constants.DuelsFile); err != nil {
+			log.Fatalf("Error getting duels file path: %v", err)
+		}
+	}
+
+	duels, err := config.LoadDuels(path)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "%s is invalid:\n%v\n", path, err)
+		os.Exit(1)
+	}
+
+	roles := config.DuelRoles{Initiator: "Initiator", Challenger: "Challenger", Stats: map[string]int{}}
+	for i, stat := range config.DuelStats {
+		roles.Stats[stat] = i + 1
+	}
+
+	for i, duel := range duels {
+		outcome := roles
+		kind := "win"
+		if duel.IsDraw {
+			kind = "draw"
+		} else {
+			outcome.Winner, outcome.Loser = roles.Initiator, roles.Challenger
+		}
+		fmt.Printf("#%d (%s, weight %d)\n", i+1, kind, max(duel.Weight, 1))
+
+		for range *samples {
+			for _, template := range []string{duel.AnnounceMessage, duel.DuelMessage} {
+				message, err := config.RenderDuelTemplate(template, outcome, rand.IntN)
+				if err != nil {
+					log.Fatalf("Error rendering duel #%d: %v", i+1, err)
+				}
+				fmt.Printf("  %s\n", message)
+			}
+		}
+		fmt.Println()
+	}
+	fmt.Printf("%d duels are valid.\n", len(duels))
+}
@@ -0,0 +1,103 @@
+package achievements
+
+import (
+	config "TelTwBot/Internal/Config"
+	"context"
+	"fmt"
+)
+
+const (
+	EventDuelWin     = "duel_win"
+	EventDuelDraw    = "duel_draw"
+	EventDuelLose    = "duel_lose"
+	EventStat        = "stat"
+	EventAttendance  = "attendance"
+	EventTriviaWin   = "trivia_win"
+	EventDailyStreak = "daily_streak"
+)
+
+// Event is something that happened to the user. Value is the current value for threshold events,
+// e.g. the new stat value for the stat event.
+type Event struct {
+	Username string
+	Type     string
+	Stat     string
+	Value    int
+}
+
+// Store keeps the event counters and unlocked achievements, *database.Database implements it.
+type Store interface {
+	// IncrementAchievementProgress counts the event and returns how many times it happened to the user.
+	IncrementAchievementProgress(ctx context.Context, username string, event string) (int, error)
+	// UnlockAchievement returns false if the achievement was already unlocked.
+	UnlockAchievement(ctx context.Context, username string, achievementID string) (bool, error)
+}
+
+type Evaluator struct {
+	achievements []config.Achievement
+	store        Store
+}
+
+func NewEvaluator(achievements []config.Achievement, store Store) *Evaluator {
+	return &Evaluator{achievements: achievements, store: store}
+}
+
+// Evaluate records the event and returns the achievements unlocked by it.
+func (e *Evaluator) Evaluate(ctx context.Context, event Event) ([]config.Achievement, error) {
+	var candidates []config.Achievement
+	countNeeded := false
+	for _, achievement := range e.achievements {
+		if achievement.Event != event.Type || (achievement.Stat != "" && achievement.Stat != event.Stat) {
+			continue
+		}
+		candidates = append(candidates, achievement)
+		countNeeded = countNeeded || achievement.Count > 0
+	}
+
+	if len(candidates) == 0 {
+		return nil, nil
+	}
+
+	//Events are counted only if some achievement needs the count
+	count := 0
+	if countNeeded {
+		var err error
+		count, err = e.store.IncrementAchievementProgress(ctx, event.Username, event.Type)
+		if err != nil {
+			return nil, fmt.Errorf("failed to count %s event: %w", event.Type, err)
+		}
+	}
+
+	var unlocked []config.Achievement
+	for _, achievement := range candidates {
+		reached := (achievement.Count > 0 && count >= achievement.Count) ||
+			(achievement.Value > 0 && event.Value >= achievement.Value)
+		if !reached {
+			continue
+		}
+
+		isNew, err := e.store.UnlockAchievement(ctx, event.Username, achievement.ID)
+		if err != nil {
+			return unlocked, fmt.Errorf("failed to unlock %s: %w", achievement.ID, err)
+		}
+		if isNew {
+			unlocked = append(unlocked, achievement)
+		}
+	}
+
+	return unlocked, nil
+}
+
+// Find returns the achievement definition by ID.
+func (e *Evaluator) Find(id string) (config.Achievement, bool) {
+	for _, achievement := range e.achievements {
+		if achievement.ID == id {
+			return achievement, true
+		}
+	}
+	return config.Achievement{}, false
+}
+
+func (e *Evaluator) Count() int {
+	return len(e.achievements)
+}
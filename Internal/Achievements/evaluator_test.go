@@ -0,0 +1,77 @@
+package achievements
+
+import (
+	config "TelTwBot/Internal/Config"
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+type fakeStore struct {
+	progress map[string]int
+	unlocked map[string]bool
+}
+
+func newFakeStore() *fakeStore {
+	return &fakeStore{progress: make(map[string]int), unlocked: make(map[string]bool)}
+}
+
+func (s *fakeStore) IncrementAchievementProgress(ctx context.Context, username string, event string) (int, error) {
+	s.progress[username+"/"+event]++
+	return s.progress[username+"/"+event], nil
+}
+
+func (s *fakeStore) UnlockAchievement(ctx context.Context, username string, achievementID string) (bool, error) {
+	key := username + "/" + achievementID
+	if s.unlocked[key] {
+		return false, nil
+	}
+	s.unlocked[key] = true
+	return true, nil
+}
+
+var testAchievements = []config.Achievement{
+	{ID: "first-win", Name: "First Blood", Event: EventDuelWin, Count: 1},
+	{ID: "three-draws", Name: "Peacekeeper", Event: EventDuelDraw, Count: 3},
+	{ID: "max-strength", Name: "Hercules", Event: EventStat, Stat: "strength", Value: 10},
+}
+
+func TestEvaluatorEventStream(t *testing.T) {
+	store := newFakeStore()
+	evaluator := NewEvaluator(testAchievements, store)
+
+	stream := []struct {
+		event    Event
+		unlocked []string
+	}{
+		{Event{Username: "alice", Type: EventDuelLose}, nil},
+		{Event{Username: "alice", Type: EventDuelWin}, []string{"first-win"}},
+		{Event{Username: "alice", Type: EventDuelWin}, nil},
+		{Event{Username: "bob", Type: EventDuelWin}, []string{"first-win"}},
+		{Event{Username: "alice", Type: EventDuelDraw}, nil},
+		{Event{Username: "bob", Type: EventDuelDraw}, nil},
+		{Event{Username: "alice", Type: EventDuelDraw}, nil},
+		{Event{Username: "alice", Type: EventDuelDraw}, []string{"three-draws"}},
+		{Event{Username: "alice", Type: EventDuelDraw}, nil},
+		{Event{Username: "bob", Type: EventStat, Stat: "luck", Value: 10}, nil},
+		{Event{Username: "bob", Type: EventStat, Stat: "strength", Value: 9}, nil},
+		{Event{Username: "bob", Type: EventStat, Stat: "strength", Value: 10}, []string{"max-strength"}},
+		{Event{Username: "bob", Type: EventStat, Stat: "strength", Value: 10}, nil},
+	}
+
+	for i, step := range stream {
+		unlocked, err := evaluator.Evaluate(context.Background(), step.event)
+		require.NoError(t, err)
+
+		var ids []string
+		for _, achievement := range unlocked {
+			ids = append(ids, achievement.ID)
+		}
+		require.Equal(t, step.unlocked, ids, "event #%d: %+v", i+1, step.event)
+	}
+
+	//Events without count achievements aren't stored
+	require.NotContains(t, store.progress, "alice/"+EventDuelLose)
+	require.NotContains(t, store.progress, "bob/"+EventStat)
+}
@@ -0,0 +1,35 @@
+package achievements
+
+import (
+	config "TelTwBot/Internal/Config"
+	"fmt"
+	"slices"
+	"strings"
+)
+
+// Events are the events achievements can be bound to.
+var Events = []string{EventDuelWin, EventDuelDraw, EventDuelLose, EventStat, EventAttendance, EventTriviaWin, EventDailyStreak}
+
+func Validate(achievements []config.Achievement) error {
+	ids := make(map[string]bool)
+	for i, achievement := range achievements {
+		if achievement.ID == "" || achievement.Name == "" {
+			return fmt.Errorf("achievement #%d should have an ID and a name", i+1)
+		}
+		if ids[achievement.ID] {
+			return fmt.Errorf("achievement '%s' is defined twice", achievement.ID)
+		}
+		ids[achievement.ID] = true
+
+		if !slices.Contains(Events, achievement.Event) {
+			return fmt.Errorf("achievement '%s' has unknown event '%s', expected one of: %s", achievement.ID, achievement.Event, strings.Join(Events, ", "))
+		}
+		if (achievement.Count > 0) == (achievement.Value > 0) {
+			return fmt.Errorf("achievement '%s' should have either Count or Value greater than 0", achievement.ID)
+		}
+		if achievement.Event == EventStat && (achievement.Stat == "" || achievement.Value <= 0) {
+			return fmt.Errorf("achievement '%s' should have Stat and Value for stat event", achievement.ID)
+		}
+	}
+	return nil
+}
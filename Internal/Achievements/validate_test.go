@@ -0,0 +1,38 @@
+package achievements
+
+import (
+	config "TelTwBot/Internal/Config"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestShippedAchievementsAreValid(t *testing.T) {
+	achievements, err := config.LoadFromJSON[[]config.Achievement]("../Config/achievements.json")
+	require.NoError(t, err)
+	require.NoError(t, Validate(achievements))
+}
+
+func TestValidate(t *testing.T) {
+	for _, tc := range []struct {
+		name        string
+		achievement config.Achievement
+		expected    string
+	}{
+		{"no name", config.Achievement{ID: "win", Event: EventDuelWin, Count: 1}, "achievement #1 should have an ID and a name"},
+		{"unknown event", config.Achievement{ID: "win", Name: "Win", Event: "duel_won", Count: 1},
+			"achievement 'win' has unknown event 'duel_won', expected one of: duel_win, duel_draw, duel_lose, stat, attendance, trivia_win, daily_streak"},
+		{"count and value", config.Achievement{ID: "win", Name: "Win", Event: EventDuelWin, Count: 1, Value: 1},
+			"achievement 'win' should have either Count or Value greater than 0"},
+		{"stat without name", config.Achievement{ID: "strong", Name: "Strong", Event: EventStat, Value: 10},
+			"achievement 'strong' should have Stat and Value for stat event"},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			require.EqualError(t, Validate([]config.Achievement{tc.achievement}), tc.expected)
+		})
+	}
+
+	win := config.Achievement{ID: "win", Name: "Win", Event: EventDuelWin, Count: 1}
+	require.NoError(t, Validate([]config.Achievement{win}))
+	require.EqualError(t, Validate([]config.Achievement{win, win}), "achievement 'win' is defined twice")
+}
@@ -0,0 +1,156 @@
+package calc
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestEvaluate(t *testing.T) {
+	for _, tc := range []struct {
+		input    string
+		expected string
+	}{
+		{"2+2", "4"},
+		{"2 + 3 * 4", "14"},
+		{"(2 + 3) * 4", "20"},
+		{"-2^2", "-4"},
+		{"2^-1", "0.5"},
+		{"2^3^2", "512"},
+		{"2**10", "1024"},
+		{"--3", "3"},
+		{"10 % 4", "2"},
+		{"7 / 2", "3.5"},
+		{"6 × 7 ÷ 2 − 1", "20"},
+		{"sqrt(16) + abs(-2)", "6"},
+		{"round(pi, 2)", "3.14"},
+		{"round(2.5)", "3"},
+		{"log(1000)", "3"},
+		{"log(8, 2)", "3"},
+		{"ln(e)", "1"},
+		{"min(3, 1, 2) + max(4, 9)", "10"},
+		{"sin(0)", "0"},
+		{"1e3 + .5", "1000.5"},
+		{"1/3", "0.333333333333"},
+		{"2^40", "1099511627776"},
+		{"2^60", "1.15292150461e+18"},
+	} {
+		t.Run(tc.input, func(t *testing.T) {
+			value, variable, err := Evaluate(tc.input, nil)
+			require.NoError(t, err)
+			require.Empty(t, variable)
+			require.Equal(t, tc.expected, Format(value))
+		})
+	}
+}
+
+func TestEvaluateErrors(t *testing.T) {
+	for _, tc := range []struct {
+		input string
+		pos   int
+		msg   string
+	}{
+		{"", 1, "empty expression"},
+		{"2 +", 4, "unexpected end of expression"},
+		{"(2 + 3", 7, "missing ')' for '(' at position 1"},
+		{"2 + 3)", 6, "unexpected ')'"},
+		{"5 / (2 - 2)", 3, "division by zero"},
+		{"5 % 0", 3, "modulo by zero"},
+		{"2 $ 3", 3, "unexpected character '$'"},
+		{"10^400", 3, "result of '^' is too big"},
+		{"1e400", 1, "number '1e400' is too big"},
+		{"sqrt(-1)", 1, "sqrt(): square root of a negative number"},
+		{"foo(1)", 1, "unknown function 'foo'"},
+		{"x + 1", 1, "unknown variable 'x'"},
+		{"round(1, 2, 3)", 1, "round() takes 1 to 2 arguments"},
+		{"pi = 3", 1, "can't assign to constant 'pi'"},
+		{"2 3", 3, "unexpected '3'"},
+	} {
+		t.Run(tc.input, func(t *testing.T) {
+			_, _, err := Evaluate(tc.input, nil)
+
+			var calcErr *Error
+			require.ErrorAs(t, err, &calcErr)
+			require.Equal(t, tc.pos, calcErr.Pos)
+			require.Equal(t, tc.msg, calcErr.Msg)
+		})
+	}
+}
+
+func TestSessions(t *testing.T) {
+	var sessions Sessions
+
+	result, err := sessions.Evaluate("alice", "x = 5")
+	require.NoError(t, err)
+	require.Equal(t, "x = 5", result)
+
+	result, err = sessions.Evaluate("alice", "x * 2")
+	require.NoError(t, err)
+	require.Equal(t, "10", result)
+
+	result, err = sessions.Evaluate("alice", "ans + x")
+	require.NoError(t, err)
+	require.Equal(t, "15", result)
+
+	//Variables are per user
+	_, err = sessions.Evaluate("bob", "x")
+	require.Error(t, err)
+
+	_, err = sessions.Evaluate("bob", "ans = 1")
+	require.Error(t, err)
+}
+
+func TestConvert(t *testing.T) {
+	for _, tc := range []struct {
+		input    string
+		expected string
+	}{
+		{"10 km to mi", "10 km = 6.21371 mi"},
+		{"72 F to C", "72 F = 22.2222 °C"},
+		{"-40 c in f", "-40 c = -40 °F"},
+		{"0 K to celsius", "0 K = -273.15 °C"},
+		{`5'11" to cm`, `5'11" = 180.34 cm`},
+		{"5 ft 11 in to cm", "5 ft 11 in = 180.34 cm"},
+		{"12 in to ft", "12 in = 1 ft"},
+		{"1h30m to min", "1h30m = 90 min"},
+		{"2d 4h to hours", "2d 4h = 52 h"},
+		{"90 min to h", "90 min = 1.5 h"},
+		{"1 GiB to MB", "1 GiB = 1073.74 MB"},
+		{"100 Mb to MB", "100 Mb = 12.5 MB"},
+		{"8 b to B", "8 b = 1 B"},
+		{"100 km/h to mph", "100 km/h = 62.1371 mph"},
+		{"10 kn to km/h", "10 kn = 18.52 km/h"},
+		{"1 lb to g", "1 lb = 453.592 g"},
+		{"2*5 km to mi", "2*5 km = 6.21371 mi"},
+		{"1e3 m to km", "1e3 m = 1 km"},
+	} {
+		t.Run(tc.input, func(t *testing.T) {
+			require.True(t, IsConversion(tc.input))
+			result, err := Convert(tc.input)
+			require.NoError(t, err)
+			require.Equal(t, tc.expected, result)
+		})
+	}
+}
+
+func TestConvertErrors(t *testing.T) {
+	for _, tc := range []struct {
+		input string
+		msg   string
+	}{
+		{"10 km", "expected <amount> <unit> to <unit>"},
+		{"10 km to parsec", "unknown unit 'parsec'"},
+		{"10 furlongs to m", "unknown unit 'furlongs'"},
+		{"10 km to kg", "can't convert length to mass"},
+		{"5 ft 3 kg to m", "can't mix length and mass in one amount"},
+		{"10 C 5 F to K", "temperatures can't be combined"},
+		{"km to mi", "expected <amount> <unit> to <unit>"},
+	} {
+		t.Run(tc.input, func(t *testing.T) {
+			_, err := Convert(tc.input)
+			require.EqualError(t, err, tc.msg)
+		})
+	}
+
+	require.False(t, IsConversion("2 + 2"))
+}
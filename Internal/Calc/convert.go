@@ -0,0 +1,143 @@
+package calc
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"regexp"
+	"strconv"
+	"strings"
+	"unicode"
+)
+
+var (
+	//The first group is greedy, so "12 in to cm" splits on "to" and not on the inch
+	conversionPattern = regexp.MustCompile(`(?i)^\s*(.+)\s+(?:to|in|->)\s+(\S+)\s*$`)
+	//A number followed by a unit, several parts make composite amounts like 5'11" or 1h30m
+	amountPart       = `(\d+(?:\.\d*)?|\.\d+)\s*([^\d\s.,]+)\s*`
+	amountPartRegexp = regexp.MustCompile(amountPart)
+	compositePattern = regexp.MustCompile(`^\s*([-+]?)\s*(?:` + amountPart + `)+$`)
+)
+
+// IsConversion reports whether the input looks like a unit conversion ("10 km to mi") rather than an expression.
+func IsConversion(input string) bool {
+	return conversionPattern.MatchString(input)
+}
+
+// Convert converts an amount between units of the same kind, e.g. "10 km to mi", "72 F in C", "5'11\" to cm" or "1h30m to min".
+// The amount can also be an expression followed by a unit ("2*5 km to mi"). It returns the formatted answer, e.g. "10 km = 6.21371 mi".
+func Convert(input string) (string, error) {
+	if len([]rune(input)) > maxInputLength {
+		return "", fmt.Errorf("input is too long (max %d characters)", maxInputLength)
+	}
+
+	match := conversionPattern.FindStringSubmatch(input)
+	if match == nil {
+		return "", errors.New("expected <amount> <unit> to <unit>")
+	}
+	amount, target := strings.TrimSpace(match[1]), match[2]
+
+	to, ok := lookupUnit(target)
+	if !ok {
+		return "", fmt.Errorf("unknown unit '%s'", target)
+	}
+
+	base, from, err := parseAmount(amount)
+	if err != nil {
+		return "", err
+	}
+	if from.dimension != to.dimension {
+		return "", fmt.Errorf("can't convert %s to %s", from.dimension, to.dimension)
+	}
+
+	result := (base - to.offset) / to.factor
+	if math.IsInf(result, 0) || math.IsNaN(result) {
+		return "", errors.New("result is too big")
+	}
+	return fmt.Sprintf("%s = %s %s", amount, formatConverted(result), to.symbol), nil
+}
+
+// parseAmount returns the amount in the base unit of its dimension and the unit it was given in
+// (the first one for composite amounts).
+func parseAmount(amount string) (float64, unit, error) {
+	if !compositePattern.MatchString(amount) {
+		return parseExpressionAmount(amount)
+	}
+
+	base, from, err := parseComposite(amount)
+	if err == nil {
+		return base, from, nil
+	}
+	//Things like "1e3 m" or "5-3 km" look like composite amounts with weird units
+	if base, from, exprErr := parseExpressionAmount(amount); exprErr == nil {
+		return base, from, nil
+	}
+	return 0, unit{}, err
+}
+
+func parseComposite(amount string) (float64, unit, error) {
+	sign := 1.0
+	if strings.HasPrefix(strings.TrimSpace(amount), "-") {
+		sign = -1
+	}
+
+	parts := amountPartRegexp.FindAllStringSubmatch(amount, -1)
+	units := make([]unit, len(parts))
+	isDuration := false
+	for i, part := range parts {
+		u, ok := lookupUnit(part[2])
+		if !ok {
+			return 0, unit{}, fmt.Errorf("unknown unit '%s'", part[2])
+		}
+		units[i] = u
+		isDuration = isDuration || (u.dimension == dimTime && len(parts) > 1)
+	}
+
+	var total float64
+	for i, part := range parts {
+		//In durations like 1h30m "m" means minutes, not meters
+		if isDuration && strings.EqualFold(part[2], "m") {
+			units[i], _ = lookupUnit("min")
+		}
+		if units[i].dimension != units[0].dimension {
+			return 0, unit{}, fmt.Errorf("can't mix %s and %s in one amount", units[0].dimension, units[i].dimension)
+		}
+		if units[i].dimension == dimTemperature && len(parts) > 1 {
+			return 0, unit{}, errors.New("temperatures can't be combined")
+		}
+
+		value, err := strconv.ParseFloat(part[1], 64)
+		if err != nil {
+			return 0, unit{}, fmt.Errorf("invalid number '%s'", part[1])
+		}
+		total += value * units[i].factor
+	}
+
+	return sign*total + units[0].offset, units[0], nil
+}
+
+// parseExpressionAmount handles "<expression> <unit>", the unit is the last word.
+func parseExpressionAmount(amount string) (float64, unit, error) {
+	idx := strings.LastIndexFunc(amount, unicode.IsSpace)
+	if idx < 0 {
+		return 0, unit{}, errors.New("expected <amount> <unit> to <unit>")
+	}
+
+	name := amount[idx+1:]
+	from, ok := lookupUnit(name)
+	if !ok {
+		return 0, unit{}, fmt.Errorf("unknown unit '%s'", name)
+	}
+
+	value, _, err := Evaluate(strings.TrimSpace(amount[:idx]), nil)
+	if err != nil {
+		return 0, unit{}, err
+	}
+	return value*from.factor + from.offset, from, nil
+}
+
+// formatConverted rounds the result to 6 significant digits, unit factors aren't more precise than that anyway.
+func formatConverted(value float64) string {
+	rounded, _ := strconv.ParseFloat(strconv.FormatFloat(value, 'g', 6, 64), 64)
+	return Format(rounded)
+}
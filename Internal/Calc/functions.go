@@ -0,0 +1,103 @@
+package calc
+
+import (
+	"errors"
+	"fmt"
+	"math"
+)
+
+var constants = map[string]float64{
+	"pi": math.Pi,
+	"e":  math.E,
+}
+
+type function struct {
+	minArgs int
+	//-1 means any number of arguments
+	maxArgs int
+	call    func(args []float64) (float64, error)
+}
+
+func (f function) arity() string {
+	switch {
+	case f.maxArgs < 0:
+		return fmt.Sprintf("at least %d argument(s)", f.minArgs)
+	case f.minArgs == f.maxArgs:
+		return fmt.Sprintf("%d argument(s)", f.minArgs)
+	default:
+		return fmt.Sprintf("%d to %d arguments", f.minArgs, f.maxArgs)
+	}
+}
+
+func unary(fn func(float64) float64) function {
+	return function{minArgs: 1, maxArgs: 1, call: func(args []float64) (float64, error) {
+		return fn(args[0]), nil
+	}}
+}
+
+var functions = map[string]function{
+	"sqrt": {minArgs: 1, maxArgs: 1, call: func(args []float64) (float64, error) {
+		if args[0] < 0 {
+			return 0, errors.New("square root of a negative number")
+		}
+		return math.Sqrt(args[0]), nil
+	}},
+	"abs":   unary(math.Abs),
+	"sin":   unary(math.Sin),
+	"cos":   unary(math.Cos),
+	"tan":   unary(math.Tan),
+	"exp":   unary(math.Exp),
+	"floor": unary(math.Floor),
+	"ceil":  unary(math.Ceil),
+	"ln": {minArgs: 1, maxArgs: 1, call: func(args []float64) (float64, error) {
+		return logarithm(args[0], math.E)
+	}},
+	//log(x) is base 10, log(x, base) for any other base
+	"log": {minArgs: 1, maxArgs: 2, call: func(args []float64) (float64, error) {
+		if len(args) == 2 {
+			return logarithm(args[0], args[1])
+		}
+		return logarithm(args[0], 10)
+	}},
+	//round(x) or round(x, digits)
+	"round": {minArgs: 1, maxArgs: 2, call: func(args []float64) (float64, error) {
+		if len(args) == 1 {
+			return math.Round(args[0]), nil
+		}
+		digits := args[1]
+		if digits != math.Trunc(digits) || digits < -15 || digits > 15 {
+			return 0, errors.New("digits should be a whole number from -15 to 15")
+		}
+		scale := math.Pow(10, digits)
+		return math.Round(args[0]*scale) / scale, nil
+	}},
+	"min": {minArgs: 1, maxArgs: -1, call: func(args []float64) (float64, error) {
+		result := args[0]
+		for _, arg := range args[1:] {
+			result = math.Min(result, arg)
+		}
+		return result, nil
+	}},
+	"max": {minArgs: 1, maxArgs: -1, call: func(args []float64) (float64, error) {
+		result := args[0]
+		for _, arg := range args[1:] {
+			result = math.Max(result, arg)
+		}
+		return result, nil
+	}},
+}
+
+func logarithm(x float64, base float64) (float64, error) {
+	if x <= 0 {
+		return 0, errors.New("logarithm of a non-positive number")
+	}
+	switch {
+	case base <= 0 || base == 1:
+		return 0, errors.New("base should be positive and not 1")
+	case base == 10:
+		return math.Log10(x), nil
+	case base == 2:
+		return math.Log2(x), nil
+	}
+	return math.Log(x) / math.Log(base), nil
+}
@@ -0,0 +1,127 @@
+package calc
+
+import (
+	"fmt"
+	"math"
+	"strconv"
+	"unicode"
+)
+
+type tokenKind int
+
+const (
+	tokenNumber tokenKind = iota
+	tokenIdent
+	tokenOperator
+	tokenLParen
+	tokenRParen
+	tokenComma
+	tokenAssign
+	tokenEOF
+)
+
+type token struct {
+	kind  tokenKind
+	text  string
+	value float64
+	//1-based position of the first character, for error messages
+	pos int
+}
+
+// Error points to the position (1-based, in characters) of the problem in the expression.
+type Error struct {
+	Pos int
+	Msg string
+}
+
+func (e *Error) Error() string {
+	return fmt.Sprintf("%s at position %d", e.Msg, e.Pos)
+}
+
+func errorAt(pos int, format string, args ...any) *Error {
+	return &Error{Pos: pos, Msg: fmt.Sprintf(format, args...)}
+}
+
+func tokenize(input string) ([]token, error) {
+	runes := []rune(input)
+	var tokens []token
+
+	for i := 0; i < len(runes); {
+		r := runes[i]
+		pos := i + 1
+
+		switch {
+		case unicode.IsSpace(r):
+			i++
+
+		case unicode.IsDigit(r) || (r == '.' && i+1 < len(runes) && unicode.IsDigit(runes[i+1])):
+			start := i
+			for i < len(runes) && (unicode.IsDigit(runes[i]) || runes[i] == '.') {
+				i++
+			}
+			//Exponent is taken only if digits follow, so "2e" stays a number and the constant e
+			if i < len(runes) && (runes[i] == 'e' || runes[i] == 'E') {
+				j := i + 1
+				if j < len(runes) && (runes[j] == '+' || runes[j] == '-') {
+					j++
+				}
+				if j < len(runes) && unicode.IsDigit(runes[j]) {
+					for j < len(runes) && unicode.IsDigit(runes[j]) {
+						j++
+					}
+					i = j
+				}
+			}
+
+			text := string(runes[start:i])
+			value, err := strconv.ParseFloat(text, 64)
+			if err != nil && !math.IsInf(value, 0) {
+				return nil, errorAt(pos, "invalid number '%s'", text)
+			}
+			if math.IsInf(value, 0) {
+				return nil, errorAt(pos, "number '%s' is too big", text)
+			}
+			tokens = append(tokens, token{kind: tokenNumber, text: text, value: value, pos: pos})
+
+		case unicode.IsLetter(r) || r == '_':
+			start := i
+			for i < len(runes) && (unicode.IsLetter(runes[i]) || unicode.IsDigit(runes[i]) || runes[i] == '_') {
+				i++
+			}
+			tokens = append(tokens, token{kind: tokenIdent, text: string(runes[start:i]), pos: pos})
+
+		case r == '*' && i+1 < len(runes) && runes[i+1] == '*':
+			tokens = append(tokens, token{kind: tokenOperator, text: "^", pos: pos})
+			i += 2
+
+		case r == '+' || r == '-' || r == '*' || r == '/' || r == '%' || r == '^':
+			tokens = append(tokens, token{kind: tokenOperator, text: string(r), pos: pos})
+			i++
+
+		//Chat and phone keyboards like to put these instead of * / -
+		case r == '×' || r == '÷' || r == '−':
+			text := map[rune]string{'×': "*", '÷': "/", '−': "-"}[r]
+			tokens = append(tokens, token{kind: tokenOperator, text: text, pos: pos})
+			i++
+
+		case r == '(':
+			tokens = append(tokens, token{kind: tokenLParen, text: "(", pos: pos})
+			i++
+		case r == ')':
+			tokens = append(tokens, token{kind: tokenRParen, text: ")", pos: pos})
+			i++
+		case r == ',':
+			tokens = append(tokens, token{kind: tokenComma, text: ",", pos: pos})
+			i++
+		case r == '=':
+			tokens = append(tokens, token{kind: tokenAssign, text: "=", pos: pos})
+			i++
+
+		default:
+			return nil, errorAt(pos, "unexpected character '%c'", r)
+		}
+	}
+
+	tokens = append(tokens, token{kind: tokenEOF, pos: len(runes) + 1})
+	return tokens, nil
+}
@@ -0,0 +1,287 @@
+package calc
+
+import (
+	"math"
+)
+
+const (
+	maxInputLength = 256
+	maxDepth       = 64
+)
+
+// Grammar, evaluated while parsing:
+//
+//	statement  = [ident "="] expression
+//	expression = term {("+" | "-") term}
+//	term       = unary {("*" | "/" | "%") unary}
+//	unary      = ("-" | "+") unary | power
+//	power      = primary ["^" unary]          (right-associative, so -2^2 = -4 and 2^-1 = 0.5)
+//	primary    = number | ident | ident "(" [expression {"," expression}] ")" | "(" expression ")"
+type parser struct {
+	tokens []token
+	pos    int
+	depth  int
+	vars   map[string]float64
+}
+
+// Evaluate evaluates the expression, vars are the variables of the user (ans included).
+// If the input is an assignment ("x = 5"), the name of the assigned variable is returned too.
+func Evaluate(input string, vars map[string]float64) (float64, string, error) {
+	if len([]rune(input)) > maxInputLength {
+		return 0, "", errorAt(maxInputLength+1, "expression is too long (max %d characters)", maxInputLength)
+	}
+
+	tokens, err := tokenize(input)
+	if err != nil {
+		return 0, "", err
+	}
+
+	p := &parser{tokens: tokens, vars: vars}
+	if p.peek().kind == tokenEOF {
+		return 0, "", errorAt(1, "empty expression")
+	}
+
+	var variable string
+	if p.peek().kind == tokenIdent && p.tokens[p.pos+1].kind == tokenAssign {
+		name := p.next()
+		if err := checkVariableName(name); err != nil {
+			return 0, "", err
+		}
+		variable = name.text
+		p.next()
+	}
+
+	value, err := p.expression()
+	if err != nil {
+		return 0, "", err
+	}
+
+	if tok := p.peek(); tok.kind != tokenEOF {
+		return 0, "", errorAt(tok.pos, "unexpected '%s'", tok.text)
+	}
+
+	return value, variable, nil
+}
+
+func checkVariableName(name token) error {
+	if _, ok := constants[name.text]; ok {
+		return errorAt(name.pos, "can't assign to constant '%s'", name.text)
+	}
+	if _, ok := functions[name.text]; ok {
+		return errorAt(name.pos, "can't assign to function '%s'", name.text)
+	}
+	if name.text == "ans" {
+		return errorAt(name.pos, "'ans' is the last result and can't be assigned")
+	}
+	return nil
+}
+
+func (p *parser) peek() token {
+	return p.tokens[p.pos]
+}
+
+func (p *parser) next() token {
+	tok := p.tokens[p.pos]
+	if tok.kind != tokenEOF {
+		p.pos++
+	}
+	return tok
+}
+
+func (p *parser) expression() (float64, error) {
+	p.depth++
+	defer func() { p.depth-- }()
+	if p.depth > maxDepth {
+		return 0, errorAt(p.peek().pos, "expression is nested too deep")
+	}
+
+	left, err := p.term()
+	if err != nil {
+		return 0, err
+	}
+
+	for tok := p.peek(); tok.kind == tokenOperator && (tok.text == "+" || tok.text == "-"); tok = p.peek() {
+		p.next()
+		right, err := p.term()
+		if err != nil {
+			return 0, err
+		}
+
+		if tok.text == "+" {
+			left += right
+		} else {
+			left -= right
+		}
+		if err := checkResult(left, tok); err != nil {
+			return 0, err
+		}
+	}
+	return left, nil
+}
+
+func (p *parser) term() (float64, error) {
+	left, err := p.unary()
+	if err != nil {
+		return 0, err
+	}
+
+	for tok := p.peek(); tok.kind == tokenOperator && (tok.text == "*" || tok.text == "/" || tok.text == "%"); tok = p.peek() {
+		p.next()
+		right, err := p.unary()
+		if err != nil {
+			return 0, err
+		}
+
+		switch tok.text {
+		case "*":
+			left *= right
+		case "/":
+			if right == 0 {
+				return 0, errorAt(tok.pos, "division by zero")
+			}
+			left /= right
+		case "%":
+			if right == 0 {
+				return 0, errorAt(tok.pos, "modulo by zero")
+			}
+			left = math.Mod(left, right)
+		}
+		if err := checkResult(left, tok); err != nil {
+			return 0, err
+		}
+	}
+	return left, nil
+}
+
+func (p *parser) unary() (float64, error) {
+	if tok := p.peek(); tok.kind == tokenOperator && (tok.text == "-" || tok.text == "+") {
+		p.depth++
+		defer func() { p.depth-- }()
+		if p.depth > maxDepth {
+			return 0, errorAt(tok.pos, "expression is nested too deep")
+		}
+
+		p.next()
+		value, err := p.unary()
+		if tok.text == "-" {
+			value = -value
+		}
+		return value, err
+	}
+	return p.power()
+}
+
+func (p *parser) power() (float64, error) {
+	base, err := p.primary()
+	if err != nil {
+		return 0, err
+	}
+
+	if tok := p.peek(); tok.kind == tokenOperator && tok.text == "^" {
+		p.next()
+		exponent, err := p.unary()
+		if err != nil {
+			return 0, err
+		}
+
+		if base == 0 && exponent < 0 {
+			return 0, errorAt(tok.pos, "division by zero")
+		}
+		result := math.Pow(base, exponent)
+		if err := checkResult(result, tok); err != nil {
+			return 0, err
+		}
+		return result, nil
+	}
+	return base, nil
+}
+
+func (p *parser) primary() (float64, error) {
+	tok := p.next()
+	switch tok.kind {
+	case tokenNumber:
+		return tok.value, nil
+
+	case tokenLParen:
+		value, err := p.expression()
+		if err != nil {
+			return 0, err
+		}
+		if closing := p.next(); closing.kind != tokenRParen {
+			return 0, errorAt(closing.pos, "missing ')' for '(' at position %d", tok.pos)
+		}
+		return value, nil
+
+	case tokenIdent:
+		if p.peek().kind == tokenLParen {
+			return p.call(tok)
+		}
+		if value, ok := constants[tok.text]; ok {
+			return value, nil
+		}
+		if value, ok := p.vars[tok.text]; ok {
+			return value, nil
+		}
+		if _, ok := functions[tok.text]; ok {
+			return 0, errorAt(tok.pos, "function '%s' needs arguments in brackets", tok.text)
+		}
+		return 0, errorAt(tok.pos, "unknown variable '%s'", tok.text)
+
+	case tokenEOF:
+		return 0, errorAt(tok.pos, "unexpected end of expression")
+
+	default:
+		return 0, errorAt(tok.pos, "unexpected '%s'", tok.text)
+	}
+}
+
+func (p *parser) call(name token) (float64, error) {
+	fn, ok := functions[name.text]
+	if !ok {
+		return 0, errorAt(name.pos, "unknown function '%s'", name.text)
+	}
+	p.next()
+
+	var args []float64
+	if p.peek().kind != tokenRParen {
+		for {
+			arg, err := p.expression()
+			if err != nil {
+				return 0, err
+			}
+			args = append(args, arg)
+
+			if p.peek().kind != tokenComma {
+				break
+			}
+			p.next()
+		}
+	}
+
+	if closing := p.next(); closing.kind != tokenRParen {
+		return 0, errorAt(closing.pos, "missing ')' for %s(", name.text)
+	}
+
+	if len(args) < fn.minArgs || (fn.maxArgs >= 0 && len(args) > fn.maxArgs) {
+		return 0, errorAt(name.pos, "%s() takes %s", name.text, fn.arity())
+	}
+
+	result, err := fn.call(args)
+	if err != nil {
+		return 0, errorAt(name.pos, "%s(): %s", name.text, err)
+	}
+	if err := checkResult(result, name); err != nil {
+		return 0, err
+	}
+	return result, nil
+}
+
+func checkResult(value float64, tok token) error {
+	if math.IsInf(value, 0) {
+		return errorAt(tok.pos, "result of '%s' is too big", tok.text)
+	}
+	if math.IsNaN(value) {
+		return errorAt(tok.pos, "result of '%s' is not a number", tok.text)
+	}
+	return nil
+}
@@ -0,0 +1,61 @@
+package calc
+
+import (
+	"fmt"
+	"math"
+	"strconv"
+	"sync"
+)
+
+const maxVariables = 20
+
+// Sessions keeps ans and named variables for every user, it's safe for concurrent use.
+type Sessions struct {
+	mutex sync.Mutex
+	vars  map[string]map[string]float64
+}
+
+// Evaluate evaluates the input in the context of the user. The result is saved as ans, assignments also save the variable.
+// It returns the formatted answer, e.g. "4" or "x = 5".
+func (s *Sessions) Evaluate(user string, input string) (string, error) {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	if s.vars == nil {
+		s.vars = make(map[string]map[string]float64)
+	}
+	vars, ok := s.vars[user]
+	if !ok {
+		vars = make(map[string]float64)
+		s.vars[user] = vars
+	}
+
+	value, variable, err := Evaluate(input, vars)
+	if err != nil {
+		return "", err
+	}
+
+	vars["ans"] = value
+	if variable == "" {
+		return Format(value), nil
+	}
+
+	//ans takes one of the slots
+	if _, exists := vars[variable]; !exists && len(vars) > maxVariables {
+		return "", fmt.Errorf("too many variables (max %d)", maxVariables)
+	}
+	vars[variable] = value
+	return fmt.Sprintf("%s = %s", variable, Format(value)), nil
+}
+
+// Format prints whole numbers without exponent up to 1e15 and everything else with 12 significant digits.
+func Format(value float64) string {
+	if value == 0 {
+		//Avoid "-0"
+		return "0"
+	}
+	if value == math.Trunc(value) && math.Abs(value) < 1e15 {
+		return strconv.FormatFloat(value, 'f', -1, 64)
+	}
+	return strconv.FormatFloat(value, 'g', 12, 64)
+}
@@ -0,0 +1,118 @@
+package calc
+
+import (
+	"strings"
+)
+
+type dimension string
+
+const (
+	dimLength      dimension = "length"
+	dimMass        dimension = "mass"
+	dimTemperature dimension = "temperature"
+	dimTime        dimension = "time"
+	dimData        dimension = "data size"
+	dimSpeed       dimension = "speed"
+)
+
+// unit converts to the base unit of its dimension as value*factor + offset.
+// Only temperatures have an offset (the base is kelvin).
+type unit struct {
+	symbol    string
+	dimension dimension
+	factor    float64
+	offset    float64
+}
+
+type unitDef struct {
+	unit
+	aliases []string
+}
+
+var unitDefs = []unitDef{
+	//Length, base is meter
+	{unit{"mm", dimLength, 0.001, 0}, []string{"millimeter", "millimeters", "millimetre", "millimetres"}},
+	{unit{"cm", dimLength, 0.01, 0}, []string{"centimeter", "centimeters", "centimetre", "centimetres"}},
+	{unit{"m", dimLength, 1, 0}, []string{"meter", "meters", "metre", "metres"}},
+	{unit{"km", dimLength, 1000, 0}, []string{"kilometer", "kilometers", "kilometre", "kilometres"}},
+	{unit{"in", dimLength, 0.0254, 0}, []string{"inch", "inches", `"`, "″"}},
+	{unit{"ft", dimLength, 0.3048, 0}, []string{"foot", "feet", "'", "′"}},
+	{unit{"yd", dimLength, 0.9144, 0}, []string{"yard", "yards"}},
+	{unit{"mi", dimLength, 1609.344, 0}, []string{"mile", "miles"}},
+	{unit{"nmi", dimLength, 1852, 0}, []string{"nauticalmile", "nauticalmiles"}},
+
+	//Mass, base is kilogram
+	{unit{"mg", dimMass, 1e-6, 0}, []string{"milligram", "milligrams"}},
+	{unit{"g", dimMass, 0.001, 0}, []string{"gram", "grams"}},
+	{unit{"kg", dimMass, 1, 0}, []string{"kilogram", "kilograms", "kilo", "kilos"}},
+	{unit{"t", dimMass, 1000, 0}, []string{"tonne", "tonnes", "ton", "tons"}},
+	{unit{"oz", dimMass, 0.028349523125, 0}, []string{"ounce", "ounces"}},
+	{unit{"lb", dimMass, 0.45359237, 0}, []string{"lbs", "pound", "pounds"}},
+	{unit{"st", dimMass, 6.35029318, 0}, []string{"stone", "stones"}},
+
+	//Temperature, base is kelvin
+	{unit{"°C", dimTemperature, 1, 273.15}, []string{"c", "degc", "celsius"}},
+	{unit{"°F", dimTemperature, 5.0 / 9.0, 459.67 * 5.0 / 9.0}, []string{"f", "degf", "fahrenheit"}},
+	{unit{"K", dimTemperature, 1, 0}, []string{"k", "kelvin", "kelvins"}},
+
+	//Time, base is second
+	{unit{"ms", dimTime, 0.001, 0}, []string{"millisecond", "milliseconds"}},
+	{unit{"s", dimTime, 1, 0}, []string{"sec", "secs", "second", "seconds"}},
+	{unit{"min", dimTime, 60, 0}, []string{"mins", "minute", "minutes"}},
+	{unit{"h", dimTime, 3600, 0}, []string{"hr", "hrs", "hour", "hours"}},
+	{unit{"d", dimTime, 86400, 0}, []string{"day", "days"}},
+	{unit{"wk", dimTime, 604800, 0}, []string{"week", "weeks"}},
+	{unit{"yr", dimTime, 31557600, 0}, []string{"year", "years"}},
+
+	//Data size, base is byte. KB/MB/... are decimal, KiB/MiB/... are binary
+	{unit{"bit", dimData, 0.125, 0}, []string{"bits"}},
+	{unit{"B", dimData, 1, 0}, []string{"byte", "bytes"}},
+	{unit{"KB", dimData, 1e3, 0}, []string{"kilobyte", "kilobytes"}},
+	{unit{"MB", dimData, 1e6, 0}, []string{"megabyte", "megabytes"}},
+	{unit{"GB", dimData, 1e9, 0}, []string{"gigabyte", "gigabytes"}},
+	{unit{"TB", dimData, 1e12, 0}, []string{"terabyte", "terabytes"}},
+	{unit{"KiB", dimData, 1 << 10, 0}, []string{"kibibyte", "kibibytes"}},
+	{unit{"MiB", dimData, 1 << 20, 0}, []string{"mebibyte", "mebibytes"}},
+	{unit{"GiB", dimData, 1 << 30, 0}, []string{"gibibyte", "gibibytes"}},
+	{unit{"TiB", dimData, 1 << 40, 0}, []string{"tebibyte", "tebibytes"}},
+	{unit{"Mbit", dimData, 125000, 0}, []string{"megabit", "megabits"}},
+	{unit{"Gbit", dimData, 125000000, 0}, []string{"gigabit", "gigabits"}},
+
+	//Speed, base is meter per second
+	{unit{"m/s", dimSpeed, 1, 0}, []string{"mps"}},
+	{unit{"km/h", dimSpeed, 1000.0 / 3600.0, 0}, []string{"kmh", "kph"}},
+	{unit{"mph", dimSpeed, 1609.344 / 3600.0, 0}, []string{"mi/h"}},
+	{unit{"ft/s", dimSpeed, 0.3048, 0}, []string{"fps"}},
+	{unit{"kn", dimSpeed, 1852.0 / 3600.0, 0}, []string{"knot", "knots", "kt"}},
+}
+
+// Symbols that mean different units depending on the case, they are matched exactly.
+var caseSensitiveUnits = map[string]string{
+	"b":  "bit",
+	"B":  "B",
+	"Mb": "Mbit",
+	"Gb": "Gbit",
+}
+
+var unitsByName = buildUnitIndex()
+
+func buildUnitIndex() map[string]unit {
+	index := make(map[string]unit)
+	for _, def := range unitDefs {
+		index[strings.ToLower(def.symbol)] = def.unit
+		for _, alias := range def.aliases {
+			index[strings.ToLower(alias)] = def.unit
+		}
+	}
+	//Without the degree sign
+	index["°c"], index["°f"] = index["c"], index["f"]
+	return index
+}
+
+func lookupUnit(name string) (unit, bool) {
+	if symbol, ok := caseSensitiveUnits[name]; ok {
+		return unitsByName[strings.ToLower(symbol)], true
+	}
+	u, ok := unitsByName[strings.ToLower(name)]
+	return u, ok
+}
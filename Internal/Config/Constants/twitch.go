@@ -1,13 +1,41 @@
 package constants
 
+import "time"
+
 const (
-	Channel       = "gladarfin"
-	BotUsername   = "gladarfin_bot"
-	ConfigDir     = "Internal/Config"
-	TokenFile     = ".client"
-	GreetingsFile = "hello.txt"
-	FriendsFile   = "friends.txt"
-	HelixFile     = ".twHelix"
-	DbConfigFile  = "database.json"
-	DuelsFile     = "duels.json"
+	//Defaults of twitch.channel and twitch.bot_username in the settings
+	Channel          = "gladarfin"
+	BotUsername      = "gladarfin_bot"
+	ConfigDir        = "Internal/Config"
+	SettingsFile     = "config.yaml"
+	TokenFile        = ".client"
+	GreetingsFile    = "hello.txt"
+	FriendsFile      = "friends.txt"
+	HelixFile        = ".twHelix"
+	DbConfigFile     = "database.json"
+	DuelsFile        = "duels.json"
+	TriviaDir        = "Trivia"
+	BossesFile       = "bosses.json"
+	LevelsFile       = "levels.json"
+	ItemsFile        = "items.json"
+	AchievementsFile = "achievements.json"
+	WelcomeFile      = "welcome.json"
+
+	AutoShoutoutOnRaid = true
+	MirrorPollsToHelix = true
+
+	//Share of points returned into free-points by !down
+	StatRefundRatio = 0.5
+	RespecCooldown  = 7 * 24 * time.Hour
+
+	//IANA timezone that defines the calendar day for !daily (e.g. "Europe/Berlin")
+	ChannelTimezone = "UTC"
+	DailyReward     = 1
+	//Missed days that don't break the !daily streak
+	DailyGraceDays = 1
+
+	//How long HowLongToBeat results are kept in the database before searching again
+	HLTBCacheTTL = 7 * 24 * time.Hour
+	//Top matches that can be picked with !hl <title> #N
+	HLTBMaxResults = 5
 )
@@ -0,0 +1,13 @@
+package config
+
+// Achievement unlocks when its Event has happened Count times, or when the value carried by the event
+// (e.g. the new stat value) reaches Value. For "stat" events Stat selects the stat.
+type Achievement struct {
+	ID          string `json:"ID"`
+	Name        string `json:"Name"`
+	Description string `json:"Description"`
+	Event       string `json:"Event"`
+	Stat        string `json:"Stat,omitempty"`
+	Count       int    `json:"Count,omitempty"`
+	Value       int    `json:"Value,omitempty"`
+}
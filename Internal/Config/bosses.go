@@ -0,0 +1,53 @@
+package config
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+)
+
+// ValidateBosses checks that every boss has a name, positive HP, a non-negative reward and
+// messages with exactly one %s, which is replaced by the name of the boss.
+func ValidateBosses(bosses []BossMsg) error {
+	if len(bosses) == 0 {
+		return errors.New("there should be at least one boss")
+	}
+
+	var errs []error
+	for i, boss := range bosses {
+		if boss.Name == "" {
+			errs = append(errs, fmt.Errorf("boss #%d: Name is required", i+1))
+		}
+		if boss.HP <= 0 {
+			errs = append(errs, fmt.Errorf("boss #%d: HP should be greater than 0", i+1))
+		}
+		if boss.Reward < 0 {
+			errs = append(errs, fmt.Errorf("boss #%d: Reward can't be negative", i+1))
+		}
+
+		messages := []struct{ field, message string }{
+			{"SpawnMessage", boss.SpawnMessage},
+			{"VictoryMessage", boss.VictoryMessage},
+			{"DefeatMessage", boss.DefeatMessage},
+		}
+		for _, m := range messages {
+			if strings.Count(m.message, "%s") != 1 {
+				errs = append(errs, fmt.Errorf("boss #%d: %s should have exactly one %%s for the boss name", i+1, m.field))
+			}
+		}
+	}
+	return errors.Join(errs...)
+}
+
+// LoadBosses loads and validates the bosses file.
+func LoadBosses(path string) ([]BossMsg, error) {
+	bosses, err := LoadFromJSON[[]BossMsg](path)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := ValidateBosses(bosses); err != nil {
+		return nil, err
+	}
+	return bosses, nil
+}
@@ -0,0 +1,31 @@
+package config
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestShippedBossesAreValid(t *testing.T) {
+	bosses, err := LoadBosses("bosses.json")
+	require.NoError(t, err)
+	require.NotEmpty(t, bosses)
+}
+
+func TestValidateBosses(t *testing.T) {
+	err := ValidateBosses([]BossMsg{
+		{Name: "Slime", HP: 100, Reward: 2, SpawnMessage: "A %s appears!", VictoryMessage: "The %s melts.", DefeatMessage: "The %s wins."},
+		{Name: "Dragon", HP: 0, Reward: -1, SpawnMessage: "A %s appears!", VictoryMessage: "The %s falls.", DefeatMessage: "The %s wins."},
+		{Name: "", HP: 10, SpawnMessage: "A boss appears!", VictoryMessage: "The %s and %s fall.", DefeatMessage: "The %s wins."},
+	})
+
+	require.ErrorContains(t, err, "boss #2: HP should be greater than 0")
+	require.ErrorContains(t, err, "boss #2: Reward can't be negative")
+	require.ErrorContains(t, err, "boss #3: Name is required")
+	require.ErrorContains(t, err, "boss #3: SpawnMessage should have exactly one %s for the boss name")
+	require.ErrorContains(t, err, "boss #3: VictoryMessage should have exactly one %s for the boss name")
+	require.NotContains(t, err.Error(), "boss #1")
+	require.NotContains(t, err.Error(), "boss #3: DefeatMessage")
+
+	require.EqualError(t, ValidateBosses(nil), "there should be at least one boss")
+}
@@ -0,0 +1,202 @@
+package config
+
+import (
+	"fmt"
+	"slices"
+	"strconv"
+	"strings"
+)
+
+// DuelStats are the stats that can be used in duel templates as {stat:name}.
+var DuelStats = []string{"strength", "perception", "endurance", "charisma", "intelligence", "agility", "luck"}
+
+// DuelRoles are the values of the duel template placeholders.
+type DuelRoles struct {
+	Initiator  string
+	Challenger string
+	//Empty in draws
+	Winner string
+	Loser  string
+	//Effective stats of the winner (of the initiator in draws), for {stat:name}
+	Stats map[string]int
+}
+
+type templateNode struct {
+	text        string
+	placeholder string
+	choices     [][]templateNode
+}
+
+type templateParser struct {
+	input []rune
+	pos   int
+}
+
+// parseTemplate parses the whole template, the placeholders aren't checked here.
+func parseTemplate(template string) ([]templateNode, error) {
+	p := &templateParser{input: []rune(template)}
+	nodes, err := p.sequence(false)
+	if err != nil {
+		return nil, err
+	}
+	if p.pos < len(p.input) {
+		return nil, fmt.Errorf("unexpected '%c' at position %d", p.input[p.pos], p.pos+1)
+	}
+	return nodes, nil
+}
+
+// sequence reads text and groups until the end of the input, or until '|' or '}' inside a group.
+func (p *templateParser) sequence(inGroup bool) ([]templateNode, error) {
+	var nodes []templateNode
+	var text strings.Builder
+	flush := func() {
+		if text.Len() > 0 {
+			nodes = append(nodes, templateNode{text: text.String()})
+			text.Reset()
+		}
+	}
+
+	for p.pos < len(p.input) {
+		r := p.input[p.pos]
+		switch {
+		//'}}' inside a group closes it, so only '{{' works there
+		case p.pos+1 < len(p.input) && p.input[p.pos+1] == r && (r == '{' || (r == '}' && !inGroup)):
+			text.WriteRune(r)
+			p.pos += 2
+		case r == '{':
+			flush()
+			group, err := p.group()
+			if err != nil {
+				return nil, err
+			}
+			nodes = append(nodes, group)
+		case inGroup && (r == '|' || r == '}'):
+			flush()
+			return nodes, nil
+		case r == '}':
+			return nil, fmt.Errorf("'}' without '{' at position %d", p.pos+1)
+		default:
+			text.WriteRune(r)
+			p.pos++
+		}
+	}
+	flush()
+	return nodes, nil
+}
+
+func (p *templateParser) group() (templateNode, error) {
+	start := p.pos
+	p.pos++
+
+	var choices [][]templateNode
+	for {
+		choice, err := p.sequence(true)
+		if err != nil {
+			return templateNode{}, err
+		}
+		choices = append(choices, choice)
+
+		if p.pos >= len(p.input) {
+			return templateNode{}, fmt.Errorf("'{' at position %d is never closed", start+1)
+		}
+		closing := p.input[p.pos] == '}'
+		p.pos++
+		if closing {
+			break
+		}
+	}
+
+	if len(choices) > 1 {
+		return templateNode{choices: choices}, nil
+	}
+	if len(choices[0]) == 1 && choices[0][0].text != "" {
+		return templateNode{placeholder: strings.TrimSpace(choices[0][0].text)}, nil
+	}
+	return templateNode{}, fmt.Errorf("'{' at position %d should be a placeholder like {winner} or choices like {a|b}", start+1)
+}
+
+// validateTemplate checks the syntax and that every placeholder is one of allowed (or an allowed {stat:name}).
+func validateTemplate(template string, allowed []string, statsAllowed bool) error {
+	nodes, err := parseTemplate(template)
+	if err != nil {
+		return err
+	}
+	return checkPlaceholders(nodes, allowed, statsAllowed)
+}
+
+func checkPlaceholders(nodes []templateNode, allowed []string, statsAllowed bool) error {
+	for _, node := range nodes {
+		for _, choice := range node.choices {
+			if err := checkPlaceholders(choice, allowed, statsAllowed); err != nil {
+				return err
+			}
+		}
+		if node.placeholder == "" {
+			continue
+		}
+
+		if stat, ok := strings.CutPrefix(node.placeholder, "stat:"); ok {
+			if !statsAllowed {
+				return fmt.Errorf("{%s} can't be used here", node.placeholder)
+			}
+			if !slices.Contains(DuelStats, stat) {
+				return fmt.Errorf("unknown stat in {%s}, expected one of: %s", node.placeholder, strings.Join(DuelStats, ", "))
+			}
+			continue
+		}
+		if !slices.Contains(allowed, node.placeholder) {
+			return fmt.Errorf("unknown placeholder {%s}, expected one of: %s", node.placeholder, strings.Join(allowed, ", "))
+		}
+	}
+	return nil
+}
+
+// Duel templates support:
+//
+//	{initiator} {challenger}  the duelists
+//	{winner} {loser}          only in DuelMessage of non-draw duels
+//	{stat:strength}           a stat of the winner (of the initiator in draws), only in DuelMessage
+//	{a|b|c}                   a random choice, choices can contain placeholders and other choices
+//	{{ }}                     literal braces
+//
+// RenderDuelTemplate fills the template, intn picks the random choices (rand.IntN in the bot).
+func RenderDuelTemplate(template string, roles DuelRoles, intn func(n int) int) (string, error) {
+	nodes, err := parseTemplate(template)
+	if err != nil {
+		return "", err
+	}
+
+	var result strings.Builder
+	renderNodes(&result, nodes, roles, intn)
+	return result.String(), nil
+}
+
+func renderNodes(result *strings.Builder, nodes []templateNode, roles DuelRoles, intn func(n int) int) {
+	for _, node := range nodes {
+		switch {
+		case node.choices != nil:
+			renderNodes(result, node.choices[intn(len(node.choices))], roles, intn)
+		case node.placeholder != "":
+			result.WriteString(roles.value(node.placeholder))
+		default:
+			result.WriteString(node.text)
+		}
+	}
+}
+
+func (r DuelRoles) value(placeholder string) string {
+	switch placeholder {
+	case "initiator":
+		return r.Initiator
+	case "challenger":
+		return r.Challenger
+	case "winner":
+		return r.Winner
+	case "loser":
+		return r.Loser
+	}
+	if stat, ok := strings.CutPrefix(placeholder, "stat:"); ok {
+		return strconv.Itoa(r.Stats[stat])
+	}
+	return "{" + placeholder + "}"
+}
@@ -0,0 +1,47 @@
+package config
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestRenderDuelTemplate(t *testing.T) {
+	roles := DuelRoles{
+		Initiator:  "alice",
+		Challenger: "bob",
+		Winner:     "bob",
+		Loser:      "alice",
+		Stats:      map[string]int{"strength": 7},
+	}
+	//Always picks the last choice
+	last := func(n int) int { return n - 1 }
+
+	for _, tc := range []struct {
+		template string
+		want     string
+	}{
+		{"{initiator} vs {challenger}", "alice vs bob"},
+		{"{winner} beats {loser} with {stat:strength} strength", "bob beats alice with 7 strength"},
+		{"{ winner } {swings|strikes}!", "bob strikes!"},
+		{"{loser} {falls|{is thrown out|gives up}}", "alice gives up"},
+		{"{winner} {wins|} {{literally}}", "bob  {literally}"},
+		{"{stat:luck} luck", "0 luck"},
+	} {
+		got, err := RenderDuelTemplate(tc.template, roles, last)
+		require.NoError(t, err, tc.template)
+		require.Equal(t, tc.want, got, tc.template)
+	}
+}
+
+func TestParseTemplateErrors(t *testing.T) {
+	for template, msg := range map[string]string{
+		"{winner":       "'{' at position 1 is never closed",
+		"winner}":       "'}' without '{' at position 7",
+		"{} wins":       "should be a placeholder like {winner} or choices like {a|b}",
+		"{{winner}} {a": "'{' at position 12 is never closed",
+	} {
+		_, err := parseTemplate(template)
+		require.ErrorContains(t, err, msg, template)
+	}
+}
@@ -0,0 +1,132 @@
+package config
+
+import (
+	"bufio"
+	"errors"
+	"fmt"
+	"os"
+	"strings"
+)
+
+var (
+	announcePlaceholders = []string{"initiator", "challenger"}
+	drawPlaceholders     = []string{"initiator", "challenger"}
+	winPlaceholders      = []string{"initiator", "challenger", "winner", "loser"}
+)
+
+// ValidateDuels checks the templates of every duel (see duelTemplate.go for the syntax) and the weights.
+// There should be at least one win and one draw duel.
+func ValidateDuels(duels []DuelMsg) error {
+	var errs []error
+	wins, draws := 0, 0
+	for i, duel := range duels {
+		if duel.AnnounceMessage == "" || duel.DuelMessage == "" {
+			errs = append(errs, fmt.Errorf("duel #%d: AnnounceMessage and DuelMessage are required", i+1))
+			continue
+		}
+
+		if err := validateTemplate(duel.AnnounceMessage, announcePlaceholders, false); err != nil {
+			errs = append(errs, fmt.Errorf("duel #%d: AnnounceMessage: %w", i+1, err))
+		}
+
+		placeholders := winPlaceholders
+		if duel.IsDraw {
+			placeholders = drawPlaceholders
+			draws++
+		} else {
+			wins++
+		}
+		if err := validateTemplate(duel.DuelMessage, placeholders, true); err != nil {
+			errs = append(errs, fmt.Errorf("duel #%d: DuelMessage: %w", i+1, err))
+		}
+
+		if duel.Weight < 0 {
+			errs = append(errs, fmt.Errorf("duel #%d: Weight can't be negative", i+1))
+		}
+	}
+
+	if wins == 0 || draws == 0 {
+		errs = append(errs, fmt.Errorf("there should be at least one win and one draw duel, got %d and %d", wins, draws))
+	}
+	return errors.Join(errs...)
+}
+
+// LoadDuels loads and validates the duels file. Old fmt.Sprintf-style duels with %s are converted to placeholders.
+func LoadDuels(path string) ([]DuelMsg, error) {
+	duels, err := LoadFromJSON[[]DuelMsg](path)
+	if err != nil {
+		return nil, err
+	}
+
+	var errs []error
+	for i := range duels {
+		if err := upgradeLegacyDuel(&duels[i]); err != nil {
+			errs = append(errs, fmt.Errorf("duel #%d: %w", i+1, err))
+		}
+	}
+	if len(errs) > 0 {
+		return nil, errors.Join(errs...)
+	}
+
+	if err := ValidateDuels(duels); err != nil {
+		return nil, err
+	}
+	return duels, nil
+}
+
+// upgradeLegacyDuel replaces %s verbs: the announcement got the initiator and the challenger, the duel message got the winner.
+func upgradeLegacyDuel(duel *DuelMsg) error {
+	var err error
+	if duel.AnnounceMessage, err = replaceVerbs(duel.AnnounceMessage, "initiator", "challenger"); err != nil {
+		return fmt.Errorf("AnnounceMessage: %w", err)
+	}
+
+	names := []string{"winner"}
+	if duel.IsDraw {
+		names = nil
+	}
+	if duel.DuelMessage, err = replaceVerbs(duel.DuelMessage, names...); err != nil {
+		return fmt.Errorf("DuelMessage: %w", err)
+	}
+	return nil
+}
+
+func replaceVerbs(template string, names ...string) (string, error) {
+	verbs := strings.Count(template, "%s")
+	if verbs == 0 {
+		return template, nil
+	}
+	if verbs != len(names) {
+		return "", fmt.Errorf("has %d %%s, old-style templates should have %d, better use placeholders like {winner}", verbs, len(names))
+	}
+
+	for _, name := range names {
+		template = strings.Replace(template, "%s", "{"+name+"}", 1)
+	}
+	return template, nil
+}
+
+// LoadFriends returns the non-empty lines of the friends file, an empty file is an error.
+func LoadFriends(path string) ([]string, error) {
+	file, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer file.Close()
+
+	var friends []string
+	scanner := bufio.NewScanner(file)
+	for scanner.Scan() {
+		if line := strings.TrimSpace(scanner.Text()); line != "" {
+			friends = append(friends, line)
+		}
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, err
+	}
+
+	if len(friends) == 0 {
+		return nil, errors.New("friends file is empty")
+	}
+	return friends, nil
+}
@@ -0,0 +1,49 @@
+package config
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestShippedDuelsAreValid(t *testing.T) {
+	duels, err := LoadDuels("duels.json")
+	require.NoError(t, err)
+	require.NotEmpty(t, duels)
+}
+
+func TestValidateDuels(t *testing.T) {
+	err := ValidateDuels([]DuelMsg{
+		{AnnounceMessage: "{initiator} vs {challenger}", DuelMessage: "{winner} wins!"},
+		{AnnounceMessage: "{initiator} vs {challenger}", DuelMessage: "{winner} and {loser} tie", IsDraw: true},
+		{AnnounceMessage: "{winner} is ready!", DuelMessage: "{stat:wisdom} wins!"},
+		{AnnounceMessage: "Fight!", DuelMessage: "{winner} {wins|rules", Weight: -1},
+		{AnnounceMessage: "Fight {stat:luck}!", DuelMessage: "{winner} wins!"},
+	})
+
+	require.ErrorContains(t, err, "duel #2: DuelMessage: unknown placeholder {winner}, expected one of: initiator, challenger")
+	require.ErrorContains(t, err, "duel #3: AnnounceMessage: unknown placeholder {winner}")
+	require.ErrorContains(t, err, "duel #3: DuelMessage: unknown stat in {stat:wisdom}")
+	require.ErrorContains(t, err, "duel #4: DuelMessage: '{' at position 10 is never closed")
+	require.ErrorContains(t, err, "duel #4: Weight can't be negative")
+	require.ErrorContains(t, err, "duel #5: AnnounceMessage: {stat:luck} can't be used here")
+
+	err = ValidateDuels([]DuelMsg{{AnnounceMessage: "{initiator} vs {challenger}", DuelMessage: "{winner} wins!"}})
+	require.EqualError(t, err, "there should be at least one win and one draw duel, got 1 and 0")
+}
+
+func TestLoadDuelsUpgradesLegacyTemplates(t *testing.T) {
+	path := writeFile(t, t.TempDir(), "duels.json", `[
+		{"AnnounceMessage": "%s vs %s", "DuelMessage": "%s wins!"},
+		{"AnnounceMessage": "%s vs %s", "DuelMessage": "It's a draw!", "IsDraw": true}
+	]`)
+
+	duels, err := LoadDuels(path)
+	require.NoError(t, err)
+	require.Equal(t, "{initiator} vs {challenger}", duels[0].AnnounceMessage)
+	require.Equal(t, "{winner} wins!", duels[0].DuelMessage)
+
+	path = writeFile(t, t.TempDir(), "duels.json", `[{"AnnounceMessage": "%s vs %s", "DuelMessage": "%s beats %s!"}]`)
+	_, err = LoadDuels(path)
+	require.ErrorContains(t, err, "duel #1: DuelMessage: has 2 %s, old-style templates should have 1")
+}
@@ -3,26 +3,55 @@ package config
 import (
 	constants "TelTwBot/Internal/Config/Constants"
 	"encoding/json"
+	"fmt"
 	"log"
 	"math/rand"
 	"os"
 	"path/filepath"
+	"strings"
 	"time"
 )
 
 type DbConfig struct {
-	Host     string `json:"host"`
-	Port     int    `json:"port"`
-	User     string `json:"user"`
-	Password string `json:"password"`
-	DBName   string `json:"dbname"`
-	SSLMode  string `json:"sslmode"`
+	//"postgres" or "sqlite"
+	Driver string `json:"driver" yaml:"driver"`
+	//SQLite database file, the other fields are for Postgres
+	Path     string `json:"path" yaml:"path"`
+	Host     string `json:"host" yaml:"host"`
+	Port     int    `json:"port" yaml:"port"`
+	User     string `json:"user" yaml:"user"`
+	Password string `json:"password" yaml:"password"`
+	DBName   string `json:"dbname" yaml:"dbname"`
+	SSLMode  string `json:"sslmode" yaml:"sslmode"`
 }
 
+// DuelMsg templates use placeholders like {winner} and random choices like {a|b}, see duelTemplate.go.
 type DuelMsg struct {
 	AnnounceMessage string `json:"AnnounceMessage"`
 	DuelMessage     string `json:"DuelMessage"`
 	IsDraw          bool   `json:"IsDraw"`
+	//Relative chance among the duels of the same kind, 0 means 1
+	Weight int `json:"Weight,omitempty"`
+}
+
+type BossMsg struct {
+	Name           string `json:"Name"`
+	HP             int    `json:"HP"`
+	Reward         int    `json:"Reward"`
+	SpawnMessage   string `json:"SpawnMessage"`
+	VictoryMessage string `json:"VictoryMessage"`
+	DefeatMessage  string `json:"DefeatMessage"`
+}
+
+type TriviaQuestion struct {
+	Question   string   `json:"Question"`
+	Answers    []string `json:"Answers"`
+	Difficulty string   `json:"Difficulty"`
+}
+
+type TriviaPack struct {
+	Category  string           `json:"Category"`
+	Questions []TriviaQuestion `json:"Questions"`
 }
 
 func InitRandom() *rand.Rand {
@@ -63,3 +92,42 @@ func LoadFromJSON[T any](path string) (T, error) {
 	}
 	return result, nil
 }
+
+// LoadTriviaPacks loads every *.json question pack from the directory. Difficulty defaults to "medium".
+func LoadTriviaPacks(dir string) ([]TriviaPack, error) {
+	files, err := filepath.Glob(filepath.Join(dir, "*.json"))
+	if err != nil {
+		return nil, err
+	}
+
+	var packs []TriviaPack
+	for _, file := range files {
+		pack, err := LoadFromJSON[TriviaPack](file)
+		if err != nil {
+			return nil, fmt.Errorf("%s: %w", filepath.Base(file), err)
+		}
+
+		if pack.Category == "" {
+			pack.Category = strings.TrimSuffix(filepath.Base(file), ".json")
+		}
+
+		for i := range pack.Questions {
+			question := &pack.Questions[i]
+			if question.Question == "" || len(question.Answers) == 0 {
+				return nil, fmt.Errorf("%s: question #%d should have text and at least one answer", filepath.Base(file), i+1)
+			}
+
+			switch question.Difficulty {
+			case "":
+				question.Difficulty = "medium"
+			case "easy", "medium", "hard":
+			default:
+				return nil, fmt.Errorf("%s: question #%d has unknown difficulty '%s'", filepath.Base(file), i+1, question.Difficulty)
+			}
+		}
+
+		packs = append(packs, pack)
+	}
+
+	return packs, nil
+}
@@ -0,0 +1,90 @@
+package config
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+)
+
+// ItemSlots are the gear slots, only one item per slot can be equipped.
+var ItemSlots = []string{"weapon", "armor", "trinket"}
+
+// Item is a shop item, Modifiers are added to the stats of the user while the item is equipped.
+type Item struct {
+	Name        string         `json:"Name"`
+	Slot        string         `json:"Slot"`
+	Price       int            `json:"Price"`
+	Modifiers   map[string]int `json:"Modifiers"`
+	Description string         `json:"Description"`
+}
+
+type ItemCatalog []Item
+
+func (ic ItemCatalog) Validate() error {
+	names := make(map[string]bool)
+	for i, item := range ic {
+		if item.Name == "" {
+			return fmt.Errorf("item #%d should have a name", i+1)
+		}
+
+		key := strings.ToLower(item.Name)
+		if names[key] {
+			return fmt.Errorf("item '%s' is defined twice", item.Name)
+		}
+		names[key] = true
+
+		if !slices.Contains(ItemSlots, item.Slot) {
+			return fmt.Errorf("item '%s' has unknown slot '%s', expected one of: %s", item.Name, item.Slot, strings.Join(ItemSlots, ", "))
+		}
+		if item.Price <= 0 {
+			return fmt.Errorf("item '%s' should have a price greater than 0", item.Name)
+		}
+		for stat := range item.Modifiers {
+			//Free points aren't in DuelStats, so they can't be modified either
+			if !slices.Contains(DuelStats, stat) {
+				return fmt.Errorf("item '%s' modifies unknown stat '%s', expected one of: %s", item.Name, stat, strings.Join(DuelStats, ", "))
+			}
+		}
+	}
+	return nil
+}
+
+// Find looks the item up by name, case-insensitive.
+func (ic ItemCatalog) Find(name string) (Item, bool) {
+	for _, item := range ic {
+		if strings.EqualFold(item.Name, strings.TrimSpace(name)) {
+			return item, true
+		}
+	}
+	return Item{}, false
+}
+
+// Bonuses sums the modifiers of the given (equipped) items, unknown item names are skipped.
+func (ic ItemCatalog) Bonuses(itemNames []string) map[string]int {
+	bonuses := make(map[string]int)
+	for _, name := range itemNames {
+		item, ok := ic.Find(name)
+		if !ok {
+			continue
+		}
+		for stat, mod := range item.Modifiers {
+			bonuses[stat] += mod
+		}
+	}
+	return bonuses
+}
+
+// FormatModifiers returns modifiers like "+2 strength, -1 agility" sorted by stat name.
+func (item Item) FormatModifiers() string {
+	stats := make([]string, 0, len(item.Modifiers))
+	for stat := range item.Modifiers {
+		stats = append(stats, stat)
+	}
+	slices.Sort(stats)
+
+	parts := make([]string, 0, len(stats))
+	for _, stat := range stats {
+		parts = append(parts, fmt.Sprintf("%+d %s", item.Modifiers[stat], stat))
+	}
+	return strings.Join(parts, ", ")
+}
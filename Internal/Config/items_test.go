@@ -0,0 +1,37 @@
+package config
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestShippedItemsAreValid(t *testing.T) {
+	items, err := LoadFromJSON[ItemCatalog]("items.json")
+	require.NoError(t, err)
+	require.NoError(t, items.Validate())
+}
+
+func TestItemCatalogValidate(t *testing.T) {
+	for _, tc := range []struct {
+		name     string
+		item     Item
+		expected string
+	}{
+		{"no name", Item{Slot: "weapon", Price: 1}, "item #1 should have a name"},
+		{"unknown slot", Item{Name: "Hat", Slot: "head", Price: 1}, "item 'Hat' has unknown slot 'head', expected one of: weapon, armor, trinket"},
+		{"free item", Item{Name: "Stick", Slot: "weapon"}, "item 'Stick' should have a price greater than 0"},
+		{"typo in stat", Item{Name: "Sword", Slot: "weapon", Price: 1, Modifiers: map[string]int{"strenght": 1}},
+			"item 'Sword' modifies unknown stat 'strenght', expected one of: strength, perception, endurance, charisma, intelligence, agility, luck"},
+		{"free points", Item{Name: "Coin", Slot: "trinket", Price: 1, Modifiers: map[string]int{"free-points": 5}},
+			"item 'Coin' modifies unknown stat 'free-points'"},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			require.ErrorContains(t, ItemCatalog{tc.item}.Validate(), tc.expected)
+		})
+	}
+
+	sword := Item{Name: "Sword", Slot: "weapon", Price: 3, Modifiers: map[string]int{"strength": 2, "agility": -1}}
+	require.NoError(t, ItemCatalog{sword}.Validate())
+	require.EqualError(t, ItemCatalog{sword, sword}.Validate(), "item 'Sword' is defined twice")
+}
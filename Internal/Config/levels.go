@@ -0,0 +1,54 @@
+package config
+
+import (
+	"fmt"
+	"math"
+)
+
+// LevelConfig describes the level curve and how much XP every activity gives.
+// Reaching level L needs BaseXP * (L-1)^Exponent XP in total, so level 1 starts at 0 XP.
+type LevelConfig struct {
+	BaseXP             int     `json:"BaseXP"`
+	Exponent           float64 `json:"Exponent"`
+	MaxLevel           int     `json:"MaxLevel"`
+	FreePointsPerLevel int     `json:"FreePointsPerLevel"`
+
+	ChatXP       int `json:"ChatXP"`
+	AttendanceXP int `json:"AttendanceXP"`
+	DuelWinXP    int `json:"DuelWinXP"`
+	DuelDrawXP   int `json:"DuelDrawXP"`
+	DuelLoseXP   int `json:"DuelLoseXP"`
+	TriviaXP     int `json:"TriviaXP"`
+}
+
+func (lc LevelConfig) Validate() error {
+	if lc.BaseXP <= 0 {
+		return fmt.Errorf("BaseXP should be greater than 0")
+	}
+	if lc.Exponent < 1 {
+		return fmt.Errorf("Exponent should be at least 1")
+	}
+	if lc.MaxLevel < 2 {
+		return fmt.Errorf("MaxLevel should be at least 2")
+	}
+	if lc.FreePointsPerLevel < 0 {
+		return fmt.Errorf("FreePointsPerLevel can't be negative")
+	}
+	return nil
+}
+
+// XPForLevel returns the total XP needed to reach the level.
+func (lc LevelConfig) XPForLevel(level int) int {
+	if level <= 1 {
+		return 0
+	}
+	return int(math.Round(float64(lc.BaseXP) * math.Pow(float64(level-1), lc.Exponent)))
+}
+
+func (lc LevelConfig) LevelForXP(xp int) int {
+	level := 1
+	for level < lc.MaxLevel && xp >= lc.XPForLevel(level+1) {
+		level++
+	}
+	return level
+}
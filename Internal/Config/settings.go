@@ -0,0 +1,310 @@
+package config
+
+import (
+	constants "TelTwBot/Internal/Config/Constants"
+	"bufio"
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"slices"
+	"strconv"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+)
+
+const envPrefix = "TELTW_"
+
+const (
+	DriverPostgres = "postgres"
+	DriverSQLite   = "sqlite"
+)
+
+var dbDrivers = []string{DriverPostgres, DriverSQLite}
+
+var sslModes = []string{"disable", "allow", "prefer", "require", "verify-ca", "verify-full"}
+
+// Settings are the credentials and connection settings of the bot. They come from config.yaml (or the legacy
+// .client, .twHelix, .tgClient and database.json files if there is no config.yaml), TELTW_* environment variables override them.
+type Settings struct {
+	Twitch   TwitchSettings   `yaml:"twitch"`
+	Helix    HelixSettings    `yaml:"helix"`
+	Telegram TelegramSettings `yaml:"telegram"`
+	Database DbConfig         `yaml:"database"`
+}
+
+type TwitchSettings struct {
+	Channel     string `yaml:"channel"`
+	BotUsername string `yaml:"bot_username"`
+	//IRC token, "oauth:..."
+	Token string `yaml:"token"`
+}
+
+type HelixSettings struct {
+	ClientID   string `yaml:"client_id"`
+	OAuthToken string `yaml:"oauth_token"`
+}
+
+type TelegramSettings struct {
+	BotToken string `yaml:"bot_token"`
+	ChatID   int64  `yaml:"chat_id"`
+}
+
+// ConnectionString returns the lib/pq connection string.
+func (c DbConfig) ConnectionString() string {
+	return fmt.Sprintf("host=%s port=%d user=%s password=%s dbname=%s sslmode=%s",
+		c.Host, c.Port, c.User, c.Password, c.DBName, c.SSLMode)
+}
+
+// LoadSettings loads and validates the settings, every problem is reported in the returned error.
+// path is the --config flag, if it's empty config.yaml from the config directory is used and the legacy files
+// are read when it doesn't exist.
+func LoadSettings(path string) (*Settings, error) {
+	legacyFallback := path == ""
+	if legacyFallback {
+		var err error
+		path, err = ConfigPath(constants.SettingsFile)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	return readSettings(path, legacyFallback, os.LookupEnv, false)
+}
+
+// LoadDatabaseSettings loads the settings like LoadSettings, but only the database section has to be valid, so
+// tools that only work with the database (like the migrate subcommand) don't need the Twitch and Telegram credentials.
+func LoadDatabaseSettings(path string) (DbConfig, error) {
+	legacyFallback := path == ""
+	if legacyFallback {
+		var err error
+		path, err = ConfigPath(constants.SettingsFile)
+		if err != nil {
+			return DbConfig{}, err
+		}
+	}
+
+	settings, err := readSettings(path, legacyFallback, os.LookupEnv, true)
+	if err != nil {
+		return DbConfig{}, err
+	}
+	return settings.Database, nil
+}
+
+func readSettings(path string, legacyFallback bool, lookupEnv func(string) (string, bool), databaseOnly bool) (*Settings, error) {
+	settings := &Settings{
+		Twitch:   TwitchSettings{Channel: constants.Channel, BotUsername: constants.BotUsername},
+		Database: DbConfig{Driver: DriverPostgres, Port: 5432, SSLMode: "disable"},
+	}
+
+	err := settings.loadYAML(path)
+	if errors.Is(err, fs.ErrNotExist) && legacyFallback {
+		err = settings.loadLegacy(filepath.Dir(path))
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	errs := settings.applyEnv(lookupEnv)
+	errs = append(errs, settings.validate(databaseOnly)...)
+	if len(errs) > 0 {
+		return nil, errors.Join(errs...)
+	}
+	return settings, nil
+}
+
+func (s *Settings) loadYAML(path string) error {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return err
+	}
+
+	decoder := yaml.NewDecoder(bytes.NewReader(data))
+	//Typos in keys shouldn't silently fall back to defaults
+	decoder.KnownFields(true)
+	if err := decoder.Decode(s); err != nil && !errors.Is(err, io.EOF) {
+		return fmt.Errorf("%s: %w", filepath.Base(path), err)
+	}
+	return nil
+}
+
+// loadLegacy reads the files used before config.yaml, missing files are skipped.
+func (s *Settings) loadLegacy(dir string) error {
+	token, err := os.ReadFile(filepath.Join(dir, constants.TokenFile))
+	if err == nil {
+		s.Twitch.Token = strings.TrimSpace(string(token))
+	} else if !errors.Is(err, fs.ErrNotExist) {
+		return err
+	}
+
+	helix, err := readKeyValueFile(filepath.Join(dir, constants.HelixFile))
+	if err != nil {
+		return err
+	}
+	setIfPresent(&s.Helix.ClientID, helix, "clientID")
+	setIfPresent(&s.Helix.OAuthToken, helix, "oauth")
+
+	telegram, err := readKeyValueFile(filepath.Join(dir, constants.TgSettingsFile))
+	if err != nil {
+		return err
+	}
+	setIfPresent(&s.Telegram.BotToken, telegram, "botToken")
+	if chatID, ok := telegram["chatId"]; ok {
+		id, err := strconv.ParseInt(chatID, 10, 64)
+		if err != nil {
+			return fmt.Errorf("%s: invalid chatId: %w", constants.TgSettingsFile, err)
+		}
+		s.Telegram.ChatID = id
+	}
+
+	database, err := os.ReadFile(filepath.Join(dir, constants.DbConfigFile))
+	if err == nil {
+		//Fields missing in the file keep their defaults
+		if err := json.Unmarshal(database, &s.Database); err != nil {
+			return fmt.Errorf("%s: %w", constants.DbConfigFile, err)
+		}
+	} else if !errors.Is(err, fs.ErrNotExist) {
+		return err
+	}
+	return nil
+}
+
+// readKeyValueFile reads "key value" lines, # starts a comment. A missing file is an empty map.
+func readKeyValueFile(path string) (map[string]string, error) {
+	values := make(map[string]string)
+	file, err := os.Open(path)
+	if errors.Is(err, fs.ErrNotExist) {
+		return values, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	defer file.Close()
+
+	scanner := bufio.NewScanner(file)
+	for scanner.Scan() {
+		line := strings.TrimSpace(scanner.Text())
+		if strings.HasPrefix(line, "#") || line == "" {
+			continue
+		}
+
+		key, value, found := strings.Cut(line, " ")
+		if !found {
+			continue
+		}
+		values[strings.TrimSpace(key)] = strings.TrimSpace(value)
+	}
+	return values, scanner.Err()
+}
+
+func setIfPresent(target *string, values map[string]string, key string) {
+	if value, ok := values[key]; ok {
+		*target = value
+	}
+}
+
+type settingField struct {
+	//Key in config.yaml, the environment variable is derived from it
+	key      string
+	value    any
+	optional bool
+}
+
+func (s *Settings) fields() []settingField {
+	sqlite := s.Database.Driver == DriverSQLite
+	return []settingField{
+		{key: "twitch.channel", value: &s.Twitch.Channel},
+		{key: "twitch.bot_username", value: &s.Twitch.BotUsername},
+		{key: "twitch.token", value: &s.Twitch.Token},
+		{key: "helix.client_id", value: &s.Helix.ClientID},
+		{key: "helix.oauth_token", value: &s.Helix.OAuthToken},
+		{key: "telegram.bot_token", value: &s.Telegram.BotToken},
+		{key: "telegram.chat_id", value: &s.Telegram.ChatID},
+		{key: "database.driver", value: &s.Database.Driver},
+		{key: "database.path", value: &s.Database.Path, optional: !sqlite},
+		{key: "database.host", value: &s.Database.Host, optional: sqlite},
+		{key: "database.port", value: &s.Database.Port, optional: sqlite},
+		{key: "database.user", value: &s.Database.User, optional: sqlite},
+		{key: "database.password", value: &s.Database.Password, optional: true},
+		{key: "database.dbname", value: &s.Database.DBName, optional: sqlite},
+		{key: "database.sslmode", value: &s.Database.SSLMode, optional: sqlite},
+	}
+}
+
+// envName turns "twitch.bot_username" into "TELTW_TWITCH_BOT_USERNAME".
+func envName(key string) string {
+	return envPrefix + strings.ToUpper(strings.ReplaceAll(key, ".", "_"))
+}
+
+func (s *Settings) applyEnv(lookupEnv func(string) (string, bool)) []error {
+	var errs []error
+	for _, field := range s.fields() {
+		name := envName(field.key)
+		value, ok := lookupEnv(name)
+		if !ok {
+			continue
+		}
+
+		switch target := field.value.(type) {
+		case *string:
+			*target = value
+		case *int:
+			parsed, err := strconv.Atoi(value)
+			if err != nil {
+				errs = append(errs, fmt.Errorf("%s should be a number, got '%s'", name, value))
+				continue
+			}
+			*target = parsed
+		case *int64:
+			parsed, err := strconv.ParseInt(value, 10, 64)
+			if err != nil {
+				errs = append(errs, fmt.Errorf("%s should be a number, got '%s'", name, value))
+				continue
+			}
+			*target = parsed
+		}
+	}
+	return errs
+}
+
+// validate checks the required fields and the values, with databaseOnly only the database section is checked.
+func (s *Settings) validate(databaseOnly bool) []error {
+	var errs []error
+	for _, field := range s.fields() {
+		if field.optional || databaseOnly && !strings.HasPrefix(field.key, "database.") {
+			continue
+		}
+
+		var missing bool
+		switch value := field.value.(type) {
+		case *string:
+			missing = strings.TrimSpace(*value) == ""
+		case *int:
+			missing = *value == 0
+		case *int64:
+			missing = *value == 0
+		}
+		if missing {
+			errs = append(errs, fmt.Errorf("%s is not set (config file or %s)", field.key, envName(field.key)))
+		}
+	}
+
+	if s.Database.Driver != "" && !slices.Contains(dbDrivers, s.Database.Driver) {
+		errs = append(errs, fmt.Errorf("database.driver should be one of %s, got '%s'", strings.Join(dbDrivers, ", "), s.Database.Driver))
+	}
+	if s.Database.Port < 0 || s.Database.Port > 65535 {
+		errs = append(errs, fmt.Errorf("database.port should be from 1 to 65535, got %d", s.Database.Port))
+	}
+	if s.Database.SSLMode != "" && !slices.Contains(sslModes, s.Database.SSLMode) {
+		errs = append(errs, fmt.Errorf("database.sslmode should be one of %s, got '%s'", strings.Join(sslModes, ", "), s.Database.SSLMode))
+	}
+	if !databaseOnly && strings.HasPrefix(s.Twitch.Channel, "#") {
+		errs = append(errs, errors.New("twitch.channel should be the channel name without '#'"))
+	}
+	return errs
+}
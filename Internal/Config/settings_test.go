@@ -0,0 +1,160 @@
+package config
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+const validSettings = `
+twitch:
+  channel: somechannel
+  bot_username: some_bot
+  token: oauth:abc
+helix:
+  client_id: client
+  oauth_token: helix-token
+telegram:
+  bot_token: "123:tg"
+  chat_id: -100
+database:
+  host: localhost
+  user: postgres
+  dbname: bot
+`
+
+func writeFile(t *testing.T, dir string, name string, content string) string {
+	t.Helper()
+	path := filepath.Join(dir, name)
+	require.NoError(t, os.WriteFile(path, []byte(content), 0o600))
+	return path
+}
+
+func env(values map[string]string) func(string) (string, bool) {
+	return func(name string) (string, bool) {
+		value, ok := values[name]
+		return value, ok
+	}
+}
+
+func TestLoadSettingsFromYAML(t *testing.T) {
+	path := writeFile(t, t.TempDir(), "config.yaml", validSettings)
+
+	settings, err := readSettings(path, true, env(map[string]string{
+		"TELTW_DATABASE_PASSWORD": "secret",
+		"TELTW_DATABASE_PORT":     "6543",
+	}), false)
+	require.NoError(t, err)
+
+	require.Equal(t, "somechannel", settings.Twitch.Channel)
+	require.Equal(t, int64(-100), settings.Telegram.ChatID)
+	//Defaults and environment overrides
+	require.Equal(t, "disable", settings.Database.SSLMode)
+	require.Equal(t, DriverPostgres, settings.Database.Driver)
+	require.Equal(t, "host=localhost port=6543 user=postgres password=secret dbname=bot sslmode=disable", settings.Database.ConnectionString())
+}
+
+func TestLoadSettingsForSQLite(t *testing.T) {
+	path := writeFile(t, t.TempDir(), "config.yaml", `
+twitch:
+  channel: somechannel
+  bot_username: some_bot
+  token: oauth:abc
+helix:
+  client_id: client
+  oauth_token: helix-token
+telegram:
+  bot_token: "123:tg"
+  chat_id: -100
+database:
+  driver: sqlite
+`)
+
+	//The Postgres settings aren't needed, but the file is
+	_, err := readSettings(path, true, env(nil), false)
+	require.ErrorContains(t, err, "database.path is not set")
+	require.NotContains(t, err.Error(), "database.host")
+
+	settings, err := readSettings(path, true, env(map[string]string{"TELTW_DATABASE_PATH": "bot.db"}), false)
+	require.NoError(t, err)
+	require.Equal(t, "bot.db", settings.Database.Path)
+
+	_, err = readSettings(path, true, env(map[string]string{"TELTW_DATABASE_DRIVER": "mysql"}), false)
+	require.ErrorContains(t, err, "database.driver should be one of postgres, sqlite, got 'mysql'")
+}
+
+func TestLoadSettingsFromLegacyFiles(t *testing.T) {
+	dir := t.TempDir()
+	writeFile(t, dir, ".client", "oauth:legacy\n")
+	writeFile(t, dir, ".twHelix", "# helix\nclientID legacy-client\noauth legacy-helix\n")
+	writeFile(t, dir, ".tgClient", "botToken 123:legacy\nchatId 42\n")
+	writeFile(t, dir, "database.json", `{"host": "db", "user": "bot", "dbname": "teltw"}`)
+
+	settings, err := readSettings(filepath.Join(dir, "config.yaml"), true, env(nil), false)
+	require.NoError(t, err)
+
+	require.Equal(t, "oauth:legacy", settings.Twitch.Token)
+	require.Equal(t, "legacy-client", settings.Helix.ClientID)
+	require.Equal(t, "legacy-helix", settings.Helix.OAuthToken)
+	require.Equal(t, int64(42), settings.Telegram.ChatID)
+	require.Equal(t, 5432, settings.Database.Port)
+
+	//An explicit --config path doesn't fall back
+	_, err = readSettings(filepath.Join(dir, "config.yaml"), false, env(nil), false)
+	require.ErrorIs(t, err, os.ErrNotExist)
+}
+
+func TestLoadSettingsReportsAllErrors(t *testing.T) {
+	path := writeFile(t, t.TempDir(), "config.yaml", `
+twitch:
+  channel: "#somechannel"
+database:
+  sslmode: maybe
+`)
+
+	_, err := readSettings(path, true, env(map[string]string{"TELTW_TELEGRAM_CHAT_ID": "abc"}), false)
+	require.Error(t, err)
+	for _, msg := range []string{
+		"TELTW_TELEGRAM_CHAT_ID should be a number, got 'abc'",
+		"twitch.token is not set (config file or TELTW_TWITCH_TOKEN)",
+		"helix.client_id is not set",
+		"telegram.chat_id is not set",
+		"database.host is not set",
+		"database.sslmode should be one of",
+		"twitch.channel should be the channel name without '#'",
+	} {
+		require.ErrorContains(t, err, msg)
+	}
+}
+
+func TestLoadSettingsRejectsUnknownKeys(t *testing.T) {
+	path := writeFile(t, t.TempDir(), "config.yaml", "twitch:\n  chanel: typo\n")
+
+	_, err := readSettings(path, true, env(nil), false)
+	require.ErrorContains(t, err, "field chanel not found")
+}
+
+func TestLoadDatabaseSettingsOnly(t *testing.T) {
+	path := writeFile(t, t.TempDir(), "config.yaml", `
+twitch:
+  channel: "#somechannel"
+database:
+  driver: sqlite
+  path: bot.db
+`)
+
+	settings, err := readSettings(path, false, env(nil), true)
+	require.NoError(t, err, "the Twitch, Helix and Telegram sections aren't required")
+	require.Equal(t, "bot.db", settings.Database.Path)
+
+	_, err = readSettings(path, false, env(nil), false)
+	require.ErrorContains(t, err, "twitch.token is not set")
+
+	path = writeFile(t, t.TempDir(), "config.yaml", "database:\n  sslmode: maybe\n")
+	_, err = readSettings(path, false, env(nil), true)
+	require.ErrorContains(t, err, "database.host is not set")
+	require.ErrorContains(t, err, "database.sslmode should be one of")
+	require.NotContains(t, err.Error(), "twitch")
+}
@@ -0,0 +1,29 @@
+package config
+
+import (
+	constants "TelTwBot/Internal/Config/Constants"
+	"log"
+	"sync"
+	"time"
+)
+
+var (
+	channelLocation     *time.Location
+	channelLocationOnce sync.Once
+)
+
+// ChannelToday returns the start of the current calendar day in the channel timezone.
+// If the timezone can't be loaded UTC is used.
+func ChannelToday() time.Time {
+	channelLocationOnce.Do(func() {
+		location, err := time.LoadLocation(constants.ChannelTimezone)
+		if err != nil {
+			log.Printf("Error loading timezone %s, using UTC: %v", constants.ChannelTimezone, err)
+			location = time.UTC
+		}
+		channelLocation = location
+	})
+
+	now := time.Now().In(channelLocation)
+	return time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, channelLocation)
+}
@@ -0,0 +1,50 @@
+package config
+
+import (
+	"fmt"
+	"regexp"
+	"slices"
+)
+
+// WelcomePlaceholders can be used in the welcome templates.
+var WelcomePlaceholders = []string{"{user}", "{greeting}", "{language}", "{days}"}
+
+var placeholderRegexp = regexp.MustCompile(`\{[^{}]*\}`)
+
+// WelcomeConfig describes automatic greetings of chatters. An empty template turns that variant off.
+type WelcomeConfig struct {
+	Enabled bool `json:"Enabled"`
+	//First message ever (the user has never chatted before)
+	FirstTimeTemplate string `json:"FirstTimeTemplate"`
+	//First message in the stream session
+	SessionTemplate string `json:"SessionTemplate"`
+	//First message in the session after ReturningAfterDays days or more of absence
+	ReturningTemplate  string `json:"ReturningTemplate"`
+	ReturningAfterDays int    `json:"ReturningAfterDays"`
+
+	//After a raid only one greeting per RaidGreetIntervalSeconds is sent for RaidQuietSeconds
+	RaidQuietSeconds         int `json:"RaidQuietSeconds"`
+	RaidGreetIntervalSeconds int `json:"RaidGreetIntervalSeconds"`
+}
+
+func (wc WelcomeConfig) Validate() error {
+	if wc.ReturningAfterDays < 1 {
+		return fmt.Errorf("ReturningAfterDays should be at least 1")
+	}
+	if wc.RaidQuietSeconds < 0 || wc.RaidGreetIntervalSeconds < 0 {
+		return fmt.Errorf("raid settings can't be negative")
+	}
+
+	for name, template := range map[string]string{
+		"FirstTimeTemplate": wc.FirstTimeTemplate,
+		"SessionTemplate":   wc.SessionTemplate,
+		"ReturningTemplate": wc.ReturningTemplate,
+	} {
+		for _, placeholder := range placeholderRegexp.FindAllString(template, -1) {
+			if !slices.Contains(WelcomePlaceholders, placeholder) {
+				return fmt.Errorf("%s has unknown placeholder %s", name, placeholder)
+			}
+		}
+	}
+	return nil
+}
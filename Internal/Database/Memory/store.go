@@ -0,0 +1,473 @@
+package memory
+
+import (
+	db "TelTwBot/Internal/Database"
+	"context"
+	"database/sql"
+	"fmt"
+	"math"
+	"slices"
+	"strings"
+	"sync"
+	"time"
+)
+
+// coreStatTypes are the stat types seeded by the 0002_base_stats migration, free points are uncapped by 0003_uncap_free_points.
+var coreStatTypes = []db.Stats{
+	{ID: 1, Name: "strength", DisplayName: "Strength", MinValue: 1, MaxValue: 10, DefaultValue: 1},
+	{ID: 2, Name: "perception", DisplayName: "Perception", MinValue: 1, MaxValue: 10, DefaultValue: 1},
+	{ID: 3, Name: "endurance", DisplayName: "Endurance", MinValue: 1, MaxValue: 10, DefaultValue: 1},
+	{ID: 4, Name: "charisma", DisplayName: "Charisma", MinValue: 1, MaxValue: 10, DefaultValue: 1},
+	{ID: 5, Name: "intelligence", DisplayName: "Intelligence", MinValue: 1, MaxValue: 10, DefaultValue: 1},
+	{ID: 6, Name: "agility", DisplayName: "Agility", MinValue: 1, MaxValue: 10, DefaultValue: 1},
+	{ID: 7, Name: "luck", DisplayName: "Luck", MinValue: 1, MaxValue: 10, DefaultValue: 1},
+	{ID: 8, Name: "free-points", DisplayName: "Free Points", MinValue: 0, MaxValue: math.MaxInt32, DefaultValue: 0},
+	{ID: 9, Name: "total-free-points", DisplayName: "Total FP", MinValue: 0, MaxValue: math.MaxInt32, DefaultValue: 0},
+}
+
+// Store keeps users, stats, duel results and items in memory. It behaves like the Postgres repositories
+// (both pass the repotest contract), every method is atomic.
+type Store struct {
+	mu        sync.Mutex
+	lastID    int
+	users     map[string]*db.User
+	statTypes []db.Stats
+	//user ID -> stat name -> stat
+	stats   map[int]map[string]*db.UserStats
+	results map[int]*db.UserResult
+	respecs map[int]time.Time
+	//user ID -> item name -> item
+	items map[int]map[string]*db.UserItem
+}
+
+var (
+	_ db.UserRepository   = (*Store)(nil)
+	_ db.StatsRepository  = (*Store)(nil)
+	_ db.ResultRepository = (*Store)(nil)
+	_ db.ItemRepository   = (*Store)(nil)
+)
+
+func NewStore() *Store {
+	return &Store{
+		users:     make(map[string]*db.User),
+		statTypes: coreStatTypes,
+		stats:     make(map[int]map[string]*db.UserStats),
+		results:   make(map[int]*db.UserResult),
+		respecs:   make(map[int]time.Time),
+		items:     make(map[int]map[string]*db.UserItem),
+	}
+}
+
+// Repositories returns the store as the users, stats, results and items repositories, the other ones are nil.
+func (s *Store) Repositories() db.Repositories {
+	return db.Repositories{Users: s, Stats: s, Results: s, Items: s}
+}
+
+func (s *Store) CreateUser(ctx context.Context, username string) (*db.User, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	user := s.getOrCreateUser(username)
+	user.UpdatedAt = time.Now()
+	copied := *user
+	return &copied, nil
+}
+
+func (s *Store) GetUser(ctx context.Context, username string) (*db.User, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	user, ok := s.users[username]
+	if !ok {
+		return nil, fmt.Errorf("failed to get user %s: %w", username, db.ErrUserNotFound)
+	}
+	copied := *user
+	return &copied, nil
+}
+
+func (s *Store) GetOrCreateUserStats(ctx context.Context, username string) ([]db.UserStats, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	user := s.getOrCreateUser(username)
+	s.createDefaultStats(user.ID)
+	return s.userStats(user.ID), nil
+}
+
+func (s *Store) GetTwitchUserStats(ctx context.Context, username string) ([]db.UserStats, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	user, ok := s.users[username]
+	if !ok {
+		return nil, fmt.Errorf("user lookup failed: %w", db.ErrUserNotFound)
+	}
+	return s.userStats(user.ID), nil
+}
+
+func (s *Store) UpdateUserStat(ctx context.Context, username string, stat string, val int) (string, int, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	user, ok := s.users[username]
+	if !ok {
+		return "", 0, fmt.Errorf("failed to update free points: user %s not found", username)
+	}
+	statType, ok := s.statType(stat)
+	if !ok {
+		return "", 0, fmt.Errorf("failed to update free points: stat %s not found", stat)
+	}
+	current, ok := s.stats[user.ID][stat]
+	if !ok {
+		return "", 0, fmt.Errorf("failed to update free points: failed to get current stat value: %w", sql.ErrNoRows)
+	}
+
+	freePoints := 0
+	if points, ok := s.stats[user.ID]["free-points"]; ok {
+		freePoints = points.Value
+	}
+	if freePoints < val {
+		return "", 0, fmt.Errorf("failed to update free points: not enough free points: need %d, have %d", val, freePoints)
+	}
+
+	newValue := min(current.Value+val, statType.MaxValue)
+	pointsUsed := newValue - current.Value
+	s.setStat(user.ID, stat, newValue)
+	s.setStat(user.ID, "free-points", freePoints-pointsUsed)
+	return statType.Name, newValue, nil
+}
+
+func (s *Store) DecreaseUserStat(ctx context.Context, username string, stat string, val int, refundRatio float64) (string, int, int, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	user, ok := s.users[username]
+	if !ok {
+		return "", 0, 0, fmt.Errorf("failed to decrease stat: user %s not found", username)
+	}
+	statType, ok := s.statType(stat)
+	if !ok {
+		return "", 0, 0, fmt.Errorf("failed to decrease stat: stat %s not found", stat)
+	}
+	current, ok := s.stats[user.ID][stat]
+	if !ok {
+		return "", 0, 0, fmt.Errorf("failed to decrease stat: failed to get current stat value: %w", sql.ErrNoRows)
+	}
+
+	newValue := max(current.Value-val, statType.MinValue)
+	pointsRemoved := current.Value - newValue
+	if pointsRemoved == 0 {
+		return "", 0, 0, fmt.Errorf("failed to decrease stat: stat %s is already at its minimum", statType.Name)
+	}
+
+	s.setStat(user.ID, stat, newValue)
+	refunded := int(float64(pointsRemoved) * refundRatio)
+	s.refundFreePoints(user.ID, refunded)
+	return statType.Name, newValue, refunded, nil
+}
+
+func (s *Store) RespecUserStats(ctx context.Context, username string, cooldown time.Duration) (int, time.Duration, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	user, ok := s.users[username]
+	if !ok {
+		return 0, 0, fmt.Errorf("failed to respec stats: user %s not found", username)
+	}
+	if lastRespec, ok := s.respecs[user.ID]; ok && time.Since(lastRespec) < cooldown {
+		return 0, cooldown - time.Since(lastRespec), db.ErrRespecOnCooldown
+	}
+
+	refunded := 0
+	for _, statType := range s.statTypes {
+		stat, ok := s.stats[user.ID][statType.Name]
+		if !ok || isFreePoints(statType.Name) {
+			continue
+		}
+		refunded += max(stat.Value-statType.DefaultValue, 0)
+		s.setStat(user.ID, statType.Name, statType.DefaultValue)
+	}
+
+	s.refundFreePoints(user.ID, refunded)
+	s.respecs[user.ID] = time.Now()
+	return refunded, 0, nil
+}
+
+func (s *Store) AddFreePoints(ctx context.Context, username string, points int) (int, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	user := s.getOrCreateUser(username)
+	s.createDefaultStats(user.ID)
+	for _, name := range []string{"free-points", "total-free-points"} {
+		statType, _ := s.statType(name)
+		s.setStat(user.ID, name, min(s.stats[user.ID][name].Value+points, statType.MaxValue))
+	}
+	return s.stats[user.ID]["free-points"].Value, nil
+}
+
+func (s *Store) BuyItem(ctx context.Context, username string, itemName string, slot string, price int) (int, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	user := s.getOrCreateUser(username)
+	s.createDefaultStats(user.ID)
+	if _, ok := s.items[user.ID][itemName]; ok {
+		return 0, db.ErrItemAlreadyOwned
+	}
+	freePoints := s.stats[user.ID]["free-points"].Value
+	if freePoints < price {
+		return 0, db.ErrNotEnoughFreePoints
+	}
+
+	if s.items[user.ID] == nil {
+		s.items[user.ID] = make(map[string]*db.UserItem)
+	}
+	s.items[user.ID][itemName] = &db.UserItem{ItemName: itemName, Slot: slot, AcquiredAt: time.Now()}
+	s.setStat(user.ID, "free-points", freePoints-price)
+	return freePoints - price, nil
+}
+
+// GetUserItems returns the inventory of the user, equipped items first.
+func (s *Store) GetUserItems(ctx context.Context, username string) ([]db.UserItem, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	user, ok := s.users[username]
+	if !ok {
+		return nil, nil
+	}
+
+	var items []db.UserItem
+	for _, item := range s.items[user.ID] {
+		items = append(items, *item)
+	}
+	slices.SortFunc(items, func(a, b db.UserItem) int {
+		if a.Equipped != b.Equipped {
+			if a.Equipped {
+				return -1
+			}
+			return 1
+		}
+		return strings.Compare(a.ItemName, b.ItemName)
+	})
+	return items, nil
+}
+
+func (s *Store) EquipItem(ctx context.Context, username string, itemName string) (string, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	user, ok := s.users[username]
+	if !ok {
+		return "", db.ErrItemNotOwned
+	}
+	item, ok := s.items[user.ID][itemName]
+	if !ok {
+		return "", db.ErrItemNotOwned
+	}
+
+	var replaced string
+	for _, other := range s.items[user.ID] {
+		if other.Slot == item.Slot && other.Equipped && other.ItemName != itemName {
+			other.Equipped = false
+			replaced = other.ItemName
+		}
+	}
+	item.Equipped = true
+	return replaced, nil
+}
+
+func (s *Store) UnequipItem(ctx context.Context, username string, nameOrSlot string) (string, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	user, ok := s.users[username]
+	if !ok {
+		return "", nil
+	}
+
+	for _, item := range s.items[user.ID] {
+		if item.Equipped && (item.ItemName == nameOrSlot || item.Slot == nameOrSlot) {
+			item.Equipped = false
+			return item.ItemName, nil
+		}
+	}
+	return "", nil
+}
+
+func (s *Store) GetUserResults(ctx context.Context, userID int) (*db.UserResult, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	result, ok := s.results[userID]
+	if !ok {
+		return nil, nil
+	}
+	copied := *result
+	return &copied, nil
+}
+
+func (s *Store) IncrementUserResult(ctx context.Context, userID int, resultType string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	return s.incrementResult(userID, resultType)
+}
+
+func (s *Store) UpdateResultsAfterDuel(ctx context.Context, initiator string, challenger string, result int) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	challengerUser, ok := s.users[challenger]
+	if !ok {
+		return fmt.Errorf("failed to get challenger ID: failed to get user ID: %w", sql.ErrNoRows)
+	}
+	initiatorUser, ok := s.users[initiator]
+	if !ok {
+		return fmt.Errorf("failed to get initiator ID: failed to get user ID: %w", sql.ErrNoRows)
+	}
+
+	//Everything is checked before the first change, so a failed duel update changes nothing
+	var initiatorResult, challengerResult string
+	var pointsFor []int
+	switch result {
+	case 0:
+		initiatorResult, challengerResult = "draw", "draw"
+		pointsFor = []int{initiatorUser.ID, challengerUser.ID}
+	case 1:
+		initiatorResult, challengerResult = "win", "lose"
+		pointsFor = []int{initiatorUser.ID}
+	case 2:
+		initiatorResult, challengerResult = "lose", "win"
+		pointsFor = []int{challengerUser.ID}
+	default:
+		return fmt.Errorf("invalid game result: %d", result)
+	}
+	for _, userID := range pointsFor {
+		for _, name := range []string{"free-points", "total-free-points"} {
+			if _, ok := s.stats[userID][name]; !ok {
+				return fmt.Errorf("failed to update user stats: couldn't get user %s value: %w", name, sql.ErrNoRows)
+			}
+		}
+	}
+
+	s.incrementResult(initiatorUser.ID, initiatorResult)
+	s.incrementResult(challengerUser.ID, challengerResult)
+	for _, userID := range pointsFor {
+		s.grantDuelPoints(userID, initiatorResult == "draw")
+	}
+	return nil
+}
+
+// grantDuelPoints follows updateUserPoints of the Postgres repository: a free point every winThreshold wins
+// (drawThreshold draws), the thresholds grow with every 5 points earned in duels.
+func (s *Store) grantDuelPoints(userID int, isDraw bool) {
+	const WIN_THRESHOLD_COEFF = 10
+	const DRAW_THRESHOLD_COEFF = 20
+	const MAX_POINTS_BEFORE_INCREMENT = 5
+
+	results := s.results[userID]
+	steps := int(math.Floor(float64(results.DuelPoints) / MAX_POINTS_BEFORE_INCREMENT))
+
+	earned := results.Wins%(WIN_THRESHOLD_COEFF+WIN_THRESHOLD_COEFF*steps) == 0
+	if isDraw {
+		earned = results.Draws%(DRAW_THRESHOLD_COEFF+DRAW_THRESHOLD_COEFF*steps) == 0
+	}
+	if earned {
+		results.DuelPoints++
+		s.setStat(userID, "free-points", s.stats[userID]["free-points"].Value+1)
+		s.setStat(userID, "total-free-points", s.stats[userID]["total-free-points"].Value+1)
+	}
+}
+
+func (s *Store) incrementResult(userID int, resultType string) error {
+	result, ok := s.results[userID]
+	if !ok {
+		result = &db.UserResult{UserID: userID}
+	}
+
+	switch resultType {
+	case "win":
+		result.Wins++
+	case "draw":
+		result.Draws++
+	case "lose":
+		result.Loses++
+	default:
+		return fmt.Errorf("invalid result type: %s", resultType)
+	}
+
+	result.UpdatedAt = sql.NullTime{Time: time.Now(), Valid: true}
+	s.results[userID] = result
+	return nil
+}
+
+func (s *Store) getOrCreateUser(username string) *db.User {
+	if user, ok := s.users[username]; ok {
+		return user
+	}
+
+	s.lastID++
+	now := time.Now()
+	user := &db.User{ID: s.lastID, Username: username, CreatedAt: now, UpdatedAt: now}
+	s.users[username] = user
+	return user
+}
+
+func (s *Store) createDefaultStats(userID int) {
+	if s.stats[userID] == nil {
+		s.stats[userID] = make(map[string]*db.UserStats)
+	}
+	for _, statType := range s.statTypes {
+		if _, ok := s.stats[userID][statType.Name]; !ok {
+			s.setStat(userID, statType.Name, statType.DefaultValue)
+		}
+	}
+}
+
+// userStats returns copies of the stats of the user in the stat types order.
+func (s *Store) userStats(userID int) []db.UserStats {
+	var stats []db.UserStats
+	for _, statType := range s.statTypes {
+		if stat, ok := s.stats[userID][statType.Name]; ok {
+			stats = append(stats, *stat)
+		}
+	}
+	return stats
+}
+
+func (s *Store) statType(name string) (db.Stats, bool) {
+	for _, statType := range s.statTypes {
+		if statType.Name == name {
+			return statType, true
+		}
+	}
+	return db.Stats{}, false
+}
+
+func (s *Store) setStat(userID int, name string, value int) {
+	if s.stats[userID] == nil {
+		s.stats[userID] = make(map[string]*db.UserStats)
+	}
+	s.stats[userID][name] = &db.UserStats{
+		UserID:    userID,
+		StatType:  name,
+		Value:     value,
+		UpdatedAt: sql.NullTime{Time: time.Now(), Valid: true},
+	}
+}
+
+// refundFreePoints returns points into free-points only, like refundFreePointsTx.
+func (s *Store) refundFreePoints(userID int, points int) {
+	stat, ok := s.stats[userID]["free-points"]
+	if points <= 0 || !ok {
+		return
+	}
+	statType, _ := s.statType("free-points")
+	s.setStat(userID, "free-points", min(stat.Value+points, statType.MaxValue))
+}
+
+func isFreePoints(name string) bool {
+	return name == "free-points" || name == "total-free-points"
+}
@@ -0,0 +1,13 @@
+package memory
+
+import (
+	db "TelTwBot/Internal/Database"
+	repotest "TelTwBot/Internal/Database/RepoTest"
+	"testing"
+)
+
+func TestStoreRepositoryContract(t *testing.T) {
+	repotest.RunRepositoryContract(t, func(t *testing.T) db.Repositories {
+		return NewStore().Repositories()
+	})
+}
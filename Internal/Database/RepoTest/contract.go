@@ -0,0 +1,282 @@
+package repotest
+
+import (
+	db "TelTwBot/Internal/Database"
+	"context"
+	"fmt"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+var userCounter atomic.Int64
+
+// username is unique across runs, so the contract can run against a database that already has data.
+func username(name string) string {
+	return fmt.Sprintf("%s_%d_%d", name, time.Now().UnixNano(), userCounter.Add(1))
+}
+
+func statValues(stats []db.UserStats) map[string]int {
+	values := make(map[string]int, len(stats))
+	for _, stat := range stats {
+		values[stat.StatType] = stat.Value
+	}
+	return values
+}
+
+// RunRepositoryContract checks the behavior every implementation of the repositories should share.
+// newRepositories is called for every subtest.
+func RunRepositoryContract(t *testing.T, newRepositories func(t *testing.T) db.Repositories) {
+	ctx := context.Background()
+
+	t.Run("users", func(t *testing.T) {
+		repos := newRepositories(t)
+		name := username("user")
+
+		created, err := repos.Users.CreateUser(ctx, name)
+		require.NoError(t, err)
+		require.Equal(t, name, created.Username)
+		require.NotZero(t, created.ID)
+
+		again, err := repos.Users.CreateUser(ctx, name)
+		require.NoError(t, err)
+		require.Equal(t, created.ID, again.ID)
+
+		found, err := repos.Users.GetUser(ctx, name)
+		require.NoError(t, err)
+		require.Equal(t, created.ID, found.ID)
+
+		_, err = repos.Users.GetUser(ctx, username("missing"))
+		require.ErrorIs(t, err, db.ErrUserNotFound)
+	})
+
+	t.Run("concurrent new user", func(t *testing.T) {
+		repos := newRepositories(t)
+		name := username("newcomer")
+
+		//The first message of a chatter creates the user from several goroutines at once
+		const workers = 8
+		errs := make(chan error, workers)
+		var wg sync.WaitGroup
+		for i := range workers {
+			wg.Add(1)
+			go func() {
+				defer wg.Done()
+				if i%2 == 0 {
+					_, err := repos.Stats.GetOrCreateUserStats(ctx, name)
+					errs <- err
+					return
+				}
+				_, err := repos.Stats.AddFreePoints(ctx, name, 1)
+				errs <- err
+			}()
+		}
+		wg.Wait()
+		close(errs)
+		for err := range errs {
+			require.NoError(t, err)
+		}
+
+		stats, err := repos.Stats.GetTwitchUserStats(ctx, name)
+		require.NoError(t, err)
+		require.Equal(t, workers/2, statValues(stats)["free-points"])
+	})
+
+	t.Run("default stats", func(t *testing.T) {
+		repos := newRepositories(t)
+		name := username("stats")
+
+		_, err := repos.Stats.GetTwitchUserStats(ctx, name)
+		require.ErrorIs(t, err, db.ErrUserNotFound)
+
+		stats, err := repos.Stats.GetOrCreateUserStats(ctx, name)
+		require.NoError(t, err)
+		values := statValues(stats)
+		require.Equal(t, 1, values["strength"])
+		require.Equal(t, 1, values["luck"])
+		require.Equal(t, 0, values["free-points"])
+		require.Equal(t, 0, values["total-free-points"])
+
+		existing, err := repos.Stats.GetTwitchUserStats(ctx, name)
+		require.NoError(t, err)
+		require.Equal(t, values, statValues(existing))
+
+		//The user is created along with the stats
+		_, err = repos.Users.GetUser(ctx, name)
+		require.NoError(t, err)
+	})
+
+	t.Run("spend and refund free points", func(t *testing.T) {
+		repos := newRepositories(t)
+		name := username("spender")
+
+		freePoints, err := repos.Stats.AddFreePoints(ctx, name, 3)
+		require.NoError(t, err)
+		require.Equal(t, 3, freePoints)
+
+		statName, value, err := repos.Stats.UpdateUserStat(ctx, name, "strength", 2)
+		require.NoError(t, err)
+		require.Equal(t, "strength", statName)
+		require.Equal(t, 3, value)
+
+		_, _, err = repos.Stats.UpdateUserStat(ctx, name, "strength", 2)
+		require.ErrorContains(t, err, "not enough free points")
+		_, _, err = repos.Stats.UpdateUserStat(ctx, name, "wisdom", 1)
+		require.Error(t, err)
+		_, _, err = repos.Stats.UpdateUserStat(ctx, username("missing"), "strength", 1)
+		require.Error(t, err)
+
+		statName, value, refunded, err := repos.Stats.DecreaseUserStat(ctx, name, "strength", 1, 1)
+		require.NoError(t, err)
+		require.Equal(t, "strength", statName)
+		require.Equal(t, 2, value)
+		require.Equal(t, 1, refunded)
+
+		_, _, _, err = repos.Stats.DecreaseUserStat(ctx, name, "luck", 1, 1)
+		require.ErrorContains(t, err, "already at its minimum")
+
+		stats, err := repos.Stats.GetTwitchUserStats(ctx, name)
+		require.NoError(t, err)
+		values := statValues(stats)
+		require.Equal(t, 2, values["free-points"])
+		require.Equal(t, 3, values["total-free-points"], "spending doesn't change the earned points")
+	})
+
+	t.Run("free points aren't capped", func(t *testing.T) {
+		repos := newRepositories(t)
+		name := username("rich")
+
+		freePoints, err := repos.Stats.AddFreePoints(ctx, name, 500)
+		require.NoError(t, err)
+		require.Equal(t, 500, freePoints)
+
+		stats, err := repos.Stats.GetTwitchUserStats(ctx, name)
+		require.NoError(t, err)
+		require.Equal(t, 500, statValues(stats)["total-free-points"])
+	})
+
+	t.Run("respec", func(t *testing.T) {
+		repos := newRepositories(t)
+		name := username("respec")
+
+		_, err := repos.Stats.AddFreePoints(ctx, name, 4)
+		require.NoError(t, err)
+		_, _, err = repos.Stats.UpdateUserStat(ctx, name, "strength", 3)
+		require.NoError(t, err)
+		_, _, err = repos.Stats.UpdateUserStat(ctx, name, "agility", 1)
+		require.NoError(t, err)
+
+		refunded, _, err := repos.Stats.RespecUserStats(ctx, name, time.Hour)
+		require.NoError(t, err)
+		require.Equal(t, 4, refunded)
+
+		stats, err := repos.Stats.GetTwitchUserStats(ctx, name)
+		require.NoError(t, err)
+		values := statValues(stats)
+		require.Equal(t, 1, values["strength"])
+		require.Equal(t, 1, values["agility"])
+		require.Equal(t, 4, values["free-points"])
+
+		_, remaining, err := repos.Stats.RespecUserStats(ctx, name, time.Hour)
+		require.ErrorIs(t, err, db.ErrRespecOnCooldown)
+		require.Greater(t, remaining, 59*time.Minute)
+	})
+
+	t.Run("duel results", func(t *testing.T) {
+		repos := newRepositories(t)
+		initiator, challenger := username("initiator"), username("challenger")
+		for _, name := range []string{initiator, challenger} {
+			_, err := repos.Stats.GetOrCreateUserStats(ctx, name)
+			require.NoError(t, err)
+		}
+		initiatorUser, err := repos.Users.GetUser(ctx, initiator)
+		require.NoError(t, err)
+		challengerUser, err := repos.Users.GetUser(ctx, challenger)
+		require.NoError(t, err)
+
+		results, err := repos.Results.GetUserResults(ctx, initiatorUser.ID)
+		require.NoError(t, err)
+		require.Nil(t, results)
+
+		//Points from other rewards don't raise the duel thresholds
+		_, err = repos.Stats.AddFreePoints(ctx, initiator, 10)
+		require.NoError(t, err)
+
+		require.NoError(t, repos.Results.UpdateResultsAfterDuel(ctx, initiator, challenger, 0))
+		//The 10th win grants a free point
+		for range 10 {
+			require.NoError(t, repos.Results.UpdateResultsAfterDuel(ctx, initiator, challenger, 1))
+		}
+
+		require.Error(t, repos.Results.UpdateResultsAfterDuel(ctx, initiator, challenger, 3))
+		require.Error(t, repos.Results.UpdateResultsAfterDuel(ctx, initiator, username("missing"), 1))
+		require.Error(t, repos.Results.IncrementUserResult(ctx, initiatorUser.ID, "surrender"))
+
+		results, err = repos.Results.GetUserResults(ctx, initiatorUser.ID)
+		require.NoError(t, err)
+		require.Equal(t, 10, results.Wins)
+		require.Equal(t, 1, results.Draws)
+		require.Equal(t, 0, results.Loses)
+		require.Equal(t, 1, results.DuelPoints)
+
+		results, err = repos.Results.GetUserResults(ctx, challengerUser.ID)
+		require.NoError(t, err)
+		require.Equal(t, 10, results.Loses)
+
+		stats, err := repos.Stats.GetTwitchUserStats(ctx, initiator)
+		require.NoError(t, err)
+		require.Equal(t, 11, statValues(stats)["free-points"])
+		require.Equal(t, 11, statValues(stats)["total-free-points"])
+	})
+
+	t.Run("items", func(t *testing.T) {
+		repos := newRepositories(t)
+		name := username("buyer")
+
+		items, err := repos.Items.GetUserItems(ctx, name)
+		require.NoError(t, err)
+		require.Empty(t, items)
+
+		_, err = repos.Stats.AddFreePoints(ctx, name, 5)
+		require.NoError(t, err)
+		freePoints, err := repos.Items.BuyItem(ctx, name, "Sword", "weapon", 2)
+		require.NoError(t, err)
+		require.Equal(t, 3, freePoints)
+		_, err = repos.Items.BuyItem(ctx, name, "Sword", "weapon", 2)
+		require.ErrorIs(t, err, db.ErrItemAlreadyOwned)
+		_, err = repos.Items.BuyItem(ctx, name, "Crown", "head", 4)
+		require.ErrorIs(t, err, db.ErrNotEnoughFreePoints)
+		_, err = repos.Items.BuyItem(ctx, name, "Axe", "weapon", 3)
+		require.NoError(t, err)
+
+		stats, err := repos.Stats.GetTwitchUserStats(ctx, name)
+		require.NoError(t, err)
+		require.Equal(t, 0, statValues(stats)["free-points"], "a failed purchase takes nothing")
+
+		_, err = repos.Items.EquipItem(ctx, name, "Crown")
+		require.ErrorIs(t, err, db.ErrItemNotOwned)
+		replaced, err := repos.Items.EquipItem(ctx, name, "Sword")
+		require.NoError(t, err)
+		require.Empty(t, replaced)
+		replaced, err = repos.Items.EquipItem(ctx, name, "Axe")
+		require.NoError(t, err)
+		require.Equal(t, "Sword", replaced)
+
+		items, err = repos.Items.GetUserItems(ctx, name)
+		require.NoError(t, err)
+		require.Len(t, items, 2)
+		require.Equal(t, "Axe", items[0].ItemName, "equipped items come first")
+		require.True(t, items[0].Equipped)
+		require.False(t, items[1].Equipped)
+
+		unequipped, err := repos.Items.UnequipItem(ctx, name, "weapon")
+		require.NoError(t, err)
+		require.Equal(t, "Axe", unequipped)
+		unequipped, err = repos.Items.UnequipItem(ctx, name, "weapon")
+		require.NoError(t, err)
+		require.Empty(t, unequipped)
+	})
+}
@@ -0,0 +1,98 @@
+package database
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"time"
+)
+
+type UserAchievement struct {
+	AchievementID string
+	UnlockedAt    time.Time
+}
+
+func (d *Database) IncrementAchievementProgress(ctx context.Context, username string, event string) (int, error) {
+	var count int
+	err := d.WithTransaction(ctx, func(tx *sql.Tx) error {
+		userID, err := d.scoped(tx).getOrCreateUser(ctx, username)
+		if err != nil {
+			return fmt.Errorf("user setup failed: %w", err)
+		}
+
+		const query = `
+			INSERT INTO achievement_progress (user_id, event, count)
+			VALUES ($1, $2, 1)
+			ON CONFLICT (user_id, event) DO UPDATE
+			SET count = achievement_progress.count + 1
+			RETURNING count
+		`
+		return tx.QueryRowContext(ctx, query, userID, event).Scan(&count)
+	})
+	if err != nil {
+		return 0, fmt.Errorf("failed to update achievement progress: %w", err)
+	}
+
+	return count, nil
+}
+
+func (d *Database) UnlockAchievement(ctx context.Context, username string, achievementID string) (bool, error) {
+	var unlocked bool
+	err := d.WithTransaction(ctx, func(tx *sql.Tx) error {
+		userID, err := d.scoped(tx).getOrCreateUser(ctx, username)
+		if err != nil {
+			return fmt.Errorf("user setup failed: %w", err)
+		}
+
+		const query = `
+			INSERT INTO user_achievements (user_id, achievement_id)
+			VALUES ($1, $2)
+			ON CONFLICT (user_id, achievement_id) DO NOTHING
+		`
+		res, err := tx.ExecContext(ctx, query, userID, achievementID)
+		if err != nil {
+			return err
+		}
+
+		affected, err := res.RowsAffected()
+		unlocked = affected > 0
+		return err
+	})
+	if err != nil {
+		return false, fmt.Errorf("failed to unlock achievement: %w", err)
+	}
+
+	return unlocked, nil
+}
+
+func (d *Database) GetUserAchievements(ctx context.Context, username string) ([]UserAchievement, error) {
+	var achievements []UserAchievement
+	err := d.WithTransaction(ctx, func(tx *sql.Tx) error {
+		const query = `
+			SELECT ua.achievement_id, ua.unlocked_at
+			FROM user_achievements ua
+			JOIN users u ON u.id = ua.user_id
+			WHERE u.username = $1
+			ORDER BY ua.unlocked_at
+		`
+		rows, err := tx.QueryContext(ctx, query, username)
+		if err != nil {
+			return err
+		}
+		defer rows.Close()
+
+		for rows.Next() {
+			var achievement UserAchievement
+			if err := rows.Scan(&achievement.AchievementID, &achievement.UnlockedAt); err != nil {
+				return fmt.Errorf("failed to scan achievement row: %w", err)
+			}
+			achievements = append(achievements, achievement)
+		}
+		return rows.Err()
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to get user achievements: %w", err)
+	}
+
+	return achievements, nil
+}
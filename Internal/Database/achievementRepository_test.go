@@ -0,0 +1,69 @@
+package database
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/stretchr/testify/require"
+)
+
+func TestUnlockAchievement(t *testing.T) {
+	for _, tc := range []struct {
+		name     string
+		affected int64
+		unlocked bool
+	}{
+		{name: "new achievement", affected: 1, unlocked: true},
+		{name: "already unlocked", affected: 0, unlocked: false},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			db, mock, err := sqlmock.New()
+			require.NoError(t, err)
+			defer db.Close()
+
+			mock.ExpectBegin()
+			mock.ExpectQuery("INSERT INTO users .* ON CONFLICT \\(username\\)").
+				WithArgs("testuser").
+				WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow(1))
+			mock.ExpectExec("INSERT INTO user_achievements \\(user_id, achievement_id\\) .* ON CONFLICT \\(user_id, achievement_id\\) DO NOTHING").
+				WithArgs(1, "first-duel-win").
+				WillReturnResult(sqlmock.NewResult(0, tc.affected))
+			mock.ExpectCommit()
+
+			database := &Database{db: db}
+			unlocked, err := database.UnlockAchievement(context.Background(), "testuser", "first-duel-win")
+
+			require.NoError(t, err)
+			require.Equal(t, tc.unlocked, unlocked)
+			require.NoError(t, mock.ExpectationsWereMet())
+		})
+	}
+}
+
+func TestGetUserAchievements(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	require.NoError(t, err)
+	defer db.Close()
+
+	unlockedAt := time.Date(2025, 5, 17, 20, 0, 0, 0, time.UTC)
+
+	mock.ExpectBegin()
+	mock.ExpectQuery("SELECT ua.achievement_id, ua.unlocked_at FROM user_achievements ua JOIN users u .* WHERE u.username = \\$1").
+		WithArgs("testuser").
+		WillReturnRows(sqlmock.NewRows([]string{"achievement_id", "unlocked_at"}).
+			AddRow("first-duel-win", unlockedAt).
+			AddRow("max-strength", unlockedAt))
+	mock.ExpectCommit()
+
+	database := &Database{db: db}
+	achievements, err := database.GetUserAchievements(context.Background(), "testuser")
+
+	require.NoError(t, err)
+	require.Equal(t, []UserAchievement{
+		{AchievementID: "first-duel-win", UnlockedAt: unlockedAt},
+		{AchievementID: "max-strength", UnlockedAt: unlockedAt},
+	}, achievements)
+	require.NoError(t, mock.ExpectationsWereMet())
+}
@@ -3,6 +3,7 @@ package database
 import (
 	"context"
 	"database/sql"
+	"errors"
 	"fmt"
 	"log"
 	"time"
@@ -11,15 +12,17 @@ import (
 )
 
 type Database struct {
-	db DB
+	//*sql.DB, or *sql.Tx when the Database is scoped to a transaction
+	db      DBTX
+	dialect Dialect
 }
 
-var (
-	dbInstance *Database
-)
-
 func New(connectionString string) (*Database, error) {
-	db, err := sql.Open("postgres", connectionString)
+	return open("postgres", connectionString, Postgres)
+}
+
+func open(driverName string, dataSource string, dialect Dialect) (*Database, error) {
+	db, err := sql.Open(driverName, dataSource)
 	if err != nil {
 		return nil, fmt.Errorf("failed to open database. Error: %s", err)
 	}
@@ -28,18 +31,18 @@ func New(connectionString string) (*Database, error) {
 	defer cancel()
 
 	if err := db.PingContext(ctx); err != nil {
+		db.Close()
 		return nil, fmt.Errorf("failed to ping database: %s", err)
 	}
 
-	log.Printf("[%s] ✅Successfully connected to database.", time.Now().Format("15:04:05"))
-	dbInstance = &Database{db: db}
-	return dbInstance, nil
-}
-
-func GetInstance() *Database {
-	return dbInstance
+	log.Printf("[%s] ✅Successfully connected to %s database.", time.Now().Format("15:04:05"), dialect)
+	return &Database{db: db, dialect: dialect}, nil
 }
 
 func (database *Database) Close() error {
-	return database.db.(*sql.DB).Close()
+	db, ok := database.db.(*sql.DB)
+	if !ok {
+		return errors.New("a transaction-scoped database can't be closed")
+	}
+	return db.Close()
 }
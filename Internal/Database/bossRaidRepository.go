@@ -0,0 +1,55 @@
+package database
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"time"
+)
+
+type BossRaidParticipant struct {
+	Username string
+	Damage   int
+	Reward   int
+}
+
+type BossRaidResult struct {
+	BossName     string
+	MaxHP        int
+	DamageDealt  int
+	Defeated     bool
+	StartedAt    time.Time
+	Participants []BossRaidParticipant
+}
+
+func (d *Database) SaveBossRaid(ctx context.Context, raid BossRaidResult) (int, error) {
+	var raidID int
+	err := d.WithTransaction(ctx, func(tx *sql.Tx) error {
+		const insertRaid = `
+			INSERT INTO boss_raids (boss_name, max_hp, damage_dealt, defeated, started_at)
+			VALUES ($1, $2, $3, $4, $5)
+			RETURNING id
+		`
+		err := tx.QueryRowContext(ctx, insertRaid, raid.BossName, raid.MaxHP, raid.DamageDealt, raid.Defeated, raid.StartedAt).Scan(&raidID)
+		if err != nil {
+			return err
+		}
+
+		const insertParticipant = `
+			INSERT INTO boss_raid_participants (raid_id, username, damage, reward)
+			VALUES ($1, $2, $3, $4)
+		`
+		for _, participant := range raid.Participants {
+			if _, err := tx.ExecContext(ctx, insertParticipant, raidID, participant.Username, participant.Damage, participant.Reward); err != nil {
+				return fmt.Errorf("failed to save participant %s: %w", participant.Username, err)
+			}
+		}
+
+		return nil
+	})
+	if err != nil {
+		return 0, fmt.Errorf("failed to save boss raid: %w", err)
+	}
+
+	return raidID, nil
+}
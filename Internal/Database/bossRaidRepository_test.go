@@ -0,0 +1,48 @@
+package database
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSaveBossRaid(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	require.NoError(t, err)
+	defer db.Close()
+
+	startedAt := time.Now().Add(-2 * time.Minute)
+	raid := BossRaidResult{
+		BossName:    "Giant Slime",
+		MaxHP:       100,
+		DamageDealt: 100,
+		Defeated:    true,
+		StartedAt:   startedAt,
+		Participants: []BossRaidParticipant{
+			{Username: "knight", Damage: 70, Reward: 2},
+			{Username: "archer", Damage: 30, Reward: 0},
+		},
+	}
+
+	mock.ExpectBegin()
+	mock.ExpectQuery("INSERT INTO boss_raids .* RETURNING id").
+		WithArgs("Giant Slime", 100, 100, true, startedAt).
+		WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow(9))
+	mock.ExpectExec("INSERT INTO boss_raid_participants").
+		WithArgs(9, "knight", 70, 2).
+		WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectExec("INSERT INTO boss_raid_participants").
+		WithArgs(9, "archer", 30, 0).
+		WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectCommit()
+
+	database := &Database{db: db}
+	id, err := database.SaveBossRaid(context.Background(), raid)
+
+	require.NoError(t, err)
+	require.Equal(t, 9, id)
+	require.NoError(t, mock.ExpectationsWereMet())
+}
@@ -0,0 +1,44 @@
+package database_test
+
+import (
+	db "TelTwBot/Internal/Database"
+	repotest "TelTwBot/Internal/Database/RepoTest"
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+// TestPostgresRepositoryContract runs the repository contract against a real database (the migrations are
+// applied first), e.g. TELTW_TEST_DATABASE_URL="host=localhost user=postgres dbname=teltw_test sslmode=disable".
+func TestPostgresRepositoryContract(t *testing.T) {
+	connectionString := os.Getenv("TELTW_TEST_DATABASE_URL")
+	if connectionString == "" {
+		t.Skip("TELTW_TEST_DATABASE_URL is not set")
+	}
+
+	database, err := db.New(connectionString)
+	require.NoError(t, err)
+	defer database.Close()
+
+	_, err = database.MigrateUp(context.Background())
+	require.NoError(t, err)
+
+	repotest.RunRepositoryContract(t, func(t *testing.T) db.Repositories {
+		return database.Repositories()
+	})
+}
+
+func TestSQLiteRepositoryContract(t *testing.T) {
+	repotest.RunRepositoryContract(t, func(t *testing.T) db.Repositories {
+		database, err := db.NewSQLite(filepath.Join(t.TempDir(), "bot.db"))
+		require.NoError(t, err)
+		t.Cleanup(func() { database.Close() })
+
+		_, err = database.MigrateUp(context.Background())
+		require.NoError(t, err)
+		return database.Repositories()
+	})
+}
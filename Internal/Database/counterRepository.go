@@ -0,0 +1,153 @@
+package database
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+)
+
+type Counter struct {
+	ID      int
+	Name    string
+	PerGame bool
+}
+
+func (d *Database) CreateCounter(ctx context.Context, name string, perGame bool) (*Counter, error) {
+	var counter Counter
+	err := d.WithTransaction(ctx, func(tx *sql.Tx) error {
+		const query = `
+			INSERT INTO counters (name, per_game)
+			VALUES ($1, $2)
+			ON CONFLICT (name) DO UPDATE SET per_game = EXCLUDED.per_game
+			RETURNING id, name, per_game
+		`
+		return tx.QueryRowContext(ctx, query, name, perGame).Scan(&counter.ID, &counter.Name, &counter.PerGame)
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to create counter: %w", err)
+	}
+
+	return &counter, nil
+}
+
+func (d *Database) DeleteCounter(ctx context.Context, name string) (bool, error) {
+	var deleted bool
+	err := d.WithTransaction(ctx, func(tx *sql.Tx) error {
+		res, err := tx.ExecContext(ctx, `DELETE FROM counters WHERE name = $1`, name)
+		if err != nil {
+			return err
+		}
+
+		affected, err := res.RowsAffected()
+		deleted = affected > 0
+		return err
+	})
+	if err != nil {
+		return false, fmt.Errorf("failed to delete counter: %w", err)
+	}
+
+	return deleted, nil
+}
+
+// GetCounter returns nil if the counter doesn't exist.
+func (d *Database) GetCounter(ctx context.Context, name string) (*Counter, error) {
+	var result *Counter
+	err := d.WithTransaction(ctx, func(tx *sql.Tx) error {
+		var counter Counter
+		err := tx.QueryRowContext(ctx, `SELECT id, name, per_game FROM counters WHERE name = $1`, name).
+			Scan(&counter.ID, &counter.Name, &counter.PerGame)
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil
+		}
+		if err != nil {
+			return err
+		}
+
+		result = &counter
+		return nil
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to get counter: %w", err)
+	}
+
+	return result, nil
+}
+
+func (d *Database) GetCounters(ctx context.Context) ([]Counter, error) {
+	var counters []Counter
+	err := d.WithTransaction(ctx, func(tx *sql.Tx) error {
+		rows, err := tx.QueryContext(ctx, `SELECT id, name, per_game FROM counters ORDER BY name`)
+		if err != nil {
+			return err
+		}
+		defer rows.Close()
+
+		for rows.Next() {
+			var counter Counter
+			if err := rows.Scan(&counter.ID, &counter.Name, &counter.PerGame); err != nil {
+				return fmt.Errorf("failed to scan counter row: %w", err)
+			}
+			counters = append(counters, counter)
+		}
+
+		return rows.Err()
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to get counters: %w", err)
+	}
+
+	return counters, nil
+}
+
+func (d *Database) GetCounterValue(ctx context.Context, counterID int, game string) (int, error) {
+	var value int
+	err := d.WithTransaction(ctx, func(tx *sql.Tx) error {
+		err := tx.QueryRowContext(ctx, `SELECT value FROM counter_values WHERE counter_id = $1 AND game = $2`, counterID, game).Scan(&value)
+		if errors.Is(err, sql.ErrNoRows) {
+			value = 0
+			return nil
+		}
+		return err
+	})
+	if err != nil {
+		return 0, fmt.Errorf("failed to get counter value: %w", err)
+	}
+
+	return value, nil
+}
+
+// AddToCounter changes the counter by delta (the value never goes below zero) and returns the new value.
+func (d *Database) AddToCounter(ctx context.Context, counterID int, game string, delta int) (int, error) {
+	var value int
+	err := d.WithTransaction(ctx, func(tx *sql.Tx) error {
+		const query = `
+			INSERT INTO counter_values (counter_id, game, value)
+			VALUES ($1, $2, GREATEST($3, 0))
+			ON CONFLICT (counter_id, game) DO UPDATE
+			SET value = GREATEST(counter_values.value + $3, 0), updated_at = NOW()
+			RETURNING value
+		`
+		return tx.QueryRowContext(ctx, query, counterID, game, delta).Scan(&value)
+	})
+	if err != nil {
+		return 0, fmt.Errorf("failed to update counter: %w", err)
+	}
+
+	return value, nil
+}
+
+func (d *Database) SetCounter(ctx context.Context, counterID int, game string, value int) error {
+	return d.WithTransaction(ctx, func(tx *sql.Tx) error {
+		const query = `
+			INSERT INTO counter_values (counter_id, game, value)
+			VALUES ($1, $2, $3)
+			ON CONFLICT (counter_id, game) DO UPDATE
+			SET value = EXCLUDED.value, updated_at = NOW()
+		`
+		if _, err := tx.ExecContext(ctx, query, counterID, game, value); err != nil {
+			return fmt.Errorf("failed to set counter: %w", err)
+		}
+		return nil
+	})
+}
@@ -0,0 +1,68 @@
+package database
+
+import (
+	"context"
+	"testing"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/stretchr/testify/require"
+)
+
+func TestGetCounter(t *testing.T) {
+	t.Run("existing counter", func(t *testing.T) {
+		db, mock, err := sqlmock.New()
+		require.NoError(t, err)
+		defer db.Close()
+
+		mock.ExpectBegin()
+		mock.ExpectQuery("SELECT id, name, per_game FROM counters WHERE name = \\$1").
+			WithArgs("deaths").
+			WillReturnRows(sqlmock.NewRows([]string{"id", "name", "per_game"}).AddRow(1, "deaths", true))
+		mock.ExpectCommit()
+
+		database := &Database{db: db}
+		counter, err := database.GetCounter(context.Background(), "deaths")
+
+		require.NoError(t, err)
+		require.Equal(t, &Counter{ID: 1, Name: "deaths", PerGame: true}, counter)
+		require.NoError(t, mock.ExpectationsWereMet())
+	})
+
+	t.Run("missing counter", func(t *testing.T) {
+		db, mock, err := sqlmock.New()
+		require.NoError(t, err)
+		defer db.Close()
+
+		mock.ExpectBegin()
+		mock.ExpectQuery("SELECT id, name, per_game FROM counters WHERE name = \\$1").
+			WithArgs("hello").
+			WillReturnRows(sqlmock.NewRows([]string{"id", "name", "per_game"}))
+		mock.ExpectCommit()
+
+		database := &Database{db: db}
+		counter, err := database.GetCounter(context.Background(), "hello")
+
+		require.NoError(t, err)
+		require.Nil(t, counter)
+		require.NoError(t, mock.ExpectationsWereMet())
+	})
+}
+
+func TestAddToCounter(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	require.NoError(t, err)
+	defer db.Close()
+
+	mock.ExpectBegin()
+	mock.ExpectQuery("INSERT INTO counter_values .* ON CONFLICT \\(counter_id, game\\) DO UPDATE .* RETURNING value").
+		WithArgs(1, "Elden Ring", -1).
+		WillReturnRows(sqlmock.NewRows([]string{"value"}).AddRow(41))
+	mock.ExpectCommit()
+
+	database := &Database{db: db}
+	value, err := database.AddToCounter(context.Background(), 1, "Elden Ring", -1)
+
+	require.NoError(t, err)
+	require.Equal(t, 41, value)
+	require.NoError(t, mock.ExpectationsWereMet())
+}
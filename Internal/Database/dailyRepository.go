@@ -0,0 +1,169 @@
+package database
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"time"
+)
+
+type DailyStreak struct {
+	Username   string
+	Streak     int
+	BestStreak int
+	LastClaim  time.Time
+}
+
+type DailyResult struct {
+	Claimed    bool
+	Streak     int
+	BestStreak int
+	Reward     int
+	FreePoints int
+}
+
+// IsActive reports whether the streak can still be continued today.
+func (ds DailyStreak) IsActive(today time.Time, graceDays int) bool {
+	return daysBetween(ds.LastClaim, today) <= graceDays+1
+}
+
+// ClaimDaily claims the daily reward for the day (calendar day in the channel timezone). The streak continues if
+// the previous claim was at most graceDays+1 days ago, otherwise it starts over. rewardForStreak returns the free
+// points for the new streak. If the reward was already claimed for the day, Claimed is false.
+func (d *Database) ClaimDaily(ctx context.Context, username string, day time.Time, graceDays int, rewardForStreak func(streak int) int) (*DailyResult, error) {
+	var result DailyResult
+	err := d.WithTransaction(ctx, func(tx *sql.Tx) error {
+		//The reward is given in free points, so user and stats should exist
+		if _, err := d.scoped(tx).GetOrCreateUserStats(ctx, username); err != nil {
+			return fmt.Errorf("failed to ensure stats exist: %w", err)
+		}
+
+		const getStreakQuery = `
+			SELECT ds.streak, ds.best_streak, ds.last_claim
+			FROM daily_streaks ds
+			JOIN users u ON u.id = ds.user_id
+			WHERE u.username = $1
+		`
+		var streak DailyStreak
+		err := tx.QueryRowContext(ctx, getStreakQuery, username).Scan(&streak.Streak, &streak.BestStreak, &streak.LastClaim)
+		if err != nil && !errors.Is(err, sql.ErrNoRows) {
+			return fmt.Errorf("couldn't get streak: %w", err)
+		}
+
+		result.Streak, result.BestStreak = streak.Streak, streak.BestStreak
+		if err == nil && daysBetween(streak.LastClaim, day) <= 0 {
+			return nil
+		}
+
+		if err == nil && streak.IsActive(day, graceDays) {
+			result.Streak = streak.Streak + 1
+		} else {
+			result.Streak = 1
+		}
+		result.BestStreak = max(result.BestStreak, result.Streak)
+
+		//The WHERE makes the claim once per day even if two claims run at the same time
+		const saveStreakQuery = `
+			INSERT INTO daily_streaks (user_id, streak, best_streak, last_claim)
+			SELECT id, $2, $3, $4 FROM users WHERE username = $1
+			ON CONFLICT (user_id) DO UPDATE
+			SET streak = EXCLUDED.streak, best_streak = EXCLUDED.best_streak, last_claim = EXCLUDED.last_claim, updated_at = NOW()
+			WHERE daily_streaks.last_claim < EXCLUDED.last_claim
+		`
+		res, err := tx.ExecContext(ctx, saveStreakQuery, username, result.Streak, result.BestStreak, day.Format("2006-01-02"))
+		if err != nil {
+			return fmt.Errorf("couldn't save streak: %w", err)
+		}
+		if affected, err := res.RowsAffected(); err != nil {
+			return err
+		} else if affected == 0 {
+			result.Streak, result.BestStreak = streak.Streak, streak.BestStreak
+			return nil
+		}
+
+		result.Claimed = true
+		result.Reward = rewardForStreak(result.Streak)
+		if result.Reward > 0 {
+			result.FreePoints, err = addFreePointsTx(ctx, tx, username, result.Reward)
+			return err
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to claim daily reward: %w", err)
+	}
+
+	return &result, nil
+}
+
+// GetDailyStreak returns nil if the user has never claimed the daily reward.
+func (d *Database) GetDailyStreak(ctx context.Context, username string) (*DailyStreak, error) {
+	var result *DailyStreak
+	err := d.WithTransaction(ctx, func(tx *sql.Tx) error {
+		const query = `
+			SELECT ds.streak, ds.best_streak, ds.last_claim
+			FROM daily_streaks ds
+			JOIN users u ON u.id = ds.user_id
+			WHERE u.username = $1
+		`
+		streak := DailyStreak{Username: username}
+		err := tx.QueryRowContext(ctx, query, username).Scan(&streak.Streak, &streak.BestStreak, &streak.LastClaim)
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil
+		}
+		if err != nil {
+			return err
+		}
+
+		result = &streak
+		return nil
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to get daily streak: %w", err)
+	}
+
+	return result, nil
+}
+
+// GetTopDailyStreaks returns the longest streaks that are still active today.
+func (d *Database) GetTopDailyStreaks(ctx context.Context, today time.Time, graceDays int, limit int) ([]DailyStreak, error) {
+	var streaks []DailyStreak
+	err := d.WithTransaction(ctx, func(tx *sql.Tx) error {
+		const query = `
+			SELECT u.username, ds.streak, ds.best_streak, ds.last_claim
+			FROM daily_streaks ds
+			JOIN users u ON u.id = ds.user_id
+			WHERE ds.last_claim >= $1
+			ORDER BY ds.streak DESC, ds.best_streak DESC, u.username
+			LIMIT $2
+		`
+		activeSince := today.AddDate(0, 0, -(graceDays + 1)).Format("2006-01-02")
+		rows, err := tx.QueryContext(ctx, query, activeSince, limit)
+		if err != nil {
+			return err
+		}
+		defer rows.Close()
+
+		for rows.Next() {
+			var streak DailyStreak
+			if err := rows.Scan(&streak.Username, &streak.Streak, &streak.BestStreak, &streak.LastClaim); err != nil {
+				return fmt.Errorf("failed to scan streak row: %w", err)
+			}
+			streaks = append(streaks, streak)
+		}
+		return rows.Err()
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to get top daily streaks: %w", err)
+	}
+
+	return streaks, nil
+}
+
+// daysBetween counts calendar days, the time of the day and the timezone are ignored.
+func daysBetween(from time.Time, to time.Time) int {
+	fromDate := time.Date(from.Year(), from.Month(), from.Day(), 0, 0, 0, 0, time.UTC)
+	toDate := time.Date(to.Year(), to.Month(), to.Day(), 0, 0, 0, 0, time.UTC)
+	return int(toDate.Sub(fromDate).Hours() / 24)
+}
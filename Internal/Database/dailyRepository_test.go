@@ -0,0 +1,115 @@
+package database
+
+import (
+	"context"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/stretchr/testify/require"
+)
+
+func TestDailyStreakIsActive(t *testing.T) {
+	today := time.Date(2025, 5, 17, 0, 0, 0, 0, time.FixedZone("UTC+3", 3*60*60))
+
+	for _, tc := range []struct {
+		name      string
+		lastClaim time.Time
+		graceDays int
+		active    bool
+	}{
+		{name: "claimed today", lastClaim: time.Date(2025, 5, 17, 0, 0, 0, 0, time.UTC), graceDays: 0, active: true},
+		{name: "claimed yesterday", lastClaim: time.Date(2025, 5, 16, 0, 0, 0, 0, time.UTC), graceDays: 0, active: true},
+		{name: "missed a day without grace", lastClaim: time.Date(2025, 5, 15, 0, 0, 0, 0, time.UTC), graceDays: 0, active: false},
+		{name: "missed a day with grace", lastClaim: time.Date(2025, 5, 15, 0, 0, 0, 0, time.UTC), graceDays: 1, active: true},
+		{name: "missed two days with grace", lastClaim: time.Date(2025, 5, 14, 0, 0, 0, 0, time.UTC), graceDays: 1, active: false},
+		{name: "across months", lastClaim: time.Date(2025, 4, 30, 0, 0, 0, 0, time.UTC), graceDays: 0, active: false},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			streak := DailyStreak{Streak: 5, LastClaim: tc.lastClaim}
+			require.Equal(t, tc.active, streak.IsActive(today, tc.graceDays))
+		})
+	}
+}
+
+func TestGetTopDailyStreaks(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	require.NoError(t, err)
+	defer db.Close()
+
+	today := time.Date(2025, 5, 17, 0, 0, 0, 0, time.UTC)
+	lastClaim := time.Date(2025, 5, 16, 0, 0, 0, 0, time.UTC)
+
+	mock.ExpectBegin()
+	mock.ExpectQuery("SELECT u.username, ds.streak, ds.best_streak, ds.last_claim FROM daily_streaks ds .* WHERE ds.last_claim >= \\$1").
+		WithArgs("2025-05-15", 10).
+		WillReturnRows(sqlmock.NewRows([]string{"username", "streak", "best_streak", "last_claim"}).
+			AddRow("alice", 12, 20, lastClaim).
+			AddRow("bob", 3, 3, today))
+	mock.ExpectCommit()
+
+	database := &Database{db: db}
+	streaks, err := database.GetTopDailyStreaks(context.Background(), today, 1, 10)
+
+	require.NoError(t, err)
+	require.Equal(t, []DailyStreak{
+		{Username: "alice", Streak: 12, BestStreak: 20, LastClaim: lastClaim},
+		{Username: "bob", Streak: 3, BestStreak: 3, LastClaim: today},
+	}, streaks)
+	require.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestClaimDaily(t *testing.T) {
+	ctx := context.Background()
+	database := newSQLiteTestDB(t)
+	day := time.Date(2025, 5, 17, 0, 0, 0, 0, time.UTC)
+	reward := func(streak int) int { return streak * 10 }
+
+	for _, tc := range []struct {
+		name       string
+		previous   []int //days of the earlier claims, relative to day
+		graceDays  int
+		claimed    bool
+		streak     int
+		bestStreak int
+		freePoints int
+	}{
+		{name: "first claim", claimed: true, streak: 1, bestStreak: 1, freePoints: 10},
+		{name: "continues the streak", previous: []int{-2, -1}, claimed: true, streak: 3, bestStreak: 3, freePoints: 60},
+		{name: "missed day within grace", previous: []int{-3, -2}, graceDays: 1, claimed: true, streak: 3, bestStreak: 3, freePoints: 60},
+		{name: "missed day without grace", previous: []int{-3, -2}, claimed: true, streak: 1, bestStreak: 2, freePoints: 40},
+		{name: "resets but keeps the best", previous: []int{-9, -8, -7, -1}, claimed: true, streak: 2, bestStreak: 3, freePoints: 90},
+		{name: "double claim", previous: []int{-1, 0}, claimed: false, streak: 2, bestStreak: 2, freePoints: 30},
+		{name: "claim for an earlier day", previous: []int{1}, claimed: false, streak: 1, bestStreak: 1, freePoints: 10},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			username := strings.ReplaceAll(tc.name, " ", "_")
+			for _, offset := range tc.previous {
+				result, err := database.ClaimDaily(ctx, username, day.AddDate(0, 0, offset), tc.graceDays, reward)
+				require.NoError(t, err)
+				require.True(t, result.Claimed)
+			}
+
+			result, err := database.ClaimDaily(ctx, username, day, tc.graceDays, reward)
+			require.NoError(t, err)
+			require.Equal(t, tc.claimed, result.Claimed)
+			require.Equal(t, tc.streak, result.Streak)
+			require.Equal(t, tc.bestStreak, result.BestStreak)
+			if tc.claimed {
+				require.Equal(t, reward(tc.streak), result.Reward)
+				require.Equal(t, tc.freePoints, result.FreePoints)
+			} else {
+				require.Zero(t, result.Reward)
+			}
+
+			stats, err := database.GetTwitchUserStats(ctx, username)
+			require.NoError(t, err)
+			for _, stat := range stats {
+				if stat.StatType == "free-points" {
+					require.Equal(t, tc.freePoints, stat.Value)
+				}
+			}
+		})
+	}
+}
@@ -5,7 +5,14 @@ import (
 	"database/sql"
 )
 
-type DB interface {
+// DBTX is what the repositories query through, it's either *sql.DB or *sql.Tx (for a tx-scoped Database).
+type DBTX interface {
 	ExecContext(ctx context.Context, query string, args ...any) (sql.Result, error)
+	QueryContext(ctx context.Context, query string, args ...any) (*sql.Rows, error)
 	QueryRowContext(ctx context.Context, query string, args ...any) *sql.Row
 }
+
+var (
+	_ DBTX = (*sql.DB)(nil)
+	_ DBTX = (*sql.Tx)(nil)
+)
@@ -0,0 +1,30 @@
+package database
+
+import "strings"
+
+// Dialect is the SQL flavor of the database. The queries are written so both databases run them (AS for aliases,
+// unqualified RETURNING columns), the SQLite backend provides NOW(), GREATEST() and LEAST() itself (see sqlite.go).
+type Dialect string
+
+const (
+	Postgres Dialect = "postgres"
+	SQLite   Dialect = "sqlite"
+)
+
+// sqliteSchema turns the Postgres types, defaults and functions of the migrations into SQLite ones.
+// Timestamps are stored as UTC text in the format the driver writes time.Time in, so they compare as strings.
+var sqliteSchema = strings.NewReplacer(
+	"SERIAL PRIMARY KEY", "INTEGER PRIMARY KEY AUTOINCREMENT",
+	"TIMESTAMP WITH TIME ZONE", "TIMESTAMP",
+	"DEFAULT NOW()", "DEFAULT (strftime('%Y-%m-%d %H:%M:%f+00:00', 'now'))",
+	"JSONB", "BLOB",
+	"lower(", "unicode_lower(",
+)
+
+// translateSchema translates schema statements (migrations) written for Postgres into the dialect.
+func translateSchema(dialect Dialect, statements string) string {
+	if dialect == SQLite {
+		return sqliteSchema.Replace(statements)
+	}
+	return statements
+}
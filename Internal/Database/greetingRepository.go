@@ -0,0 +1,125 @@
+package database
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+)
+
+// GetGreetingLanguage returns the language chosen by !hello <language>, or an empty string if there is none.
+func (d *Database) GetGreetingLanguage(ctx context.Context, username string) (string, error) {
+	var language string
+	err := d.WithTransaction(ctx, func(tx *sql.Tx) error {
+		const query = `
+			SELECT gp.language
+			FROM greeting_preferences gp
+			JOIN users u ON u.id = gp.user_id
+			WHERE u.username = $1
+		`
+		err := tx.QueryRowContext(ctx, query, username).Scan(&language)
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil
+		}
+		return err
+	})
+	if err != nil {
+		return "", fmt.Errorf("failed to get greeting language: %w", err)
+	}
+
+	return language, nil
+}
+
+// SetGreetingLanguage saves the greeting language of the user, an empty language means random greetings.
+func (d *Database) SetGreetingLanguage(ctx context.Context, username string, language string) error {
+	err := d.WithTransaction(ctx, func(tx *sql.Tx) error {
+		userID, err := d.scoped(tx).getOrCreateUser(ctx, username)
+		if err != nil {
+			return fmt.Errorf("user setup failed: %w", err)
+		}
+
+		const query = `
+			INSERT INTO greeting_preferences (user_id, language)
+			VALUES ($1, $2)
+			ON CONFLICT (user_id) DO UPDATE
+			SET language = EXCLUDED.language, updated_at = NOW()
+		`
+		_, err = tx.ExecContext(ctx, query, userID, language)
+		return err
+	})
+	if err != nil {
+		return fmt.Errorf("failed to save greeting language: %w", err)
+	}
+
+	return nil
+}
+
+// SetNoGreet turns the auto-greeting of the user off (or back on).
+func (d *Database) SetNoGreet(ctx context.Context, username string, noGreet bool) error {
+	err := d.WithTransaction(ctx, func(tx *sql.Tx) error {
+		userID, err := d.scoped(tx).getOrCreateUser(ctx, username)
+		if err != nil {
+			return fmt.Errorf("user setup failed: %w", err)
+		}
+
+		const query = `
+			INSERT INTO greeting_preferences (user_id, no_greet)
+			VALUES ($1, $2)
+			ON CONFLICT (user_id) DO UPDATE
+			SET no_greet = EXCLUDED.no_greet, updated_at = NOW()
+		`
+		_, err = tx.ExecContext(ctx, query, userID, noGreet)
+		return err
+	})
+	if err != nil {
+		return fmt.Errorf("failed to save greeting opt-out: %w", err)
+	}
+
+	return nil
+}
+
+type ChatterVisit struct {
+	//The user has never chatted before, the users row may already exist (e.g. it's created by commands).
+	//Users from before the chatters table are backfilled by 0005_backfill_chatters, so they aren't new.
+	IsNew bool
+	//Previous visit, not valid for new chatters
+	LastSeen sql.NullTime
+	NoGreet  bool
+}
+
+// RegisterChatter creates the user if needed, saves the visit and returns the previous one.
+func (d *Database) RegisterChatter(ctx context.Context, username string) (*ChatterVisit, error) {
+	var visit ChatterVisit
+	err := d.WithTransaction(ctx, func(tx *sql.Tx) error {
+		userID, err := d.scoped(tx).getOrCreateUser(ctx, username)
+		if err != nil {
+			return fmt.Errorf("user setup failed: %w", err)
+		}
+
+		err = tx.QueryRowContext(ctx, `SELECT last_seen_at FROM chatters WHERE user_id = $1`, userID).Scan(&visit.LastSeen)
+		if err != nil && !errors.Is(err, sql.ErrNoRows) {
+			return fmt.Errorf("couldn't get last visit: %w", err)
+		}
+		visit.IsNew = errors.Is(err, sql.ErrNoRows)
+
+		const saveVisitQuery = `
+			INSERT INTO chatters (user_id, last_seen_at)
+			VALUES ($1, NOW())
+			ON CONFLICT (user_id) DO UPDATE SET last_seen_at = EXCLUDED.last_seen_at
+		`
+		if _, err := tx.ExecContext(ctx, saveVisitQuery, userID); err != nil {
+			return fmt.Errorf("couldn't save visit: %w", err)
+		}
+
+		err = tx.QueryRowContext(ctx, `SELECT no_greet FROM greeting_preferences WHERE user_id = $1`, userID).Scan(&visit.NoGreet)
+		if err != nil && !errors.Is(err, sql.ErrNoRows) {
+			return fmt.Errorf("couldn't get greeting opt-out: %w", err)
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to register chatter: %w", err)
+	}
+
+	return &visit, nil
+}
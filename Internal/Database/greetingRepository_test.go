@@ -0,0 +1,106 @@
+package database
+
+import (
+	"context"
+	"database/sql"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/stretchr/testify/require"
+)
+
+func TestGetGreetingLanguage(t *testing.T) {
+	for _, tc := range []struct {
+		name     string
+		rows     *sqlmock.Rows
+		err      error
+		language string
+	}{
+		{name: "saved language", rows: sqlmock.NewRows([]string{"language"}).AddRow("Japanese"), language: "Japanese"},
+		{name: "no preference", err: sql.ErrNoRows, language: ""},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			db, mock, err := sqlmock.New()
+			require.NoError(t, err)
+			defer db.Close()
+
+			mock.ExpectBegin()
+			query := mock.ExpectQuery("SELECT gp.language FROM greeting_preferences gp JOIN users u .* WHERE u.username = \\$1").
+				WithArgs("testuser")
+			if tc.rows != nil {
+				query.WillReturnRows(tc.rows)
+			} else {
+				query.WillReturnError(tc.err)
+			}
+			mock.ExpectCommit()
+
+			database := &Database{db: db}
+			language, err := database.GetGreetingLanguage(context.Background(), "testuser")
+
+			require.NoError(t, err)
+			require.Equal(t, tc.language, language)
+			require.NoError(t, mock.ExpectationsWereMet())
+		})
+	}
+}
+
+func TestRegisterChatter(t *testing.T) {
+	lastSeen := time.Date(2025, 5, 1, 20, 0, 0, 0, time.UTC)
+
+	t.Run("first time chatter", func(t *testing.T) {
+		db, mock, err := sqlmock.New()
+		require.NoError(t, err)
+		defer db.Close()
+
+		mock.ExpectBegin()
+		mock.ExpectQuery("INSERT INTO users \\(username, created_at, updated_at\\) .* ON CONFLICT \\(username\\)").
+			WithArgs("newuser").
+			WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow(7))
+		mock.ExpectQuery("SELECT last_seen_at FROM chatters WHERE user_id = \\$1").
+			WithArgs(7).
+			WillReturnError(sql.ErrNoRows)
+		mock.ExpectExec("INSERT INTO chatters \\(user_id, last_seen_at\\)").
+			WithArgs(7).
+			WillReturnResult(sqlmock.NewResult(0, 1))
+		mock.ExpectQuery("SELECT no_greet FROM greeting_preferences WHERE user_id = \\$1").
+			WithArgs(7).
+			WillReturnError(sql.ErrNoRows)
+		mock.ExpectCommit()
+
+		database := &Database{db: db}
+		visit, err := database.RegisterChatter(context.Background(), "newuser")
+
+		require.NoError(t, err)
+		require.Equal(t, &ChatterVisit{IsNew: true}, visit)
+		require.NoError(t, mock.ExpectationsWereMet())
+	})
+
+	t.Run("returning chatter with opt-out", func(t *testing.T) {
+		db, mock, err := sqlmock.New()
+		require.NoError(t, err)
+		defer db.Close()
+
+		mock.ExpectBegin()
+		mock.ExpectQuery("INSERT INTO users \\(username, created_at, updated_at\\) .* ON CONFLICT \\(username\\)").
+			WithArgs("testuser").
+			WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow(1))
+		mock.ExpectQuery("SELECT last_seen_at FROM chatters WHERE user_id = \\$1").
+			WithArgs(1).
+			WillReturnRows(sqlmock.NewRows([]string{"last_seen_at"}).AddRow(lastSeen))
+		mock.ExpectExec("INSERT INTO chatters \\(user_id, last_seen_at\\)").
+			WithArgs(1).
+			WillReturnResult(sqlmock.NewResult(0, 1))
+		mock.ExpectQuery("SELECT no_greet FROM greeting_preferences WHERE user_id = \\$1").
+			WithArgs(1).
+			WillReturnRows(sqlmock.NewRows([]string{"no_greet"}).AddRow(true))
+		mock.ExpectCommit()
+
+		database := &Database{db: db}
+		visit, err := database.RegisterChatter(context.Background(), "testuser")
+
+		require.NoError(t, err)
+		require.Equal(t, &ChatterVisit{LastSeen: sql.NullTime{Time: lastSeen, Valid: true}, NoGreet: true}, visit)
+		require.NoError(t, mock.ExpectationsWereMet())
+	})
+}
@@ -0,0 +1,50 @@
+package database
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"time"
+)
+
+// GetHLTBCache returns the cached HowLongToBeat results of the query as JSON, or nil if there are none younger than maxAge.
+func (d *Database) GetHLTBCache(ctx context.Context, query string, maxAge time.Duration) ([]byte, error) {
+	var results []byte
+	err := d.WithTransaction(ctx, func(tx *sql.Tx) error {
+		const selectQuery = `
+			SELECT results
+			FROM hltb_cache
+			WHERE query = $1 AND fetched_at > $2
+		`
+		err := tx.QueryRowContext(ctx, selectQuery, query, time.Now().UTC().Add(-maxAge)).Scan(&results)
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil
+		}
+		return err
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to get cached HLTB results: %w", err)
+	}
+
+	return results, nil
+}
+
+// SaveHLTBCache saves (or refreshes) the HowLongToBeat results of the query.
+func (d *Database) SaveHLTBCache(ctx context.Context, query string, results []byte) error {
+	err := d.WithTransaction(ctx, func(tx *sql.Tx) error {
+		const upsertQuery = `
+			INSERT INTO hltb_cache (query, results, fetched_at)
+			VALUES ($1, $2, NOW())
+			ON CONFLICT (query) DO UPDATE
+			SET results = EXCLUDED.results, fetched_at = EXCLUDED.fetched_at
+		`
+		_, err := tx.ExecContext(ctx, upsertQuery, query, results)
+		return err
+	})
+	if err != nil {
+		return fmt.Errorf("failed to save HLTB results: %w", err)
+	}
+
+	return nil
+}
@@ -0,0 +1,64 @@
+package database
+
+import (
+	"context"
+	"database/sql"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/stretchr/testify/require"
+)
+
+func TestGetHLTBCache(t *testing.T) {
+	for _, tc := range []struct {
+		name    string
+		rows    *sqlmock.Rows
+		err     error
+		results []byte
+	}{
+		{name: "fresh results", rows: sqlmock.NewRows([]string{"results"}).AddRow([]byte(`[{"game_id":1}]`)), results: []byte(`[{"game_id":1}]`)},
+		{name: "missing or expired", err: sql.ErrNoRows, results: nil},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			db, mock, err := sqlmock.New()
+			require.NoError(t, err)
+			defer db.Close()
+
+			mock.ExpectBegin()
+			query := mock.ExpectQuery("SELECT results FROM hltb_cache WHERE query = \\$1 AND fetched_at > \\$2").
+				WithArgs("celeste", sqlmock.AnyArg())
+			if tc.rows != nil {
+				query.WillReturnRows(tc.rows)
+			} else {
+				query.WillReturnError(tc.err)
+			}
+			mock.ExpectCommit()
+
+			database := &Database{db: db}
+			results, err := database.GetHLTBCache(context.Background(), "celeste", time.Hour)
+
+			require.NoError(t, err)
+			require.Equal(t, tc.results, results)
+			require.NoError(t, mock.ExpectationsWereMet())
+		})
+	}
+}
+
+func TestSaveHLTBCache(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	require.NoError(t, err)
+	defer db.Close()
+
+	mock.ExpectBegin()
+	mock.ExpectExec("INSERT INTO hltb_cache \\(query, results, fetched_at\\)").
+		WithArgs("celeste", []byte(`[]`)).
+		WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectCommit()
+
+	database := &Database{db: db}
+	err = database.SaveHLTBCache(context.Background(), "celeste", []byte(`[]`))
+
+	require.NoError(t, err)
+	require.NoError(t, mock.ExpectationsWereMet())
+}
@@ -0,0 +1,184 @@
+package database
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"time"
+)
+
+var (
+	ErrNotEnoughFreePoints = errors.New("not enough free points")
+	ErrItemAlreadyOwned    = errors.New("item is already owned")
+	ErrItemNotOwned        = errors.New("item is not owned")
+)
+
+type UserItem struct {
+	ItemName   string
+	Slot       string
+	Equipped   bool
+	AcquiredAt time.Time
+}
+
+// The check and the spend are one statement, so two purchases at once can't go below zero
+const spendPointsQuery = `
+	UPDATE user_stats AS us
+	SET value = us.value - $2, updated_at = NOW()
+	FROM stat_types AS st, users AS u
+	WHERE us.stat_type_id = st.id AND us.user_id = u.id
+		AND u.username = $1 AND st.name = 'free-points' AND us.value >= $2
+	RETURNING value
+`
+
+// BuyItem adds the item to the inventory and takes the price from free-points, it returns the free points left.
+func (d *Database) BuyItem(ctx context.Context, username string, itemName string, slot string, price int) (int, error) {
+	var freePoints int
+	err := d.WithTransaction(ctx, func(tx *sql.Tx) error {
+		if _, err := d.scoped(tx).GetOrCreateUserStats(ctx, username); err != nil {
+			return fmt.Errorf("failed to ensure stats exist: %w", err)
+		}
+
+		const addItemQuery = `
+			INSERT INTO user_items (user_id, item_name, slot)
+			SELECT id, $2, $3 FROM users WHERE username = $1
+			ON CONFLICT (user_id, item_name) DO NOTHING
+		`
+		res, err := tx.ExecContext(ctx, addItemQuery, username, itemName, slot)
+		if err != nil {
+			return fmt.Errorf("couldn't add item: %w", err)
+		}
+		if affected, err := res.RowsAffected(); err != nil {
+			return err
+		} else if affected == 0 {
+			return ErrItemAlreadyOwned
+		}
+
+		err = tx.QueryRowContext(ctx, spendPointsQuery, username, price).Scan(&freePoints)
+		if errors.Is(err, sql.ErrNoRows) {
+			return ErrNotEnoughFreePoints
+		}
+		if err != nil {
+			return fmt.Errorf("couldn't spend free points: %w", err)
+		}
+		return nil
+	})
+
+	if errors.Is(err, ErrItemAlreadyOwned) || errors.Is(err, ErrNotEnoughFreePoints) {
+		return 0, err
+	}
+	if err != nil {
+		return 0, fmt.Errorf("failed to buy item: %w", err)
+	}
+
+	return freePoints, nil
+}
+
+// GetUserItems returns the inventory of the user, equipped items first.
+func (d *Database) GetUserItems(ctx context.Context, username string) ([]UserItem, error) {
+	var items []UserItem
+	err := d.WithTransaction(ctx, func(tx *sql.Tx) error {
+		const query = `
+			SELECT ui.item_name, ui.slot, ui.equipped, ui.acquired_at
+			FROM user_items ui
+			JOIN users u ON u.id = ui.user_id
+			WHERE u.username = $1
+			ORDER BY ui.equipped DESC, ui.item_name
+		`
+		rows, err := tx.QueryContext(ctx, query, username)
+		if err != nil {
+			return err
+		}
+		defer rows.Close()
+
+		for rows.Next() {
+			var item UserItem
+			if err := rows.Scan(&item.ItemName, &item.Slot, &item.Equipped, &item.AcquiredAt); err != nil {
+				return fmt.Errorf("failed to scan item row: %w", err)
+			}
+			items = append(items, item)
+		}
+		return rows.Err()
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to get user items: %w", err)
+	}
+
+	return items, nil
+}
+
+// EquipItem equips the owned item and unequips whatever was in the same slot, it returns the unequipped item name (if any).
+func (d *Database) EquipItem(ctx context.Context, username string, itemName string) (string, error) {
+	var replaced string
+	err := d.WithTransaction(ctx, func(tx *sql.Tx) error {
+		var userID int
+		var slot string
+		const itemQuery = `
+			SELECT ui.user_id, ui.slot
+			FROM user_items ui
+			JOIN users u ON u.id = ui.user_id
+			WHERE u.username = $1 AND ui.item_name = $2
+		`
+		err := tx.QueryRowContext(ctx, itemQuery, username, itemName).Scan(&userID, &slot)
+		if errors.Is(err, sql.ErrNoRows) {
+			return ErrItemNotOwned
+		}
+		if err != nil {
+			return fmt.Errorf("couldn't get item: %w", err)
+		}
+
+		const unequipQuery = `
+			UPDATE user_items SET equipped = FALSE
+			WHERE user_id = $1 AND slot = $2 AND equipped AND item_name <> $3
+			RETURNING item_name
+		`
+		err = tx.QueryRowContext(ctx, unequipQuery, userID, slot, itemName).Scan(&replaced)
+		if err != nil && !errors.Is(err, sql.ErrNoRows) {
+			return fmt.Errorf("couldn't unequip %s slot: %w", slot, err)
+		}
+
+		const equipQuery = `
+			UPDATE user_items SET equipped = TRUE
+			WHERE user_id = $1 AND item_name = $2
+		`
+		if _, err := tx.ExecContext(ctx, equipQuery, userID, itemName); err != nil {
+			return fmt.Errorf("couldn't equip item: %w", err)
+		}
+		return nil
+	})
+
+	if errors.Is(err, ErrItemNotOwned) {
+		return "", err
+	}
+	if err != nil {
+		return "", fmt.Errorf("failed to equip item: %w", err)
+	}
+
+	return replaced, nil
+}
+
+const unequipItemQuery = `
+	UPDATE user_items AS ui SET equipped = FALSE
+	FROM users AS u
+	WHERE u.id = ui.user_id AND u.username = $1 AND ui.equipped
+		AND (ui.item_name = $2 OR ui.slot = $2)
+	RETURNING item_name
+`
+
+// UnequipItem unequips the item, nameOrSlot can be the item name or the slot. It returns the unequipped item name,
+// or an empty string if nothing was equipped.
+func (d *Database) UnequipItem(ctx context.Context, username string, nameOrSlot string) (string, error) {
+	var unequipped string
+	err := d.WithTransaction(ctx, func(tx *sql.Tx) error {
+		err := tx.QueryRowContext(ctx, unequipItemQuery, username, nameOrSlot).Scan(&unequipped)
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil
+		}
+		return err
+	})
+	if err != nil {
+		return "", fmt.Errorf("failed to unequip item: %w", err)
+	}
+
+	return unequipped, nil
+}
@@ -0,0 +1,74 @@
+package database
+
+import (
+	"context"
+	"database/sql"
+	"testing"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/stretchr/testify/require"
+)
+
+func TestEquipItem(t *testing.T) {
+	t.Run("replaces item in the same slot", func(t *testing.T) {
+		db, mock, err := sqlmock.New()
+		require.NoError(t, err)
+		defer db.Close()
+
+		mock.ExpectBegin()
+		mock.ExpectQuery("SELECT ui.user_id, ui.slot FROM user_items ui JOIN users u .* WHERE u.username = \\$1 AND ui.item_name = \\$2").
+			WithArgs("testuser", "Battle Axe").
+			WillReturnRows(sqlmock.NewRows([]string{"user_id", "slot"}).AddRow(1, "weapon"))
+		mock.ExpectQuery("UPDATE user_items SET equipped = FALSE WHERE user_id = \\$1 AND slot = \\$2").
+			WithArgs(1, "weapon", "Battle Axe").
+			WillReturnRows(sqlmock.NewRows([]string{"item_name"}).AddRow("Rusty Sword"))
+		mock.ExpectExec("UPDATE user_items SET equipped = TRUE WHERE user_id = \\$1 AND item_name = \\$2").
+			WithArgs(1, "Battle Axe").
+			WillReturnResult(sqlmock.NewResult(0, 1))
+		mock.ExpectCommit()
+
+		database := &Database{db: db}
+		replaced, err := database.EquipItem(context.Background(), "testuser", "Battle Axe")
+
+		require.NoError(t, err)
+		require.Equal(t, "Rusty Sword", replaced)
+		require.NoError(t, mock.ExpectationsWereMet())
+	})
+
+	t.Run("item not owned", func(t *testing.T) {
+		db, mock, err := sqlmock.New()
+		require.NoError(t, err)
+		defer db.Close()
+
+		mock.ExpectBegin()
+		mock.ExpectQuery("SELECT ui.user_id, ui.slot FROM user_items ui").
+			WithArgs("testuser", "Battle Axe").
+			WillReturnError(sql.ErrNoRows)
+		mock.ExpectRollback()
+
+		database := &Database{db: db}
+		_, err = database.EquipItem(context.Background(), "testuser", "Battle Axe")
+
+		require.ErrorIs(t, err, ErrItemNotOwned)
+		require.NoError(t, mock.ExpectationsWereMet())
+	})
+}
+
+func TestUnequipItem(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	require.NoError(t, err)
+	defer db.Close()
+
+	mock.ExpectBegin()
+	mock.ExpectQuery("UPDATE user_items AS ui SET equipped = FALSE FROM users AS u .* \\(ui.item_name = \\$2 OR ui.slot = \\$2\\)").
+		WithArgs("testuser", "weapon").
+		WillReturnRows(sqlmock.NewRows([]string{"item_name"}).AddRow("Rusty Sword"))
+	mock.ExpectCommit()
+
+	database := &Database{db: db}
+	unequipped, err := database.UnequipItem(context.Background(), "testuser", "weapon")
+
+	require.NoError(t, err)
+	require.Equal(t, "Rusty Sword", unequipped)
+	require.NoError(t, mock.ExpectationsWereMet())
+}
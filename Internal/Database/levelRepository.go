@@ -0,0 +1,127 @@
+package database
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"time"
+)
+
+type UserLevel struct {
+	UserID int
+	XP     int
+	Level  int
+}
+
+type XPResult struct {
+	XP                int
+	OldLevel          int
+	NewLevel          int
+	FreePointsGranted int
+}
+
+// AddXP adds XP to the user, levelForXP converts total XP into level. Every gained level grants pointsPerLevel free points.
+func (d *Database) AddXP(ctx context.Context, username string, amount int, levelForXP func(xp int) int, pointsPerLevel int) (*XPResult, error) {
+	var result XPResult
+	err := d.WithTransaction(ctx, func(tx *sql.Tx) error {
+		//Level-up grants free points, so user and stats should exist
+		if _, err := d.scoped(tx).GetOrCreateUserStats(ctx, username); err != nil {
+			return fmt.Errorf("failed to ensure stats exist: %w", err)
+		}
+
+		const addXPQuery = `
+			INSERT INTO user_levels (user_id, xp)
+			SELECT id, $2 FROM users WHERE username = $1
+			ON CONFLICT (user_id) DO UPDATE
+			SET xp = user_levels.xp + EXCLUDED.xp, updated_at = NOW()
+			RETURNING xp, level
+		`
+		if err := tx.QueryRowContext(ctx, addXPQuery, username, amount).Scan(&result.XP, &result.OldLevel); err != nil {
+			return fmt.Errorf("couldn't update xp: %w", err)
+		}
+
+		result.NewLevel = levelForXP(result.XP)
+		if result.NewLevel <= result.OldLevel {
+			result.NewLevel = result.OldLevel
+			return nil
+		}
+
+		const updateLevelQuery = `
+			UPDATE user_levels SET level = $2
+			WHERE user_id = (SELECT id FROM users WHERE username = $1)
+		`
+		if _, err := tx.ExecContext(ctx, updateLevelQuery, username, result.NewLevel); err != nil {
+			return fmt.Errorf("couldn't update level: %w", err)
+		}
+
+		result.FreePointsGranted = (result.NewLevel - result.OldLevel) * pointsPerLevel
+		if result.FreePointsGranted > 0 {
+			if _, err := addFreePointsTx(ctx, tx, username, result.FreePointsGranted); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to add xp: %w", err)
+	}
+
+	return &result, nil
+}
+
+// GetUserLevel returns nil if the user has never earned XP.
+func (d *Database) GetUserLevel(ctx context.Context, username string) (*UserLevel, error) {
+	var result *UserLevel
+	err := d.WithTransaction(ctx, func(tx *sql.Tx) error {
+		const query = `
+			SELECT ul.user_id, ul.xp, ul.level
+			FROM user_levels ul
+			JOIN users u ON u.id = ul.user_id
+			WHERE u.username = $1
+		`
+		var level UserLevel
+		err := tx.QueryRowContext(ctx, query, username).Scan(&level.UserID, &level.XP, &level.Level)
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil
+		}
+		if err != nil {
+			return err
+		}
+
+		result = &level
+		return nil
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to get user level: %w", err)
+	}
+
+	return result, nil
+}
+
+// ClaimAttendance marks the user as present for the day, it returns false if the user was already marked that day.
+func (d *Database) ClaimAttendance(ctx context.Context, username string, day time.Time) (bool, error) {
+	var claimed bool
+	err := d.WithTransaction(ctx, func(tx *sql.Tx) error {
+		const query = `
+			INSERT INTO user_levels (user_id, last_attendance)
+			SELECT id, $2 FROM users WHERE username = $1
+			ON CONFLICT (user_id) DO UPDATE
+			SET last_attendance = EXCLUDED.last_attendance
+			WHERE user_levels.last_attendance IS NULL OR user_levels.last_attendance < EXCLUDED.last_attendance
+		`
+		res, err := tx.ExecContext(ctx, query, username, day.Format("2006-01-02"))
+		if err != nil {
+			return err
+		}
+
+		affected, err := res.RowsAffected()
+		claimed = affected > 0
+		return err
+	})
+	if err != nil {
+		return false, fmt.Errorf("failed to claim attendance: %w", err)
+	}
+
+	return claimed, nil
+}
@@ -0,0 +1,61 @@
+package database
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/stretchr/testify/require"
+)
+
+func TestGetUserLevel(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	require.NoError(t, err)
+	defer db.Close()
+
+	mock.ExpectBegin()
+	mock.ExpectQuery("SELECT ul.user_id, ul.xp, ul.level FROM user_levels ul JOIN users u .* WHERE u.username = \\$1").
+		WithArgs("testuser").
+		WillReturnRows(sqlmock.NewRows([]string{"user_id", "xp", "level"}).AddRow(1, 350, 3))
+	mock.ExpectCommit()
+
+	database := &Database{db: db}
+	level, err := database.GetUserLevel(context.Background(), "testuser")
+
+	require.NoError(t, err)
+	require.Equal(t, &UserLevel{UserID: 1, XP: 350, Level: 3}, level)
+	require.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestClaimAttendance(t *testing.T) {
+	day := time.Date(2025, 5, 17, 20, 0, 0, 0, time.UTC)
+
+	for _, tc := range []struct {
+		name     string
+		affected int64
+		claimed  bool
+	}{
+		{name: "first message today", affected: 1, claimed: true},
+		{name: "already claimed", affected: 0, claimed: false},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			db, mock, err := sqlmock.New()
+			require.NoError(t, err)
+			defer db.Close()
+
+			mock.ExpectBegin()
+			mock.ExpectExec("INSERT INTO user_levels \\(user_id, last_attendance\\) .* ON CONFLICT \\(user_id\\) DO UPDATE").
+				WithArgs("testuser", "2025-05-17").
+				WillReturnResult(sqlmock.NewResult(0, tc.affected))
+			mock.ExpectCommit()
+
+			database := &Database{db: db}
+			claimed, err := database.ClaimAttendance(context.Background(), "testuser", day)
+
+			require.NoError(t, err)
+			require.Equal(t, tc.claimed, claimed)
+			require.NoError(t, mock.ExpectationsWereMet())
+		})
+	}
+}
@@ -0,0 +1,236 @@
+package database
+
+import (
+	"context"
+	"database/sql"
+	"embed"
+	"fmt"
+	"io/fs"
+	"path"
+	"regexp"
+	"slices"
+	"strconv"
+	"time"
+)
+
+//go:embed migrations/*.sql
+var migrationFiles embed.FS
+
+// Migration files are named 0001_name.up.sql and 0001_name.down.sql
+var migrationFileName = regexp.MustCompile(`^(\d+)_(\w+)\.(up|down)\.sql$`)
+
+type Migration struct {
+	Version int
+	Name    string
+	Up      string
+	Down    string
+}
+
+type MigrationStatus struct {
+	Migration
+	//Nil for pending migrations
+	AppliedAt *time.Time
+}
+
+// Migrations returns the embedded migrations ordered by version.
+func Migrations() ([]Migration, error) {
+	return loadMigrations(migrationFiles, "migrations")
+}
+
+func loadMigrations(fsys fs.FS, dir string) ([]Migration, error) {
+	entries, err := fs.ReadDir(fsys, dir)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read migrations: %w", err)
+	}
+
+	byVersion := make(map[int]*Migration)
+	for _, entry := range entries {
+		match := migrationFileName.FindStringSubmatch(entry.Name())
+		if match == nil {
+			return nil, fmt.Errorf("unexpected migration file %s, should be like 0001_name.up.sql", entry.Name())
+		}
+		version, _ := strconv.Atoi(match[1])
+		content, err := fs.ReadFile(fsys, path.Join(dir, entry.Name()))
+		if err != nil {
+			return nil, fmt.Errorf("failed to read migration %s: %w", entry.Name(), err)
+		}
+
+		migration, ok := byVersion[version]
+		if !ok {
+			migration = &Migration{Version: version, Name: match[2]}
+			byVersion[version] = migration
+		}
+		if migration.Name != match[2] {
+			return nil, fmt.Errorf("migration %d has two names: %s and %s", version, migration.Name, match[2])
+		}
+		if match[3] == "up" {
+			migration.Up = string(content)
+		} else {
+			migration.Down = string(content)
+		}
+	}
+
+	migrations := make([]Migration, 0, len(byVersion))
+	for _, migration := range byVersion {
+		if migration.Up == "" || migration.Down == "" {
+			return nil, fmt.Errorf("migration %d_%s should have both up and down files", migration.Version, migration.Name)
+		}
+		migrations = append(migrations, *migration)
+	}
+	slices.SortFunc(migrations, func(a, b Migration) int { return a.Version - b.Version })
+	return migrations, nil
+}
+
+// MigrateUp applies all pending migrations, each one in its own transaction, and returns the applied ones.
+func (d *Database) MigrateUp(ctx context.Context) ([]Migration, error) {
+	migrations, err := Migrations()
+	if err != nil {
+		return nil, err
+	}
+	return d.migrateUp(ctx, migrations)
+}
+
+// MigrateDown reverts the last steps applied migrations and returns the reverted ones.
+func (d *Database) MigrateDown(ctx context.Context, steps int) ([]Migration, error) {
+	migrations, err := Migrations()
+	if err != nil {
+		return nil, err
+	}
+	return d.migrateDown(ctx, migrations, steps)
+}
+
+// MigrationStatus returns every known migration with the time it was applied.
+func (d *Database) MigrationStatus(ctx context.Context) ([]MigrationStatus, error) {
+	migrations, err := Migrations()
+	if err != nil {
+		return nil, err
+	}
+
+	applied, err := d.appliedMigrations(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	statuses := make([]MigrationStatus, 0, len(migrations))
+	for _, migration := range migrations {
+		status := MigrationStatus{Migration: migration}
+		if appliedAt, ok := applied[migration.Version]; ok {
+			status.AppliedAt = &appliedAt
+		}
+		statuses = append(statuses, status)
+	}
+	return statuses, nil
+}
+
+func (d *Database) migrateUp(ctx context.Context, migrations []Migration) ([]Migration, error) {
+	applied, err := d.appliedMigrations(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	var done []Migration
+	for _, migration := range migrations {
+		if _, ok := applied[migration.Version]; ok {
+			continue
+		}
+
+		err := d.WithTransaction(ctx, func(tx *sql.Tx) error {
+			if _, err := tx.ExecContext(ctx, translateSchema(d.dialect, migration.Up)); err != nil {
+				return err
+			}
+			const insertQuery = `
+				INSERT INTO schema_migrations (version, name)
+				VALUES ($1, $2)
+			`
+			_, err := tx.ExecContext(ctx, insertQuery, migration.Version, migration.Name)
+			return err
+		})
+		if err != nil {
+			return done, fmt.Errorf("failed to apply migration %d_%s: %w", migration.Version, migration.Name, err)
+		}
+		done = append(done, migration)
+	}
+	return done, nil
+}
+
+func (d *Database) migrateDown(ctx context.Context, migrations []Migration, steps int) ([]Migration, error) {
+	applied, err := d.appliedMigrations(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	versions := make([]int, 0, len(applied))
+	for version := range applied {
+		versions = append(versions, version)
+	}
+	slices.Sort(versions)
+	slices.Reverse(versions)
+
+	var done []Migration
+	for _, version := range versions[:min(steps, len(versions))] {
+		i := slices.IndexFunc(migrations, func(m Migration) bool { return m.Version == version })
+		if i < 0 {
+			return done, fmt.Errorf("migration %d is applied, but this build doesn't know it", version)
+		}
+		migration := migrations[i]
+
+		err := d.WithTransaction(ctx, func(tx *sql.Tx) error {
+			if _, err := tx.ExecContext(ctx, translateSchema(d.dialect, migration.Down)); err != nil {
+				return err
+			}
+			const deleteQuery = `
+				DELETE FROM schema_migrations
+				WHERE version = $1
+			`
+			_, err := tx.ExecContext(ctx, deleteQuery, migration.Version)
+			return err
+		})
+		if err != nil {
+			return done, fmt.Errorf("failed to revert migration %d_%s: %w", migration.Version, migration.Name, err)
+		}
+		done = append(done, migration)
+	}
+	return done, nil
+}
+
+// appliedMigrations creates schema_migrations if needed and returns the applied versions with their times.
+func (d *Database) appliedMigrations(ctx context.Context) (map[int]time.Time, error) {
+	applied := make(map[int]time.Time)
+	err := d.WithTransaction(ctx, func(tx *sql.Tx) error {
+		const createQuery = `
+			CREATE TABLE IF NOT EXISTS schema_migrations (
+				version INTEGER PRIMARY KEY,
+				name TEXT NOT NULL,
+				applied_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW()
+			)
+		`
+		if _, err := tx.ExecContext(ctx, translateSchema(d.dialect, createQuery)); err != nil {
+			return err
+		}
+
+		const selectQuery = `
+			SELECT version, applied_at
+			FROM schema_migrations
+		`
+		rows, err := tx.QueryContext(ctx, selectQuery)
+		if err != nil {
+			return err
+		}
+		defer rows.Close()
+
+		for rows.Next() {
+			var version int
+			var appliedAt time.Time
+			if err := rows.Scan(&version, &appliedAt); err != nil {
+				return err
+			}
+			applied[version] = appliedAt
+		}
+		return rows.Err()
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to read applied migrations: %w", err)
+	}
+
+	return applied, nil
+}
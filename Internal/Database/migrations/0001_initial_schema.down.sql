@@ -0,0 +1,25 @@
+-- Drops everything, all bot data is lost
+DROP TABLE IF EXISTS hltb_cache;
+DROP TABLE IF EXISTS chatters;
+DROP TABLE IF EXISTS greeting_preferences;
+DROP TABLE IF EXISTS daily_streaks;
+DROP TABLE IF EXISTS user_achievements;
+DROP TABLE IF EXISTS achievement_progress;
+DROP TABLE IF EXISTS user_items;
+DROP TABLE IF EXISTS user_respecs;
+DROP TABLE IF EXISTS user_levels;
+DROP TABLE IF EXISTS boss_raid_participants;
+DROP TABLE IF EXISTS boss_raids;
+DROP TABLE IF EXISTS raffle_draws;
+DROP TABLE IF EXISTS raffle_entries;
+DROP TABLE IF EXISTS raffles;
+DROP TABLE IF EXISTS poll_options;
+DROP TABLE IF EXISTS polls;
+DROP TABLE IF EXISTS counter_values;
+DROP TABLE IF EXISTS counters;
+DROP TABLE IF EXISTS quotes;
+DROP TABLE IF EXISTS timers;
+DROP TABLE IF EXISTS user_results;
+DROP TABLE IF EXISTS user_stats;
+DROP TABLE IF EXISTS stat_types;
+DROP TABLE IF EXISTS users;
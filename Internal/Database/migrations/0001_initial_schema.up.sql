@@ -0,0 +1,226 @@
+-- The schema as it was applied by hand before migrations existed. Everything is IF NOT EXISTS,
+-- so databases created with the old CreateDatabaseScript.sql are picked up as is.
+
+-- Users table (core user information)
+CREATE TABLE IF NOT EXISTS users (
+    id SERIAL PRIMARY KEY,
+    username TEXT UNIQUE NOT NULL,
+    created_at TIMESTAMP WITH TIME ZONE DEFAULT NOW(),
+    updated_at TIMESTAMP WITH TIME ZONE DEFAULT NOW()
+);
+
+-- Stats definition table (flexible stat types)
+CREATE TABLE IF NOT EXISTS stat_types (
+    id SERIAL PRIMARY KEY,
+    name TEXT UNIQUE NOT NULL,  -- 'strength', 'perception', etc.
+    display_name TEXT NOT NULL, -- 'Strength', 'Perception'
+    min_value INTEGER DEFAULT 1,
+    max_value INTEGER DEFAULT 10,
+    default_value INTEGER DEFAULT 1,
+    created_at TIMESTAMP WITH TIME ZONE DEFAULT NOW()
+);
+
+-- User stats (dynamic attributes)
+CREATE TABLE IF NOT EXISTS user_stats (
+    user_id INTEGER NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    stat_type_id INTEGER NOT NULL REFERENCES stat_types(id) ON DELETE CASCADE,
+    value INTEGER NOT NULL,
+    updated_at TIMESTAMP WITH TIME ZONE DEFAULT NOW(),
+    PRIMARY KEY (user_id, stat_type_id)
+);
+
+-- User results (win/lose/draw records)
+CREATE TABLE IF NOT EXISTS user_results (
+    user_id INTEGER NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    total_wins INTEGER NOT NULL DEFAULT 0,
+    total_draws INTEGER NOT NULL DEFAULT 0,
+    total_lose INTEGER NOT NULL DEFAULT 0,
+    updated_at TIMESTAMP WITH TIME ZONE DEFAULT NOW(),
+    PRIMARY KEY (user_id)
+);
+
+-- Indexes for performance
+CREATE INDEX IF NOT EXISTS idx_user_stats_user ON user_stats(user_id);
+CREATE INDEX IF NOT EXISTS idx_user_stats_type ON user_stats(stat_type_id);
+
+-- Timers (recurring chat announcements)
+CREATE TABLE IF NOT EXISTS timers (
+    id SERIAL PRIMARY KEY,
+    name TEXT UNIQUE NOT NULL,
+    message TEXT NOT NULL,
+    interval_seconds INTEGER NOT NULL,
+    min_lines INTEGER NOT NULL DEFAULT 0,
+    enabled BOOLEAN NOT NULL DEFAULT TRUE,
+    last_posted_at TIMESTAMP WITH TIME ZONE,
+    created_at TIMESTAMP WITH TIME ZONE DEFAULT NOW()
+);
+
+-- Quotes
+CREATE TABLE IF NOT EXISTS quotes (
+    id SERIAL PRIMARY KEY,
+    text TEXT NOT NULL,
+    author TEXT NOT NULL,
+    game TEXT NOT NULL DEFAULT '',
+    added_by TEXT NOT NULL,
+    created_at TIMESTAMP WITH TIME ZONE DEFAULT NOW()
+);
+
+-- Counters (death counter, etc.)
+CREATE TABLE IF NOT EXISTS counters (
+    id SERIAL PRIMARY KEY,
+    name TEXT UNIQUE NOT NULL,
+    per_game BOOLEAN NOT NULL DEFAULT FALSE,
+    created_at TIMESTAMP WITH TIME ZONE DEFAULT NOW()
+);
+
+-- Counter values, game is empty for counters that are not scoped to a game
+CREATE TABLE IF NOT EXISTS counter_values (
+    counter_id INTEGER NOT NULL REFERENCES counters(id) ON DELETE CASCADE,
+    game TEXT NOT NULL DEFAULT '',
+    value INTEGER NOT NULL DEFAULT 0,
+    updated_at TIMESTAMP WITH TIME ZONE DEFAULT NOW(),
+    PRIMARY KEY (counter_id, game)
+);
+
+-- Polls run by the bot in chat
+CREATE TABLE IF NOT EXISTS polls (
+    id SERIAL PRIMARY KEY,
+    question TEXT NOT NULL,
+    started_by TEXT NOT NULL,
+    started_at TIMESTAMP WITH TIME ZONE NOT NULL,
+    ended_at TIMESTAMP WITH TIME ZONE NOT NULL,
+    native_poll_id TEXT NOT NULL DEFAULT ''
+);
+
+CREATE TABLE IF NOT EXISTS poll_options (
+    poll_id INTEGER NOT NULL REFERENCES polls(id) ON DELETE CASCADE,
+    position INTEGER NOT NULL,
+    title TEXT NOT NULL,
+    votes INTEGER NOT NULL DEFAULT 0,
+    PRIMARY KEY (poll_id, position)
+);
+
+-- Raffles (giveaways), every entry and draw is kept for auditing
+CREATE TABLE IF NOT EXISTS raffles (
+    id SERIAL PRIMARY KEY,
+    keyword TEXT NOT NULL,
+    weighting TEXT NOT NULL DEFAULT 'none', -- 'none', 'sub', 'luck'
+    started_by TEXT NOT NULL,
+    started_at TIMESTAMP WITH TIME ZONE DEFAULT NOW(),
+    ended_at TIMESTAMP WITH TIME ZONE,
+    winner TEXT
+);
+
+CREATE TABLE IF NOT EXISTS raffle_entries (
+    raffle_id INTEGER NOT NULL REFERENCES raffles(id) ON DELETE CASCADE,
+    username TEXT NOT NULL,
+    tickets INTEGER NOT NULL DEFAULT 1,
+    entered_at TIMESTAMP WITH TIME ZONE DEFAULT NOW(),
+    PRIMARY KEY (raffle_id, username)
+);
+
+CREATE TABLE IF NOT EXISTS raffle_draws (
+    id SERIAL PRIMARY KEY,
+    raffle_id INTEGER NOT NULL REFERENCES raffles(id) ON DELETE CASCADE,
+    username TEXT NOT NULL,
+    responded BOOLEAN NOT NULL,
+    drawn_at TIMESTAMP WITH TIME ZONE DEFAULT NOW()
+);
+
+-- Boss raids (cooperative minigame) and their participants
+CREATE TABLE IF NOT EXISTS boss_raids (
+    id SERIAL PRIMARY KEY,
+    boss_name TEXT NOT NULL,
+    max_hp INTEGER NOT NULL,
+    damage_dealt INTEGER NOT NULL,
+    defeated BOOLEAN NOT NULL,
+    started_at TIMESTAMP WITH TIME ZONE NOT NULL,
+    ended_at TIMESTAMP WITH TIME ZONE DEFAULT NOW()
+);
+
+CREATE TABLE IF NOT EXISTS boss_raid_participants (
+    raid_id INTEGER NOT NULL REFERENCES boss_raids(id) ON DELETE CASCADE,
+    username TEXT NOT NULL,
+    damage INTEGER NOT NULL,
+    reward INTEGER NOT NULL DEFAULT 0,
+    PRIMARY KEY (raid_id, username)
+);
+
+-- XP and levels
+CREATE TABLE IF NOT EXISTS user_levels (
+    user_id INTEGER NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    xp INTEGER NOT NULL DEFAULT 0,
+    level INTEGER NOT NULL DEFAULT 1,
+    last_attendance DATE,
+    updated_at TIMESTAMP WITH TIME ZONE DEFAULT NOW(),
+    PRIMARY KEY (user_id)
+);
+
+-- Last stat respec of the user (for the cooldown)
+CREATE TABLE IF NOT EXISTS user_respecs (
+    user_id INTEGER NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    last_respec_at TIMESTAMP WITH TIME ZONE NOT NULL,
+    PRIMARY KEY (user_id)
+);
+
+-- Inventory (the items catalog itself lives in items.json), one equipped item per slot
+CREATE TABLE IF NOT EXISTS user_items (
+    user_id INTEGER NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    item_name TEXT NOT NULL,
+    slot TEXT NOT NULL,
+    equipped BOOLEAN NOT NULL DEFAULT FALSE,
+    acquired_at TIMESTAMP WITH TIME ZONE DEFAULT NOW(),
+    PRIMARY KEY (user_id, item_name)
+);
+
+CREATE UNIQUE INDEX IF NOT EXISTS idx_user_items_equipped_slot ON user_items(user_id, slot) WHERE equipped;
+
+-- Achievements (definitions live in achievements.json): event counters and unlocked achievements
+CREATE TABLE IF NOT EXISTS achievement_progress (
+    user_id INTEGER NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    event TEXT NOT NULL,
+    count INTEGER NOT NULL DEFAULT 0,
+    PRIMARY KEY (user_id, event)
+);
+
+CREATE TABLE IF NOT EXISTS user_achievements (
+    user_id INTEGER NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    achievement_id TEXT NOT NULL,
+    unlocked_at TIMESTAMP WITH TIME ZONE DEFAULT NOW(),
+    PRIMARY KEY (user_id, achievement_id)
+);
+
+-- !daily check-in streaks, last_claim is the calendar day in the channel timezone
+CREATE TABLE IF NOT EXISTS daily_streaks (
+    user_id INTEGER NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    streak INTEGER NOT NULL DEFAULT 0,
+    best_streak INTEGER NOT NULL DEFAULT 0,
+    last_claim DATE NOT NULL,
+    updated_at TIMESTAMP WITH TIME ZONE DEFAULT NOW(),
+    PRIMARY KEY (user_id)
+);
+
+CREATE INDEX IF NOT EXISTS idx_daily_streaks_last_claim ON daily_streaks(last_claim);
+
+-- Greeting settings of the user, empty language means random greetings, no_greet turns off the auto-greeting
+CREATE TABLE IF NOT EXISTS greeting_preferences (
+    user_id INTEGER NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    language TEXT NOT NULL DEFAULT '',
+    no_greet BOOLEAN NOT NULL DEFAULT FALSE,
+    updated_at TIMESTAMP WITH TIME ZONE DEFAULT NOW(),
+    PRIMARY KEY (user_id)
+);
+
+-- Last visit of the chatter (first message in the stream session), for "welcome back" greetings
+CREATE TABLE IF NOT EXISTS chatters (
+    user_id INTEGER NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    last_seen_at TIMESTAMP WITH TIME ZONE NOT NULL,
+    PRIMARY KEY (user_id)
+);
+
+-- Cached HowLongToBeat search results (JSON array of games), query is the normalized game title
+CREATE TABLE IF NOT EXISTS hltb_cache (
+    query TEXT PRIMARY KEY,
+    results JSONB NOT NULL,
+    fetched_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW()
+);
@@ -0,0 +1,2 @@
+-- Intentionally a no-op: deleting the core stat types would cascade to user_stats and wipe every user's stats.
+-- The stat types are seed data, 0002_base_stats.up.sql keeps existing ones, so applying it again is safe.
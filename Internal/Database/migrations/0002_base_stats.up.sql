@@ -1,4 +1,4 @@
--- Insert core stat types
+-- Insert core stat types, existing ones (e.g. with tuned limits) are kept
 INSERT INTO stat_types (name, display_name, min_value, max_value, default_value) VALUES
 ('strength', 'Strength', 1, 10, 1),
 ('perception', 'Perception', 1, 10, 1),
@@ -8,4 +8,5 @@ INSERT INTO stat_types (name, display_name, min_value, max_value, default_value)
 ('agility', 'Agility', 1, 10, 1),
 ('luck', 'Luck', 1, 10, 1),
 ('free-points', 'Free Points', 0, 99, 0),
-('total-free-points', 'Total FP', 0, 99, 0);
\ No newline at end of file
+('total-free-points', 'Total FP', 0, 99, 0)
+ON CONFLICT (name) DO NOTHING;
@@ -0,0 +1,3 @@
+-- Restores the old cap, values already above it are kept
+UPDATE stat_types SET max_value = 99
+WHERE name IN ('free-points', 'total-free-points');
@@ -0,0 +1,3 @@
+-- Free points were capped at 99, level-ups, daily rewards and refunds above the cap were silently lost
+UPDATE stat_types SET max_value = 2147483647
+WHERE name IN ('free-points', 'total-free-points');
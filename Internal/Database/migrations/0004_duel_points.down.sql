@@ -0,0 +1 @@
+ALTER TABLE user_results DROP COLUMN duel_points;
@@ -0,0 +1,10 @@
+-- Free points earned in duels, the duel thresholds grow with them and not with total-free-points,
+-- which also counts level-up, daily and trivia rewards
+ALTER TABLE user_results ADD COLUMN duel_points INTEGER NOT NULL DEFAULT 0;
+
+-- Best guess for existing users: until now the thresholds grew with total-free-points
+UPDATE user_results SET duel_points = COALESCE((
+    SELECT us.value FROM user_stats us
+    JOIN stat_types st ON st.id = us.stat_type_id
+    WHERE us.user_id = user_results.user_id AND st.name = 'total-free-points'
+), 0);
@@ -0,0 +1 @@
+-- Intentionally a no-op: the backfilled rows can't be told apart from real visits.
@@ -0,0 +1,5 @@
+-- Users created before chatters existed have already chatted, without a chatters row every one of them
+-- would be greeted as a first-timer. WHERE true lets SQLite parse ON CONFLICT after a SELECT.
+INSERT INTO chatters (user_id, last_seen_at)
+SELECT id, COALESCE(updated_at, created_at, NOW()) FROM users WHERE true
+ON CONFLICT (user_id) DO NOTHING;
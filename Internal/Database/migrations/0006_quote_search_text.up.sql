@@ -0,0 +1,6 @@
+-- Lowered text and author of the quote, on a new line each (see quoteSearchText), so the search is case-insensitive
+-- with one LIKE in both databases. SQLite runs unicode_lower() instead of lower(), which only knows ASCII there.
+ALTER TABLE quotes ADD COLUMN search_text TEXT NOT NULL DEFAULT '';
+
+UPDATE quotes SET search_text = lower(text) || '
+' || lower(author);
@@ -0,0 +1,93 @@
+package database
+
+import (
+	"context"
+	"testing"
+	"testing/fstest"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/stretchr/testify/require"
+)
+
+func TestEmbeddedMigrations(t *testing.T) {
+	migrations, err := Migrations()
+	require.NoError(t, err)
+	require.NotEmpty(t, migrations)
+
+	for i, migration := range migrations {
+		require.Equal(t, i+1, migration.Version, "versions should go without gaps")
+	}
+	//Seed data can be applied again on databases that already have it
+	require.Contains(t, migrations[1].Up, "ON CONFLICT (name) DO NOTHING")
+}
+
+func TestLoadMigrationsErrors(t *testing.T) {
+	for name, files := range map[string]fstest.MapFS{
+		"should be like 0001_name.up.sql": {"m/init.sql": {}},
+		"should have both up and down":    {"m/0001_init.up.sql": {Data: []byte("SELECT 1")}},
+		"has two names":                   {"m/0001_init.up.sql": {Data: []byte("SELECT 1")}, "m/0001_other.down.sql": {Data: []byte("SELECT 1")}},
+	} {
+		_, err := loadMigrations(files, "m")
+		require.ErrorContains(t, err, name)
+	}
+}
+
+var testMigrations = []Migration{
+	{Version: 1, Name: "init", Up: "CREATE TABLE a", Down: "DROP TABLE a"},
+	{Version: 2, Name: "seed", Up: "INSERT INTO a", Down: "DELETE FROM a"},
+}
+
+func expectAppliedMigrations(mock sqlmock.Sqlmock, versions ...int) {
+	rows := sqlmock.NewRows([]string{"version", "applied_at"})
+	for _, version := range versions {
+		rows.AddRow(version, time.Now())
+	}
+
+	mock.ExpectBegin()
+	mock.ExpectExec("CREATE TABLE IF NOT EXISTS schema_migrations").WillReturnResult(sqlmock.NewResult(0, 0))
+	mock.ExpectQuery("SELECT version, applied_at FROM schema_migrations").WillReturnRows(rows)
+	mock.ExpectCommit()
+}
+
+func TestMigrateUpAppliesPendingOnly(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	require.NoError(t, err)
+	defer db.Close()
+
+	expectAppliedMigrations(mock, 1)
+	mock.ExpectBegin()
+	mock.ExpectExec("INSERT INTO a").WillReturnResult(sqlmock.NewResult(0, 9))
+	mock.ExpectExec("INSERT INTO schema_migrations \\(version, name\\)").
+		WithArgs(2, "seed").
+		WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectCommit()
+
+	database := &Database{db: db}
+	done, err := database.migrateUp(context.Background(), testMigrations)
+
+	require.NoError(t, err)
+	require.Equal(t, testMigrations[1:], done)
+	require.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestMigrateDownRevertsLatest(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	require.NoError(t, err)
+	defer db.Close()
+
+	expectAppliedMigrations(mock, 1, 2)
+	mock.ExpectBegin()
+	mock.ExpectExec("DELETE FROM a").WillReturnResult(sqlmock.NewResult(0, 9))
+	mock.ExpectExec("DELETE FROM schema_migrations WHERE version = \\$1").
+		WithArgs(2).
+		WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectCommit()
+
+	database := &Database{db: db}
+	done, err := database.migrateDown(context.Background(), testMigrations, 1)
+
+	require.NoError(t, err)
+	require.Equal(t, testMigrations[1:], done)
+	require.NoError(t, mock.ExpectationsWereMet())
+}
@@ -0,0 +1,54 @@
+package database
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"time"
+)
+
+type PollResult struct {
+	Question     string
+	StartedBy    string
+	StartedAt    time.Time
+	EndedAt      time.Time
+	NativePollID string
+	Options      []string
+	Votes        []int
+}
+
+func (d *Database) SavePollResult(ctx context.Context, poll PollResult) (int, error) {
+	if len(poll.Options) != len(poll.Votes) {
+		return 0, fmt.Errorf("poll has %d options but %d vote counts", len(poll.Options), len(poll.Votes))
+	}
+
+	var pollID int
+	err := d.WithTransaction(ctx, func(tx *sql.Tx) error {
+		const insertPoll = `
+			INSERT INTO polls (question, started_by, started_at, ended_at, native_poll_id)
+			VALUES ($1, $2, $3, $4, $5)
+			RETURNING id
+		`
+		err := tx.QueryRowContext(ctx, insertPoll, poll.Question, poll.StartedBy, poll.StartedAt, poll.EndedAt, poll.NativePollID).Scan(&pollID)
+		if err != nil {
+			return err
+		}
+
+		const insertOption = `
+			INSERT INTO poll_options (poll_id, position, title, votes)
+			VALUES ($1, $2, $3, $4)
+		`
+		for i, option := range poll.Options {
+			if _, err := tx.ExecContext(ctx, insertOption, pollID, i+1, option, poll.Votes[i]); err != nil {
+				return fmt.Errorf("failed to save option %d: %w", i+1, err)
+			}
+		}
+
+		return nil
+	})
+	if err != nil {
+		return 0, fmt.Errorf("failed to save poll: %w", err)
+	}
+
+	return pollID, nil
+}
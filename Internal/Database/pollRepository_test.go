@@ -0,0 +1,67 @@
+package database
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSavePollResult(t *testing.T) {
+	now := time.Now()
+	poll := PollResult{
+		Question:  "Which boss next?",
+		StartedBy: "moduser",
+		StartedAt: now.Add(-time.Minute),
+		EndedAt:   now,
+		Options:   []string{"Malenia", "Radahn"},
+		Votes:     []int{3, 5},
+	}
+
+	t.Run("success", func(t *testing.T) {
+		db, mock, err := sqlmock.New()
+		require.NoError(t, err)
+		defer db.Close()
+
+		mock.ExpectBegin()
+		mock.ExpectQuery("INSERT INTO polls .* RETURNING id").
+			WithArgs(poll.Question, poll.StartedBy, poll.StartedAt, poll.EndedAt, "").
+			WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow(4))
+		mock.ExpectExec("INSERT INTO poll_options").
+			WithArgs(4, 1, "Malenia", 3).
+			WillReturnResult(sqlmock.NewResult(0, 1))
+		mock.ExpectExec("INSERT INTO poll_options").
+			WithArgs(4, 2, "Radahn", 5).
+			WillReturnResult(sqlmock.NewResult(0, 1))
+		mock.ExpectCommit()
+
+		database := &Database{db: db}
+		id, err := database.SavePollResult(context.Background(), poll)
+
+		require.NoError(t, err)
+		require.Equal(t, 4, id)
+		require.NoError(t, mock.ExpectationsWereMet())
+	})
+
+	t.Run("option insert fails", func(t *testing.T) {
+		db, mock, err := sqlmock.New()
+		require.NoError(t, err)
+		defer db.Close()
+
+		mock.ExpectBegin()
+		mock.ExpectQuery("INSERT INTO polls .* RETURNING id").
+			WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow(4))
+		mock.ExpectExec("INSERT INTO poll_options").
+			WillReturnError(errors.New("database error"))
+		mock.ExpectRollback()
+
+		database := &Database{db: db}
+		_, err = database.SavePollResult(context.Background(), poll)
+
+		require.EqualError(t, err, "failed to save poll: failed to save option 1: database error")
+		require.NoError(t, mock.ExpectationsWereMet())
+	})
+}
@@ -0,0 +1,153 @@
+package database
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+)
+
+type Quote struct {
+	ID        int       `json:"id"`
+	Text      string    `json:"text"`
+	Author    string    `json:"author"`
+	Game      string    `json:"game"`
+	AddedBy   string    `json:"added_by"`
+	CreatedAt time.Time `json:"created_at"`
+}
+
+func (d *Database) AddQuote(ctx context.Context, text string, author string, game string, addedBy string) (*Quote, error) {
+	var quote Quote
+	err := d.WithTransaction(ctx, func(tx *sql.Tx) error {
+		const query = `
+			INSERT INTO quotes (text, author, game, added_by, search_text)
+			VALUES ($1, $2, $3, $4, $5)
+			RETURNING id, text, author, game, added_by, created_at
+		`
+		return scanQuote(tx.QueryRowContext(ctx, query, text, author, game, addedBy, quoteSearchText(text, author)), &quote)
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to add quote: %w", err)
+	}
+
+	return &quote, nil
+}
+
+// GetQuote returns nil if there is no quote with such id.
+func (d *Database) GetQuote(ctx context.Context, id int) (*Quote, error) {
+	return d.getSingleQuote(ctx, `
+		SELECT id, text, author, game, added_by, created_at
+		FROM quotes
+		WHERE id = $1
+	`, id)
+}
+
+// quoteSearchText is what the quote search looks in. SQLite has no ILIKE and its LIKE and lower() ignore the case of
+// ASCII letters only, so the text is lowered here, for both databases.
+func quoteSearchText(text string, author string) string {
+	return strings.ToLower(text + "\n" + author)
+}
+
+const randomQuoteQuery = `
+	SELECT id, text, author, game, added_by, created_at
+	FROM quotes
+	WHERE search_text LIKE '%' || $1 || '%' ESCAPE '\'
+	ORDER BY RANDOM()
+	LIMIT 1
+`
+
+// GetRandomQuote returns a random quote which text or author contains search (any quote if search is empty), or nil if nothing matches.
+// % and _ in search are matched literally.
+func (d *Database) GetRandomQuote(ctx context.Context, search string) (*Quote, error) {
+	return d.getSingleQuote(ctx, randomQuoteQuery, likeEscaper.Replace(strings.ToLower(search)))
+}
+
+var likeEscaper = strings.NewReplacer(`\`, `\\`, `%`, `\%`, `_`, `\_`)
+
+// FindQuote looks the quote up by id ("7" or "#7"), otherwise returns a random quote matching query (see GetRandomQuote).
+// It returns nil if nothing is found.
+func (d *Database) FindQuote(ctx context.Context, query string) (*Quote, error) {
+	if id, err := strconv.Atoi(strings.TrimPrefix(query, "#")); err == nil {
+		return d.GetQuote(ctx, id)
+	}
+	return d.GetRandomQuote(ctx, query)
+}
+
+func (d *Database) DeleteQuote(ctx context.Context, id int) (bool, error) {
+	var deleted bool
+	err := d.WithTransaction(ctx, func(tx *sql.Tx) error {
+		res, err := tx.ExecContext(ctx, `DELETE FROM quotes WHERE id = $1`, id)
+		if err != nil {
+			return err
+		}
+
+		affected, err := res.RowsAffected()
+		deleted = affected > 0
+		return err
+	})
+	if err != nil {
+		return false, fmt.Errorf("failed to delete quote: %w", err)
+	}
+
+	return deleted, nil
+}
+
+func (d *Database) GetAllQuotes(ctx context.Context) ([]Quote, error) {
+	var quotes []Quote
+	err := d.WithTransaction(ctx, func(tx *sql.Tx) error {
+		const query = `
+			SELECT id, text, author, game, added_by, created_at
+			FROM quotes
+			ORDER BY id
+		`
+		rows, err := tx.QueryContext(ctx, query)
+		if err != nil {
+			return err
+		}
+		defer rows.Close()
+
+		for rows.Next() {
+			var quote Quote
+			if err := scanQuote(rows, &quote); err != nil {
+				return fmt.Errorf("failed to scan quote row: %w", err)
+			}
+			quotes = append(quotes, quote)
+		}
+
+		return rows.Err()
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to get quotes: %w", err)
+	}
+
+	return quotes, nil
+}
+
+func (d *Database) getSingleQuote(ctx context.Context, query string, args ...any) (*Quote, error) {
+	var result *Quote
+	err := d.WithTransaction(ctx, func(tx *sql.Tx) error {
+		var quote Quote
+		err := scanQuote(tx.QueryRowContext(ctx, query, args...), &quote)
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil
+		}
+		if err != nil {
+			return err
+		}
+
+		result = &quote
+		return nil
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to get quote: %w", err)
+	}
+
+	return result, nil
+}
+
+func scanQuote(row interface{ Scan(dest ...any) error }, quote *Quote) error {
+	return row.Scan(&quote.ID, &quote.Text, &quote.Author, &quote.Game, &quote.AddedBy, &quote.CreatedAt)
+}
@@ -0,0 +1,112 @@
+package database
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/stretchr/testify/require"
+)
+
+var quoteColumns = []string{"id", "text", "author", "game", "added_by", "created_at"}
+
+func TestAddQuote(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	require.NoError(t, err)
+	defer db.Close()
+
+	now := time.Now()
+	mock.ExpectBegin()
+	mock.ExpectQuery("INSERT INTO quotes .* RETURNING .*").
+		WithArgs("It's fine", "gladarfin", "Dark Souls", "moduser", "it's fine\ngladarfin").
+		WillReturnRows(sqlmock.NewRows(quoteColumns).
+			AddRow(7, "It's fine", "gladarfin", "Dark Souls", "moduser", now))
+	mock.ExpectCommit()
+
+	database := &Database{db: db}
+	quote, err := database.AddQuote(context.Background(), "It's fine", "gladarfin", "Dark Souls", "moduser")
+
+	require.NoError(t, err)
+	require.Equal(t, &Quote{ID: 7, Text: "It's fine", Author: "gladarfin", Game: "Dark Souls", AddedBy: "moduser", CreatedAt: now}, quote)
+	require.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestGetQuote(t *testing.T) {
+	t.Run("found", func(t *testing.T) {
+		db, mock, err := sqlmock.New()
+		require.NoError(t, err)
+		defer db.Close()
+
+		mock.ExpectBegin()
+		mock.ExpectQuery("SELECT .* FROM quotes WHERE id = \\$1").
+			WithArgs(7).
+			WillReturnRows(sqlmock.NewRows(quoteColumns).
+				AddRow(7, "It's fine", "gladarfin", "", "moduser", time.Now()))
+		mock.ExpectCommit()
+
+		database := &Database{db: db}
+		quote, err := database.GetQuote(context.Background(), 7)
+
+		require.NoError(t, err)
+		require.NotNil(t, quote)
+		require.Equal(t, 7, quote.ID)
+		require.NoError(t, mock.ExpectationsWereMet())
+	})
+
+	t.Run("not found", func(t *testing.T) {
+		db, mock, err := sqlmock.New()
+		require.NoError(t, err)
+		defer db.Close()
+
+		mock.ExpectBegin()
+		mock.ExpectQuery("SELECT .* FROM quotes WHERE search_text LIKE .* ORDER BY RANDOM\\(\\)").
+			WithArgs("nothing").
+			WillReturnRows(sqlmock.NewRows(quoteColumns))
+		mock.ExpectCommit()
+
+		database := &Database{db: db}
+		quote, err := database.GetRandomQuote(context.Background(), "nothing")
+
+		require.NoError(t, err)
+		require.Nil(t, quote)
+		require.NoError(t, mock.ExpectationsWereMet())
+	})
+
+	t.Run("search is escaped", func(t *testing.T) {
+		db, mock, err := sqlmock.New()
+		require.NoError(t, err)
+		defer db.Close()
+
+		mock.ExpectBegin()
+		mock.ExpectQuery("SELECT .* FROM quotes WHERE search_text LIKE .* ESCAPE").
+			WithArgs(`100\% \_ok\\`).
+			WillReturnRows(sqlmock.NewRows(quoteColumns))
+		mock.ExpectCommit()
+
+		database := &Database{db: db}
+		quote, err := database.FindQuote(context.Background(), `100% _ok\`)
+
+		require.NoError(t, err)
+		require.Nil(t, quote)
+		require.NoError(t, mock.ExpectationsWereMet())
+	})
+
+	t.Run("find by id", func(t *testing.T) {
+		db, mock, err := sqlmock.New()
+		require.NoError(t, err)
+		defer db.Close()
+
+		mock.ExpectBegin()
+		mock.ExpectQuery("SELECT .* FROM quotes WHERE id = \\$1").
+			WithArgs(7).
+			WillReturnRows(sqlmock.NewRows(quoteColumns))
+		mock.ExpectCommit()
+
+		database := &Database{db: db}
+		_, err = database.FindQuote(context.Background(), "#7")
+
+		require.NoError(t, err)
+		require.NoError(t, mock.ExpectationsWereMet())
+	})
+}
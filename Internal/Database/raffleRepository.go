@@ -0,0 +1,121 @@
+package database
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"time"
+)
+
+type RaffleInfo struct {
+	ID           int
+	Keyword      string
+	Weighting    string
+	StartedBy    string
+	StartedAt    time.Time
+	EndedAt      sql.NullTime
+	Winner       sql.NullString
+	EntriesCount int
+	DrawsCount   int
+}
+
+func (d *Database) CreateRaffle(ctx context.Context, keyword string, weighting string, startedBy string) (int, error) {
+	var raffleID int
+	err := d.WithTransaction(ctx, func(tx *sql.Tx) error {
+		const query = `
+			INSERT INTO raffles (keyword, weighting, started_by)
+			VALUES ($1, $2, $3)
+			RETURNING id
+		`
+		return tx.QueryRowContext(ctx, query, keyword, weighting, startedBy).Scan(&raffleID)
+	})
+	if err != nil {
+		return 0, fmt.Errorf("failed to create raffle: %w", err)
+	}
+
+	return raffleID, nil
+}
+
+func (d *Database) AddRaffleEntry(ctx context.Context, raffleID int, username string, tickets int) error {
+	return d.WithTransaction(ctx, func(tx *sql.Tx) error {
+		const query = `
+			INSERT INTO raffle_entries (raffle_id, username, tickets)
+			VALUES ($1, $2, $3)
+			ON CONFLICT (raffle_id, username) DO NOTHING
+		`
+		if _, err := tx.ExecContext(ctx, query, raffleID, username, tickets); err != nil {
+			return fmt.Errorf("failed to add raffle entry: %w", err)
+		}
+		return nil
+	})
+}
+
+func (d *Database) RecordRaffleDraw(ctx context.Context, raffleID int, username string, responded bool) error {
+	return d.WithTransaction(ctx, func(tx *sql.Tx) error {
+		const query = `
+			INSERT INTO raffle_draws (raffle_id, username, responded)
+			VALUES ($1, $2, $3)
+		`
+		if _, err := tx.ExecContext(ctx, query, raffleID, username, responded); err != nil {
+			return fmt.Errorf("failed to record raffle draw: %w", err)
+		}
+		return nil
+	})
+}
+
+// FinishRaffle closes the raffle, winner is empty if nobody has claimed the prize.
+func (d *Database) FinishRaffle(ctx context.Context, raffleID int, winner string) error {
+	return d.WithTransaction(ctx, func(tx *sql.Tx) error {
+		const query = `
+			UPDATE raffles
+			SET ended_at = NOW(), winner = NULLIF($2, '')
+			WHERE id = $1
+		`
+		if _, err := tx.ExecContext(ctx, query, raffleID, winner); err != nil {
+			return fmt.Errorf("failed to finish raffle: %w", err)
+		}
+		return nil
+	})
+}
+
+// GetLatestRaffle returns nil if there were no raffles yet.
+func (d *Database) GetLatestRaffle(ctx context.Context) (*RaffleInfo, error) {
+	var result *RaffleInfo
+	err := d.WithTransaction(ctx, func(tx *sql.Tx) error {
+		const query = `
+			SELECT r.id, r.keyword, r.weighting, r.started_by, r.started_at, r.ended_at, r.winner,
+				(SELECT COUNT(*) FROM raffle_entries e WHERE e.raffle_id = r.id),
+				(SELECT COUNT(*) FROM raffle_draws rd WHERE rd.raffle_id = r.id)
+			FROM raffles r
+			ORDER BY r.id DESC
+			LIMIT 1
+		`
+		var info RaffleInfo
+		err := tx.QueryRowContext(ctx, query).Scan(
+			&info.ID,
+			&info.Keyword,
+			&info.Weighting,
+			&info.StartedBy,
+			&info.StartedAt,
+			&info.EndedAt,
+			&info.Winner,
+			&info.EntriesCount,
+			&info.DrawsCount,
+		)
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil
+		}
+		if err != nil {
+			return err
+		}
+
+		result = &info
+		return nil
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to get raffle: %w", err)
+	}
+
+	return result, nil
+}
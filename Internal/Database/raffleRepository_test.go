@@ -0,0 +1,71 @@
+package database
+
+import (
+	"context"
+	"database/sql"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/stretchr/testify/require"
+)
+
+func TestFinishRaffle(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	require.NoError(t, err)
+	defer db.Close()
+
+	mock.ExpectBegin()
+	mock.ExpectExec("UPDATE raffles SET ended_at = NOW\\(\\), winner = NULLIF\\(\\$2, ''\\) WHERE id = \\$1").
+		WithArgs(3, "lucky").
+		WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectCommit()
+
+	database := &Database{db: db}
+	err = database.FinishRaffle(context.Background(), 3, "lucky")
+
+	require.NoError(t, err)
+	require.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestGetLatestRaffle(t *testing.T) {
+	t.Run("in progress", func(t *testing.T) {
+		db, mock, err := sqlmock.New()
+		require.NoError(t, err)
+		defer db.Close()
+
+		now := time.Now()
+		mock.ExpectBegin()
+		mock.ExpectQuery("SELECT r.id, .* FROM raffles r ORDER BY r.id DESC LIMIT 1").
+			WillReturnRows(sqlmock.NewRows([]string{"id", "keyword", "weighting", "started_by", "started_at", "ended_at", "winner", "entries", "draws"}).
+				AddRow(3, "!join", "luck", "moduser", now, nil, nil, 12, 0))
+		mock.ExpectCommit()
+
+		database := &Database{db: db}
+		raffle, err := database.GetLatestRaffle(context.Background())
+
+		require.NoError(t, err)
+		require.Equal(t, 12, raffle.EntriesCount)
+		require.False(t, raffle.EndedAt.Valid)
+		require.Equal(t, sql.NullString{}, raffle.Winner)
+		require.NoError(t, mock.ExpectationsWereMet())
+	})
+
+	t.Run("no raffles", func(t *testing.T) {
+		db, mock, err := sqlmock.New()
+		require.NoError(t, err)
+		defer db.Close()
+
+		mock.ExpectBegin()
+		mock.ExpectQuery("SELECT r.id, .* FROM raffles r").
+			WillReturnError(sql.ErrNoRows)
+		mock.ExpectCommit()
+
+		database := &Database{db: db}
+		raffle, err := database.GetLatestRaffle(context.Background())
+
+		require.NoError(t, err)
+		require.Nil(t, raffle)
+		require.NoError(t, mock.ExpectationsWereMet())
+	})
+}
@@ -0,0 +1,150 @@
+package database
+
+import (
+	"context"
+	"errors"
+	"time"
+)
+
+var ErrUserNotFound = errors.New("user not found")
+
+type UserRepository interface {
+	CreateUser(ctx context.Context, username string) (*User, error)
+	// GetUser returns ErrUserNotFound if there is no such user.
+	GetUser(ctx context.Context, username string) (*User, error)
+}
+
+type StatsRepository interface {
+	GetOrCreateUserStats(ctx context.Context, username string) ([]UserStats, error)
+	// GetTwitchUserStats doesn't create anything, it returns ErrUserNotFound for unknown users.
+	GetTwitchUserStats(ctx context.Context, username string) ([]UserStats, error)
+	UpdateUserStat(ctx context.Context, username string, stat string, val int) (string, int, error)
+	DecreaseUserStat(ctx context.Context, username string, stat string, val int, refundRatio float64) (string, int, int, error)
+	RespecUserStats(ctx context.Context, username string, cooldown time.Duration) (int, time.Duration, error)
+	AddFreePoints(ctx context.Context, username string, points int) (int, error)
+}
+
+type ResultRepository interface {
+	GetUserResults(ctx context.Context, userID int) (*UserResult, error)
+	IncrementUserResult(ctx context.Context, userID int, resultType string) error
+	UpdateResultsAfterDuel(ctx context.Context, initiator string, challenger string, result int) error
+}
+
+type ItemRepository interface {
+	// BuyItem returns ErrItemAlreadyOwned or ErrNotEnoughFreePoints without changing anything.
+	BuyItem(ctx context.Context, username string, itemName string, slot string, price int) (int, error)
+	GetUserItems(ctx context.Context, username string) ([]UserItem, error)
+	// EquipItem returns ErrItemNotOwned if the user doesn't have the item.
+	EquipItem(ctx context.Context, username string, itemName string) (string, error)
+	UnequipItem(ctx context.Context, username string, nameOrSlot string) (string, error)
+}
+
+type LevelRepository interface {
+	AddXP(ctx context.Context, username string, amount int, levelForXP func(xp int) int, pointsPerLevel int) (*XPResult, error)
+	GetUserLevel(ctx context.Context, username string) (*UserLevel, error)
+	ClaimAttendance(ctx context.Context, username string, day time.Time) (bool, error)
+}
+
+type DailyRepository interface {
+	ClaimDaily(ctx context.Context, username string, day time.Time, graceDays int, rewardForStreak func(streak int) int) (*DailyResult, error)
+	GetDailyStreak(ctx context.Context, username string) (*DailyStreak, error)
+	GetTopDailyStreaks(ctx context.Context, today time.Time, graceDays int, limit int) ([]DailyStreak, error)
+}
+
+type AchievementRepository interface {
+	IncrementAchievementProgress(ctx context.Context, username string, event string) (int, error)
+	UnlockAchievement(ctx context.Context, username string, achievementID string) (bool, error)
+	GetUserAchievements(ctx context.Context, username string) ([]UserAchievement, error)
+}
+
+type GreetingRepository interface {
+	GetGreetingLanguage(ctx context.Context, username string) (string, error)
+	SetGreetingLanguage(ctx context.Context, username string, language string) error
+	SetNoGreet(ctx context.Context, username string, noGreet bool) error
+	RegisterChatter(ctx context.Context, username string) (*ChatterVisit, error)
+}
+
+type QuoteRepository interface {
+	AddQuote(ctx context.Context, text string, author string, game string, addedBy string) (*Quote, error)
+	FindQuote(ctx context.Context, query string) (*Quote, error)
+	DeleteQuote(ctx context.Context, id int) (bool, error)
+	GetAllQuotes(ctx context.Context) ([]Quote, error)
+}
+
+type CounterRepository interface {
+	CreateCounter(ctx context.Context, name string, perGame bool) (*Counter, error)
+	DeleteCounter(ctx context.Context, name string) (bool, error)
+	GetCounter(ctx context.Context, name string) (*Counter, error)
+	GetCounters(ctx context.Context) ([]Counter, error)
+	GetCounterValue(ctx context.Context, counterID int, game string) (int, error)
+	AddToCounter(ctx context.Context, counterID int, game string, delta int) (int, error)
+	SetCounter(ctx context.Context, counterID int, game string, value int) error
+}
+
+type TimerRepository interface {
+	SaveTimer(ctx context.Context, name string, message string, interval time.Duration, minLines int) (*Timer, error)
+	RemoveTimer(ctx context.Context, name string) (bool, error)
+	GetTimers(ctx context.Context) ([]Timer, error)
+	MarkTimerPosted(ctx context.Context, timerID int, postedAt time.Time) error
+}
+
+type PollRepository interface {
+	SavePollResult(ctx context.Context, poll PollResult) (int, error)
+}
+
+type RaffleRepository interface {
+	CreateRaffle(ctx context.Context, keyword string, weighting string, startedBy string) (int, error)
+	AddRaffleEntry(ctx context.Context, raffleID int, username string, tickets int) error
+	RecordRaffleDraw(ctx context.Context, raffleID int, username string, responded bool) error
+	FinishRaffle(ctx context.Context, raffleID int, winner string) error
+	GetLatestRaffle(ctx context.Context) (*RaffleInfo, error)
+}
+
+type BossRaidRepository interface {
+	SaveBossRaid(ctx context.Context, raid BossRaidResult) (int, error)
+}
+
+type HLTBCacheRepository interface {
+	GetHLTBCache(ctx context.Context, query string, maxAge time.Duration) ([]byte, error)
+	SaveHLTBCache(ctx context.Context, query string, results []byte) error
+}
+
+// Repositories are injected into the bots, so handlers can run on another implementation (e.g. the in-memory store).
+type Repositories struct {
+	Users        UserRepository
+	Stats        StatsRepository
+	Results      ResultRepository
+	Items        ItemRepository
+	Levels       LevelRepository
+	Daily        DailyRepository
+	Achievements AchievementRepository
+	Greetings    GreetingRepository
+	Quotes       QuoteRepository
+	Counters     CounterRepository
+	Timers       TimerRepository
+	Polls        PollRepository
+	Raffles      RaffleRepository
+	BossRaids    BossRaidRepository
+	HLTB         HLTBCacheRepository
+}
+
+// Repositories returns the Postgres implementation of all repositories.
+func (d *Database) Repositories() Repositories {
+	return Repositories{
+		Users:        d,
+		Stats:        d,
+		Results:      d,
+		Items:        d,
+		Levels:       d,
+		Daily:        d,
+		Achievements: d,
+		Greetings:    d,
+		Quotes:       d,
+		Counters:     d,
+		Timers:       d,
+		Polls:        d,
+		Raffles:      d,
+		BossRaids:    d,
+		HLTB:         d,
+	}
+}
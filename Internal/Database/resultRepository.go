@@ -8,18 +8,20 @@ import (
 )
 
 type UserResult struct {
-	UserID    int
-	Wins      int
-	Draws     int
-	Loses     int
-	UpdatedAt sql.NullTime
+	UserID int
+	Wins   int
+	Draws  int
+	Loses  int
+	//Free points earned in duels, the thresholds for the next point grow with them
+	DuelPoints int
+	UpdatedAt  sql.NullTime
 }
 
 func (d *Database) GetUserResults(ctx context.Context, userID int) (*UserResult, error) {
 	var result *UserResult
 	err := d.WithTransaction(ctx, func(tx *sql.Tx) error {
 		const query = `
-			SELECT total_wins, total_draws, total_lose, updated_at
+			SELECT total_wins, total_draws, total_lose, duel_points, updated_at
 			FROM user_results
 			WHERE user_id = $1
 		`
@@ -29,6 +31,7 @@ func (d *Database) GetUserResults(ctx context.Context, userID int) (*UserResult,
 			&res.Wins,
 			&res.Draws,
 			&res.Loses,
+			&res.DuelPoints,
 			&res.UpdatedAt,
 		)
 
@@ -73,52 +76,51 @@ func (d *Database) IncrementUserResult(ctx context.Context, userID int, resultTy
 	})
 }
 
+// UpdateResultsAfterDuel records the duel result of both users and grants free points, all in one transaction.
 func (d *Database) UpdateResultsAfterDuel(ctx context.Context, initiator string, challenger string, result int) error {
-	return d.WithTransaction(ctx, func(tx *sql.Tx) error {
-		tempRepo := &Database{db: tx}
-
-		challengerId, err := tempRepo.getUserIdByUsername(ctx, challenger)
+	return d.InTransaction(ctx, func(repo *Database) error {
+		challengerId, err := repo.getUserIdByUsername(ctx, challenger)
 		if err != nil {
 			return fmt.Errorf("failed to get challenger ID: %w", err)
 		}
 
-		initiatorId, err := tempRepo.getUserIdByUsername(ctx, initiator)
+		initiatorId, err := repo.getUserIdByUsername(ctx, initiator)
 		if err != nil {
 			return fmt.Errorf("failed to get initiator ID: %w", err)
 		}
 
 		switch result {
 		case 0:
-			if err := tempRepo.IncrementUserResult(ctx, initiatorId, "draw"); err != nil {
+			if err := repo.IncrementUserResult(ctx, initiatorId, "draw"); err != nil {
 				return fmt.Errorf("failed to update initiator result values: %w", err)
 			}
-			if err := tempRepo.IncrementUserResult(ctx, challengerId, "draw"); err != nil {
+			if err := repo.IncrementUserResult(ctx, challengerId, "draw"); err != nil {
 				return fmt.Errorf("failed to update challenger result values: %w", err)
 			}
-			if err := tempRepo.updateUserPoints(ctx, initiatorId, "draw"); err != nil {
+			if err := repo.updateUserPoints(ctx, initiatorId, "draw"); err != nil {
 				return fmt.Errorf("failed to update initiator stats: %w", err)
 			}
-			if err := tempRepo.updateUserPoints(ctx, challengerId, "draw"); err != nil {
+			if err := repo.updateUserPoints(ctx, challengerId, "draw"); err != nil {
 				return fmt.Errorf("failed to update challenger stats: %w", err)
 			}
 		case 1:
-			if err := tempRepo.IncrementUserResult(ctx, initiatorId, "win"); err != nil {
+			if err := repo.IncrementUserResult(ctx, initiatorId, "win"); err != nil {
 				return fmt.Errorf("failed to update initiator result values: %w", err)
 			}
-			if err := tempRepo.IncrementUserResult(ctx, challengerId, "lose"); err != nil {
+			if err := repo.IncrementUserResult(ctx, challengerId, "lose"); err != nil {
 				return fmt.Errorf("failed to update challenger result values: %w", err)
 			}
-			if err := tempRepo.updateUserPoints(ctx, initiatorId, "win"); err != nil {
+			if err := repo.updateUserPoints(ctx, initiatorId, "win"); err != nil {
 				return fmt.Errorf("failed to update initiator stats: %w", err)
 			}
 		case 2:
-			if err := tempRepo.IncrementUserResult(ctx, initiatorId, "lose"); err != nil {
+			if err := repo.IncrementUserResult(ctx, initiatorId, "lose"); err != nil {
 				return fmt.Errorf("failed to update initiator result values: %w", err)
 			}
-			if err := tempRepo.IncrementUserResult(ctx, challengerId, "win"); err != nil {
+			if err := repo.IncrementUserResult(ctx, challengerId, "win"); err != nil {
 				return fmt.Errorf("failed to update challenger result values: %w", err)
 			}
-			if err := tempRepo.updateUserPoints(ctx, challengerId, "win"); err != nil {
+			if err := repo.updateUserPoints(ctx, challengerId, "win"); err != nil {
 				return fmt.Errorf("failed to update challenger stats: %w", err)
 			}
 		default:
@@ -147,11 +149,11 @@ func (d *Database) getUserIdByUsername(ctx context.Context, username string) (in
 
 func (d *Database) updateUserPoints(ctx context.Context, userID int, result string) error {
 	return d.WithTransaction(ctx, func(tx *sql.Tx) error {
-		var winsCount, drawsCount int
+		var winsCount, drawsCount, duelPoints int
 		err := tx.QueryRowContext(ctx,
-			`SELECT total_wins, total_draws FROM user_results WHERE user_id = $1`,
+			`SELECT total_wins, total_draws, duel_points FROM user_results WHERE user_id = $1`,
 			userID).
-			Scan(&winsCount, &drawsCount)
+			Scan(&winsCount, &drawsCount, &duelPoints)
 		if err != nil {
 			return fmt.Errorf("couldn't get user points: %w", err)
 		}
@@ -169,23 +171,13 @@ func (d *Database) updateUserPoints(ctx context.Context, userID int, result stri
 			return fmt.Errorf("couldn't get user totat free points stat: %w", err)
 		}
 
-		const getStatTypeIdQuery = `SELECT value FROM user_stats WHERE user_id = $1 AND stat_type_id = $2`
-		var freePoints, totalFreePoints int
-		err = tx.QueryRowContext(ctx, getStatTypeIdQuery, userID, statTypeIDFreePoints).Scan(&freePoints)
-		if err != nil {
-			return fmt.Errorf("couldn't get user free points value: %w", err)
-		}
-		err = tx.QueryRowContext(ctx, getStatTypeIdQuery, userID, statTypeIDTotalFreePoints).Scan(&totalFreePoints)
-		if err != nil {
-			return fmt.Errorf("couldn't get user total free points value: %w", err)
-		}
-
 		const WIN_THRESHOLD_COEFF = 10
 		const DRAW_THRESHOLD_COEFF = 20
 		const MAX_POINTS_BEFORE_INCREMENT = 5
 
-		winThreshold := WIN_THRESHOLD_COEFF + WIN_THRESHOLD_COEFF*int(math.Floor(float64(totalFreePoints)/MAX_POINTS_BEFORE_INCREMENT))
-		drawThreshold := DRAW_THRESHOLD_COEFF + DRAW_THRESHOLD_COEFF*int(math.Floor(float64(totalFreePoints)/MAX_POINTS_BEFORE_INCREMENT))
+		//The thresholds grow with the points earned in duels only, level-ups and other rewards don't count
+		winThreshold := WIN_THRESHOLD_COEFF + WIN_THRESHOLD_COEFF*int(math.Floor(float64(duelPoints)/MAX_POINTS_BEFORE_INCREMENT))
+		drawThreshold := DRAW_THRESHOLD_COEFF + DRAW_THRESHOLD_COEFF*int(math.Floor(float64(duelPoints)/MAX_POINTS_BEFORE_INCREMENT))
 
 		var pointsEarned int
 		switch result {
@@ -212,6 +204,10 @@ func (d *Database) updateUserPoints(ctx context.Context, userID int, result stri
 			if err != nil {
 				return fmt.Errorf("couldn't update user total free points stat: %w", err)
 			}
+			_, err = tx.ExecContext(ctx, `UPDATE user_results SET duel_points = duel_points + $1 WHERE user_id = $2`, pointsEarned, userID)
+			if err != nil {
+				return fmt.Errorf("couldn't update user duel points: %w", err)
+			}
 		}
 		return nil
 	})
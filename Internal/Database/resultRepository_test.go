@@ -1 +1,53 @@
 package database
+
+import (
+	"context"
+	"testing"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/stretchr/testify/require"
+)
+
+func TestUpdateResultsAfterDuel(t *testing.T) {
+	db, mock := SetupMockDB(t)
+	defer db.Close()
+
+	mock.ExpectBegin()
+	mock.ExpectQuery("SELECT id FROM users WHERE username = \\$1").
+		WithArgs("bob").
+		WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow(2))
+	mock.ExpectQuery("SELECT id FROM users WHERE username = \\$1").
+		WithArgs("alice").
+		WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow(1))
+	mock.ExpectExec("INSERT INTO user_results \\(user_id, total_wins\\)").
+		WithArgs(1).
+		WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectExec("INSERT INTO user_results \\(user_id, total_lose\\)").
+		WithArgs(2).
+		WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectQuery("SELECT total_wins, total_draws, duel_points FROM user_results WHERE user_id = \\$1").
+		WithArgs(1).
+		WillReturnRows(sqlmock.NewRows([]string{"total_wins", "total_draws", "duel_points"}).AddRow(20, 0, 5))
+	mock.ExpectQuery("SELECT id FROM stat_types WHERE name = \\$1").
+		WithArgs("free-points").
+		WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow(8))
+	mock.ExpectQuery("SELECT id FROM stat_types WHERE name = \\$1").
+		WithArgs("total-free-points").
+		WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow(9))
+	//5 duel points double the win threshold to 20
+	mock.ExpectExec("UPDATE user_stats").
+		WithArgs(1, 1, 8).
+		WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectExec("UPDATE user_stats").
+		WithArgs(1, 1, 9).
+		WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectExec("UPDATE user_results SET duel_points = duel_points \\+ \\$1 WHERE user_id = \\$2").
+		WithArgs(1, 1).
+		WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectCommit()
+
+	err := db.UpdateResultsAfterDuel(context.Background(), "alice", "bob", 1)
+
+	require.NoError(t, err)
+	require.NoError(t, mock.ExpectationsWereMet())
+}
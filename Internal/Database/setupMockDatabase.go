@@ -11,7 +11,5 @@ func SetupMockDB(t *testing.T) (*Database, sqlmock.Sqlmock) {
 	db, mock, err := sqlmock.New()
 	require.NoError(t, err)
 
-	mock.ExpectBegin().WillReturnError(nil)
-
 	return &Database{db: db}, mock
 }
@@ -0,0 +1,76 @@
+package database
+
+import (
+	"database/sql/driver"
+	"fmt"
+	"net/url"
+	"strings"
+	"time"
+
+	"modernc.org/sqlite"
+)
+
+// sqliteTimeFormat is how the driver writes time.Time with _time_format=sqlite, NOW() returns the same.
+const sqliteTimeFormat = "2006-01-02 15:04:05.999999999-07:00"
+
+func init() {
+	sqlite.MustRegisterScalarFunction("now", 0, func(*sqlite.FunctionContext, []driver.Value) (driver.Value, error) {
+		return time.Now().UTC().Format(sqliteTimeFormat), nil
+	})
+	sqlite.MustRegisterDeterministicScalarFunction("greatest", -1, extremum("greatest", 1))
+	sqlite.MustRegisterDeterministicScalarFunction("least", -1, extremum("least", -1))
+	//The built-in lower() only knows ASCII letters, the migrations call this one instead (see translateSchema)
+	sqlite.MustRegisterDeterministicScalarFunction("unicode_lower", 1, unicodeLower)
+}
+
+// NewSQLite opens (or creates) the SQLite database file at path. The schema is created by the same migrations as
+// for Postgres, run MigrateUp on a new file.
+func NewSQLite(path string) (*Database, error) {
+	params := url.Values{}
+	params.Add("_pragma", "foreign_keys(1)")
+	params.Add("_pragma", "busy_timeout(5000)")
+	params.Add("_pragma", "journal_mode(WAL)")
+	params.Set("_time_format", "sqlite")
+	//Transactions take the write lock at once, otherwise two of them can't upgrade their read locks and one fails
+	params.Set("_txlock", "immediate")
+
+	return open("sqlite", path+"?"+params.Encode(), SQLite)
+}
+
+// unicodeLower lowers all letters like lower() in Postgres.
+func unicodeLower(_ *sqlite.FunctionContext, args []driver.Value) (driver.Value, error) {
+	switch value := args[0].(type) {
+	case nil:
+		return nil, nil
+	case string:
+		return strings.ToLower(value), nil
+	default:
+		return value, nil
+	}
+}
+
+// extremum implements GREATEST (sign 1) and LEAST (sign -1) for numbers, NULLs are ignored like in Postgres.
+func extremum(name string, sign int) func(*sqlite.FunctionContext, []driver.Value) (driver.Value, error) {
+	return func(_ *sqlite.FunctionContext, args []driver.Value) (driver.Value, error) {
+		var result driver.Value
+		var resultNumber float64
+		for _, arg := range args {
+			var number float64
+			switch value := arg.(type) {
+			case nil:
+				continue
+			case int64:
+				number = float64(value)
+			case float64:
+				number = value
+			default:
+				return nil, fmt.Errorf("%s: unsupported argument %T", name, arg)
+			}
+
+			if result == nil || (number-resultNumber)*float64(sign) > 0 {
+				result, resultNumber = arg, number
+			}
+		}
+		return result, nil
+	}
+}
@@ -0,0 +1,266 @@
+package database
+
+import (
+	"context"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func newSQLiteTestDB(t *testing.T) *Database {
+	t.Helper()
+	database, err := NewSQLite(filepath.Join(t.TempDir(), "bot.db"))
+	require.NoError(t, err)
+	t.Cleanup(func() { database.Close() })
+
+	_, err = database.MigrateUp(context.Background())
+	require.NoError(t, err)
+	return database
+}
+
+func TestSQLiteMigrations(t *testing.T) {
+	ctx := context.Background()
+	database := newSQLiteTestDB(t)
+
+	statuses, err := database.MigrationStatus(ctx)
+	require.NoError(t, err)
+	for _, status := range statuses {
+		require.NotNil(t, status.AppliedAt, "migration %d", status.Version)
+	}
+
+	reverted, err := database.MigrateDown(ctx, len(statuses))
+	require.NoError(t, err)
+	require.Len(t, reverted, len(statuses))
+
+	applied, err := database.MigrateUp(ctx)
+	require.NoError(t, err)
+	require.Len(t, applied, len(statuses))
+}
+
+func TestBaseStatsDownKeepsUserStats(t *testing.T) {
+	ctx := context.Background()
+	database := newSQLiteTestDB(t)
+
+	_, err := database.AddFreePoints(ctx, "veteran", 5)
+	require.NoError(t, err)
+
+	//Reverts everything after 0001_initial_schema
+	statuses, err := database.MigrationStatus(ctx)
+	require.NoError(t, err)
+	_, err = database.MigrateDown(ctx, len(statuses)-1)
+	require.NoError(t, err)
+	_, err = database.MigrateUp(ctx)
+	require.NoError(t, err)
+
+	stats, err := database.GetTwitchUserStats(ctx, "veteran")
+	require.NoError(t, err)
+	require.NotEmpty(t, stats)
+	for _, stat := range stats {
+		if stat.StatType == "free-points" {
+			require.Equal(t, 5, stat.Value)
+		}
+	}
+}
+
+func TestBackfillChattersKeepsRegulars(t *testing.T) {
+	ctx := context.Background()
+	database := newSQLiteTestDB(t)
+
+	//A regular from before the chatters table: the user exists, the chatters row doesn't
+	_, err := database.AddFreePoints(ctx, "regular", 1)
+	require.NoError(t, err)
+	//Reverts 0005_backfill_chatters and everything after it
+	statuses, err := database.MigrationStatus(ctx)
+	require.NoError(t, err)
+	_, err = database.MigrateDown(ctx, len(statuses)-4)
+	require.NoError(t, err)
+	_, err = database.MigrateUp(ctx)
+	require.NoError(t, err)
+
+	visit, err := database.RegisterChatter(ctx, "regular")
+	require.NoError(t, err)
+	require.False(t, visit.IsNew)
+	require.True(t, visit.LastSeen.Valid)
+
+	visit, err = database.RegisterChatter(ctx, "newcomer")
+	require.NoError(t, err)
+	require.True(t, visit.IsNew)
+}
+
+// TestSQLiteRepositories runs the statements written for both databases (AS aliases, unqualified RETURNING, NOW, GREATEST and LEAST) on SQLite.
+func TestSQLiteRepositories(t *testing.T) {
+	ctx := context.Background()
+	database := newSQLiteTestDB(t)
+
+	t.Run("items", func(t *testing.T) {
+		_, err := database.AddFreePoints(ctx, "buyer", 5)
+		require.NoError(t, err)
+
+		freePoints, err := database.BuyItem(ctx, "buyer", "Sword", "weapon", 3)
+		require.NoError(t, err)
+		require.Equal(t, 2, freePoints)
+		_, err = database.BuyItem(ctx, "buyer", "Axe", "weapon", 3)
+		require.ErrorIs(t, err, ErrNotEnoughFreePoints)
+
+		_, err = database.EquipItem(ctx, "buyer", "Sword")
+		require.NoError(t, err)
+		unequipped, err := database.UnequipItem(ctx, "buyer", "weapon")
+		require.NoError(t, err)
+		require.Equal(t, "Sword", unequipped)
+	})
+
+	t.Run("counters", func(t *testing.T) {
+		counter, err := database.CreateCounter(ctx, "deaths", true)
+		require.NoError(t, err)
+
+		value, err := database.AddToCounter(ctx, counter.ID, "Elden Ring", 2)
+		require.NoError(t, err)
+		require.Equal(t, 2, value)
+		value, err = database.AddToCounter(ctx, counter.ID, "Elden Ring", -5)
+		require.NoError(t, err)
+		require.Equal(t, 0, value, "counters don't go below zero")
+	})
+
+	t.Run("quotes", func(t *testing.T) {
+		_, err := database.AddQuote(ctx, "It's dangerous to go alone", "Old Man", "Zelda", "mod")
+		require.NoError(t, err)
+
+		quote, err := database.GetRandomQuote(ctx, "DANGEROUS")
+		require.NoError(t, err)
+		require.NotNil(t, quote)
+		require.Equal(t, "Old Man", quote.Author)
+		require.False(t, quote.CreatedAt.IsZero())
+
+		quote, err = database.GetRandomQuote(ctx, "dangerous_to")
+		require.NoError(t, err)
+		require.Nil(t, quote, "_ isn't a wildcard")
+
+		_, err = database.AddQuote(ctx, "Ну, погоди!", "Волк", "", "mod")
+		require.NoError(t, err)
+		quote, err = database.GetRandomQuote(ctx, "ПОГОДИ")
+		require.NoError(t, err)
+		require.NotNil(t, quote, "the search ignores the case of non-ASCII letters too")
+		require.Equal(t, "Волк", quote.Author)
+	})
+
+	t.Run("quote search text backfill", func(t *testing.T) {
+		_, err := database.AddQuote(ctx, "Ёлки-палки", "Дед", "", "mod")
+		require.NoError(t, err)
+
+		//Reverts and applies 0006_quote_search_text again, like on a database with quotes from before it
+		statuses, err := database.MigrationStatus(ctx)
+		require.NoError(t, err)
+		_, err = database.MigrateDown(ctx, len(statuses)-5)
+		require.NoError(t, err)
+		_, err = database.MigrateUp(ctx)
+		require.NoError(t, err)
+
+		quote, err := database.GetRandomQuote(ctx, "ёлки")
+		require.NoError(t, err)
+		require.NotNil(t, quote)
+		require.Equal(t, "Дед", quote.Author)
+	})
+
+	t.Run("daily and levels", func(t *testing.T) {
+		day := time.Date(2026, 10, 19, 0, 0, 0, 0, time.UTC)
+		reward := func(int) int { return 1 }
+
+		result, err := database.ClaimDaily(ctx, "regular", day, 1, reward)
+		require.NoError(t, err)
+		require.True(t, result.Claimed)
+		result, err = database.ClaimDaily(ctx, "regular", day, 1, reward)
+		require.NoError(t, err)
+		require.False(t, result.Claimed)
+		result, err = database.ClaimDaily(ctx, "regular", day.AddDate(0, 0, 1), 1, reward)
+		require.NoError(t, err)
+		require.Equal(t, 2, result.Streak)
+		top, err := database.GetTopDailyStreaks(ctx, day.AddDate(0, 0, 1), 1, 10)
+		require.NoError(t, err)
+		require.Len(t, top, 1)
+		require.Equal(t, "regular", top[0].Username)
+
+		xp, err := database.AddXP(ctx, "regular", 150, func(xp int) int { return xp/100 + 1 }, 1)
+		require.NoError(t, err)
+		require.Equal(t, 2, xp.NewLevel)
+		require.Equal(t, 1, xp.FreePointsGranted)
+
+		claimed, err := database.ClaimAttendance(ctx, "regular", day)
+		require.NoError(t, err)
+		require.True(t, claimed)
+		claimed, err = database.ClaimAttendance(ctx, "regular", day)
+		require.NoError(t, err)
+		require.False(t, claimed)
+	})
+
+	t.Run("greetings and chatters", func(t *testing.T) {
+		visit, err := database.RegisterChatter(ctx, "newcomer")
+		require.NoError(t, err)
+		require.True(t, visit.IsNew)
+
+		visit, err = database.RegisterChatter(ctx, "newcomer")
+		require.NoError(t, err)
+		require.True(t, visit.LastSeen.Valid)
+
+		require.NoError(t, database.SetGreetingLanguage(ctx, "newcomer", "es"))
+		language, err := database.GetGreetingLanguage(ctx, "newcomer")
+		require.NoError(t, err)
+		require.Equal(t, "es", language)
+	})
+
+	t.Run("achievements", func(t *testing.T) {
+		count, err := database.IncrementAchievementProgress(ctx, "duelist", "duel_won")
+		require.NoError(t, err)
+		require.Equal(t, 1, count)
+
+		unlocked, err := database.UnlockAchievement(ctx, "duelist", "first_blood")
+		require.NoError(t, err)
+		require.True(t, unlocked)
+		achievements, err := database.GetUserAchievements(ctx, "duelist")
+		require.NoError(t, err)
+		require.Len(t, achievements, 1)
+	})
+
+	t.Run("hltb cache", func(t *testing.T) {
+		require.NoError(t, database.SaveHLTBCache(ctx, "hollow knight", []byte(`[{"name":"Hollow Knight"}]`)))
+
+		results, err := database.GetHLTBCache(ctx, "hollow knight", time.Hour)
+		require.NoError(t, err)
+		require.JSONEq(t, `[{"name":"Hollow Knight"}]`, string(results))
+	})
+
+	t.Run("raffles, polls and raids", func(t *testing.T) {
+		raffleID, err := database.CreateRaffle(ctx, "!join", "none", "mod")
+		require.NoError(t, err)
+		require.NoError(t, database.AddRaffleEntry(ctx, raffleID, "lucky", 1))
+		require.NoError(t, database.FinishRaffle(ctx, raffleID, "lucky"))
+		raffle, err := database.GetLatestRaffle(ctx)
+		require.NoError(t, err)
+		require.True(t, raffle.EndedAt.Valid)
+		require.Equal(t, "lucky", raffle.Winner.String)
+
+		_, err = database.SavePollResult(ctx, PollResult{
+			Question: "Next game?", StartedBy: "mod", StartedAt: time.Now(), EndedAt: time.Now(),
+			Options: []string{"a", "b"}, Votes: []int{1, 2},
+		})
+		require.NoError(t, err)
+
+		_, err = database.SaveBossRaid(ctx, BossRaidResult{
+			BossName: "Dragon", MaxHP: 100, DamageDealt: 100, Defeated: true, StartedAt: time.Now(),
+			Participants: []BossRaidParticipant{{Username: "lucky", Damage: 100, Reward: 2}},
+		})
+		require.NoError(t, err)
+	})
+
+	t.Run("timers", func(t *testing.T) {
+		timer, err := database.SaveTimer(ctx, "discord", "Join the discord!", 10*time.Minute, 5)
+		require.NoError(t, err)
+		require.NoError(t, database.MarkTimerPosted(ctx, timer.ID, time.Now()))
+
+		timers, err := database.GetTimers(ctx)
+		require.NoError(t, err)
+		require.Len(t, timers, 1)
+		require.True(t, timers[0].LastPostedAt.Valid)
+	})
+}
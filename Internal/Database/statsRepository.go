@@ -5,8 +5,11 @@ import (
 	"database/sql"
 	"errors"
 	"fmt"
+	"time"
 )
 
+var ErrRespecOnCooldown = errors.New("respec is on cooldown")
+
 type Stats struct {
 	ID           int
 	Name         string
@@ -25,58 +28,48 @@ type UserStats struct {
 }
 
 func (d *Database) getOrCreateUser(ctx context.Context, username string) (int, error) {
+	//One statement, so concurrent callers for a new user (e.g. the welcome and the chat XP) don't race on the insert
+	const upsertUserQuery = `
+		INSERT INTO users (username, created_at, updated_at)
+		VALUES ($1, NOW(), NOW())
+		ON CONFLICT (username) DO UPDATE SET updated_at = NOW()
+		RETURNING id
+	`
 	var userID int
 	err := d.WithTransaction(ctx, func(tx *sql.Tx) error {
-		const userIdQuery = `
-			SELECT id FROM users WHERE username = $1
-		`
-		err := tx.QueryRowContext(ctx, userIdQuery, username).Scan(&userID)
-		if err == nil {
-			return nil
-		}
-
-		if !errors.Is(err, sql.ErrNoRows) {
-			return fmt.Errorf("error checking user: %w", err)
-		}
-
-		const addUserQuery = `
-			INSERT INTO users (username, created_at, updated_at)
-			VALUES($1, NOW(), NOW())
-			RETURNING id
-		`
-		return tx.QueryRowContext(ctx, addUserQuery, username).Scan(&userID)
+		return tx.QueryRowContext(ctx, upsertUserQuery, username).Scan(&userID)
 	})
+	if err != nil {
+		return 0, fmt.Errorf("couldn't get or create user: %w", err)
+	}
 
-	return userID, err
+	return userID, nil
 }
 
+// GetOrCreateUserStats creates the user and the missing default stats if needed, all in one transaction.
 func (d *Database) GetOrCreateUserStats(ctx context.Context, username string) ([]UserStats, error) {
-	res, err := d.WithTransactionResult(ctx, func(tx *sql.Tx) (any, error) {
-		userID, err := d.getOrCreateUser(ctx, username)
+	var stats []UserStats
+	err := d.InTransaction(ctx, func(repo *Database) error {
+		userID, err := repo.getOrCreateUser(ctx, username)
 		if err != nil {
-			return nil, fmt.Errorf("user setup failed: %w", err)
+			return fmt.Errorf("user setup failed: %w", err)
 		}
 
-		if err := d.createDefaultStatsForUser(ctx, userID); err != nil {
-			return nil, fmt.Errorf("failed to ensure stats exist: %w", err)
+		if err := repo.createDefaultStatsForUser(ctx, userID); err != nil {
+			return fmt.Errorf("failed to ensure stats exist: %w", err)
 		}
 
-		return d.getExistingUserStats(ctx, tx, userID)
+		stats, err = getExistingUserStats(ctx, repo.db, userID)
+		return err
 	})
-
 	if err != nil {
 		return nil, err
 	}
 
-	stats, ok := res.([]UserStats)
-	if !ok {
-		return nil, fmt.Errorf("unexpected type returned from transaction")
-	}
-
 	return stats, nil
 }
 
-func (d *Database) getExistingUserStats(ctx context.Context, tx *sql.Tx, userID int) ([]UserStats, error) {
+func getExistingUserStats(ctx context.Context, q DBTX, userID int) ([]UserStats, error) {
 	const query = `
 			SELECT s.name, us.value, us.updated_at
 			FROM user_stats us
@@ -84,7 +77,7 @@ func (d *Database) getExistingUserStats(ctx context.Context, tx *sql.Tx, userID
 			WHERE us.user_id = $1
 	`
 
-	rows, err := tx.QueryContext(ctx, query, userID)
+	rows, err := q.QueryContext(ctx, query, userID)
 	if err != nil {
 		return nil, fmt.Errorf("failed to get user stats: %w", err)
 	}
@@ -111,7 +104,7 @@ func (d *Database) getExistingUserStats(ctx context.Context, tx *sql.Tx, userID
 
 func (d *Database) createDefaultStatsForUser(ctx context.Context, userID int) error {
 	return d.WithTransaction(ctx, func(tx *sql.Tx) error {
-		statTypes, err := d.getAllStatTypes(ctx, tx)
+		statTypes, err := getAllStatTypes(ctx, tx)
 		if err != nil {
 			return fmt.Errorf("failed to get stat types: %w", err)
 		}
@@ -184,9 +177,9 @@ func (d *Database) UpdateUserStats(ctx context.Context, userID int, statName str
 	return nil
 }
 
-func (d *Database) getAllStatTypes(ctx context.Context, tx *sql.Tx) ([]string, error) {
+func getAllStatTypes(ctx context.Context, q DBTX) ([]string, error) {
 	const query = `SELECT name FROM stat_types`
-	rows, err := tx.QueryContext(ctx, query)
+	rows, err := q.QueryContext(ctx, query)
 	if err != nil {
 		return nil, fmt.Errorf("failed to get stat type: %w", err)
 	}
@@ -298,27 +291,225 @@ func (d *Database) UpdateUserStat(ctx context.Context, username string, stat str
 	return statName, newStatValue, nil
 }
 
-//For Telegram
+// DecreaseUserStat lowers the stat by val (not below its min value) and refunds refundRatio of the removed points into free points.
+func (d *Database) DecreaseUserStat(ctx context.Context, username string, stat string, val int, refundRatio float64) (string, int, int, error) {
+	var statName string
+	var newStatValue, refunded int
 
-func (d *Database) GetTwitchUserStats(ctx context.Context, username string) ([]UserStats, error) {
-	res, err := d.WithTransactionResult(ctx, func(tx *sql.Tx) (any, error) {
-		userId, err := d.getUserIdByUsername(ctx, username)
+	err := d.WithTransaction(ctx, func(tx *sql.Tx) error {
+		var userID int
+		err := tx.QueryRowContext(ctx, "SELECT id from users WHERE username = $1", username).Scan(&userID)
+		if err != nil {
+			if err == sql.ErrNoRows {
+				return fmt.Errorf("user %s not found", username)
+			}
+			return fmt.Errorf("failed to get user: %w", err)
+		}
+
+		var statTypeID, minValue int
+		err = tx.QueryRowContext(ctx, `
+			SELECT id, name, min_value
+			FROM stat_types
+			WHERE name = $1`, stat).
+			Scan(&statTypeID, &statName, &minValue)
 		if err != nil {
-			return nil, fmt.Errorf("user lookup failed: %w", err)
+			if err == sql.ErrNoRows {
+				return fmt.Errorf("stat %s not found", stat)
+			}
+			return fmt.Errorf("failed to get stat type: %w", err)
+		}
+
+		var curStatValue int
+		err = tx.QueryRowContext(ctx, `
+			SELECT value FROM user_stats
+			WHERE user_id = $1 AND stat_type_id = $2`, userID, statTypeID).
+			Scan(&curStatValue)
+		if err != nil {
+			return fmt.Errorf("failed to get current stat value: %w", err)
 		}
-		if userId == 0 {
-			return nil, fmt.Errorf("user not found")
+
+		newStatValue = max(curStatValue-val, minValue)
+		pointsRemoved := curStatValue - newStatValue
+		if pointsRemoved == 0 {
+			return fmt.Errorf("stat %s is already at its minimum", statName)
 		}
-		return d.getExistingUserStats(ctx, tx, userId)
+
+		const updStatQuery = `
+			UPDATE user_stats SET value = $3, updated_at = NOW()
+			WHERE user_id = $1 AND stat_type_id = $2
+		`
+		if _, err := tx.ExecContext(ctx, updStatQuery, userID, statTypeID, newStatValue); err != nil {
+			return fmt.Errorf("failed to update stat: %w", err)
+		}
+
+		refunded = int(float64(pointsRemoved) * refundRatio)
+		return refundFreePointsTx(ctx, tx, userID, refunded)
 	})
 
 	if err != nil {
-		return nil, err
+		return "", 0, 0, fmt.Errorf("failed to decrease stat: %w", err)
+	}
+
+	return statName, newStatValue, refunded, nil
+}
+
+// SQLite needs AS for the alias of the updated table
+const resetStatsQuery = `
+	UPDATE user_stats AS us
+	SET value = st.default_value, updated_at = NOW()
+	FROM stat_types AS st
+	WHERE us.stat_type_id = st.id AND us.user_id = $1 AND st.name NOT IN ('free-points', 'total-free-points')
+`
+
+// RespecUserStats resets all stats of the user to their default values and refunds the spent points into free points.
+// If the last respec was less than cooldown ago, it returns ErrRespecOnCooldown and the remaining time.
+func (d *Database) RespecUserStats(ctx context.Context, username string, cooldown time.Duration) (int, time.Duration, error) {
+	var refunded int
+	var remaining time.Duration
+
+	err := d.WithTransaction(ctx, func(tx *sql.Tx) error {
+		var userID int
+		err := tx.QueryRowContext(ctx, "SELECT id from users WHERE username = $1", username).Scan(&userID)
+		if err != nil {
+			if err == sql.ErrNoRows {
+				return fmt.Errorf("user %s not found", username)
+			}
+			return fmt.Errorf("failed to get user: %w", err)
+		}
+
+		var lastRespec time.Time
+		err = tx.QueryRowContext(ctx, `SELECT last_respec_at FROM user_respecs WHERE user_id = $1`, userID).Scan(&lastRespec)
+		if err != nil && !errors.Is(err, sql.ErrNoRows) {
+			return fmt.Errorf("failed to get last respec: %w", err)
+		}
+		if err == nil && time.Since(lastRespec) < cooldown {
+			remaining = cooldown - time.Since(lastRespec)
+			return ErrRespecOnCooldown
+		}
+
+		const spentPointsQuery = `
+			SELECT COALESCE(SUM(us.value - st.default_value), 0)
+			FROM user_stats us
+			JOIN stat_types st ON us.stat_type_id = st.id
+			WHERE us.user_id = $1 AND st.name NOT IN ('free-points', 'total-free-points') AND us.value > st.default_value
+		`
+		if err := tx.QueryRowContext(ctx, spentPointsQuery, userID).Scan(&refunded); err != nil {
+			return fmt.Errorf("failed to count spent points: %w", err)
+		}
+
+		if _, err := tx.ExecContext(ctx, resetStatsQuery, userID); err != nil {
+			return fmt.Errorf("failed to reset stats: %w", err)
+		}
+
+		if err := refundFreePointsTx(ctx, tx, userID, refunded); err != nil {
+			return err
+		}
+
+		const saveRespecQuery = `
+			INSERT INTO user_respecs (user_id, last_respec_at)
+			VALUES ($1, NOW())
+			ON CONFLICT (user_id) DO UPDATE SET last_respec_at = EXCLUDED.last_respec_at
+		`
+		if _, err := tx.ExecContext(ctx, saveRespecQuery, userID); err != nil {
+			return fmt.Errorf("failed to save respec time: %w", err)
+		}
+		return nil
+	})
+
+	if errors.Is(err, ErrRespecOnCooldown) {
+		return 0, remaining, err
+	}
+	if err != nil {
+		return 0, 0, fmt.Errorf("failed to respec stats: %w", err)
+	}
+
+	return refunded, 0, nil
+}
+
+const refundQuery = `
+	UPDATE user_stats AS us
+	SET value = LEAST(us.value + $2, st.max_value), updated_at = NOW()
+	FROM stat_types AS st
+	WHERE us.stat_type_id = st.id AND us.user_id = $1 AND st.name = 'free-points'
+`
+
+// refundFreePointsTx returns points into free-points only, total-free-points counts earned points and stays the same.
+func refundFreePointsTx(ctx context.Context, tx *sql.Tx, userID int, points int) error {
+	if points <= 0 {
+		return nil
+	}
+
+	result, err := tx.ExecContext(ctx, refundQuery, userID, points)
+	if err != nil {
+		return fmt.Errorf("failed to refund free points: %w", err)
+	}
+	//Without the free-points row the points would be lost, rolling back keeps the stats as they were
+	if rows, err := result.RowsAffected(); err != nil || rows == 0 {
+		return fmt.Errorf("failed to refund free points: user %d has no free-points stat", userID)
+	}
+	return nil
+}
+
+// AddFreePoints grants free points to the user (they are also counted in total-free-points), both are clamped by the stat max value.
+func (d *Database) AddFreePoints(ctx context.Context, username string, points int) (int, error) {
+	var freePoints int
+	err := d.WithTransaction(ctx, func(tx *sql.Tx) error {
+		if _, err := d.scoped(tx).GetOrCreateUserStats(ctx, username); err != nil {
+			return fmt.Errorf("failed to ensure stats exist: %w", err)
+		}
+
+		var err error
+		freePoints, err = addFreePointsTx(ctx, tx, username, points)
+		return err
+	})
+	if err != nil {
+		return 0, fmt.Errorf("failed to add free points: %w", err)
+	}
+
+	return freePoints, nil
+}
+
+// SQLite can't qualify the RETURNING columns, Postgres accepts them unqualified too
+const addPointsQuery = `
+	UPDATE user_stats AS us
+	SET value = LEAST(us.value + $2, st.max_value), updated_at = NOW()
+	FROM stat_types AS st, users AS u
+	WHERE us.stat_type_id = st.id AND us.user_id = u.id
+		AND u.username = $1 AND st.name = $3
+	RETURNING value
+`
+
+func addFreePointsTx(ctx context.Context, tx *sql.Tx, username string, points int) (int, error) {
+	var freePoints, totalFreePoints int
+	if err := tx.QueryRowContext(ctx, addPointsQuery, username, points, "free-points").Scan(&freePoints); err != nil {
+		return 0, fmt.Errorf("couldn't update free points: %w", err)
 	}
 
-	stats, ok := res.([]UserStats)
-	if !ok {
-		return nil, fmt.Errorf("unexpected type returned from transaction")
+	if err := tx.QueryRowContext(ctx, addPointsQuery, username, points, "total-free-points").Scan(&totalFreePoints); err != nil {
+		return 0, fmt.Errorf("couldn't update total free points: %w", err)
+	}
+
+	return freePoints, nil
+}
+
+//For Telegram
+
+func (d *Database) GetTwitchUserStats(ctx context.Context, username string) ([]UserStats, error) {
+	var stats []UserStats
+	err := d.InTransaction(ctx, func(repo *Database) error {
+		userId, err := repo.getUserIdByUsername(ctx, username)
+		if errors.Is(err, sql.ErrNoRows) {
+			return fmt.Errorf("user lookup failed: %w", ErrUserNotFound)
+		}
+		if err != nil {
+			return fmt.Errorf("user lookup failed: %w", err)
+		}
+
+		stats, err = getExistingUserStats(ctx, repo.db, userId)
+		return err
+	})
+	if err != nil {
+		return nil, err
 	}
 
 	return stats, nil
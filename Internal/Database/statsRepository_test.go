@@ -12,16 +12,16 @@ import (
 )
 
 func TestGetOrCreateUser(t *testing.T) {
-	t.Run("existing user", func(t *testing.T) {
+	t.Run("upsert", func(t *testing.T) {
 		db, mock := SetupMockDB(t)
 		defer db.Close()
 
 		ctx := context.Background()
-		username := "existinguser"
-		userID := 1
+		username := "newuser"
+		userID := 2
 
 		mock.ExpectBegin()
-		mock.ExpectQuery("SELECT id FROM users WHERE username = \\$1").
+		mock.ExpectQuery("INSERT INTO users .* ON CONFLICT \\(username\\) DO UPDATE SET updated_at = NOW\\(\\) RETURNING id").
 			WithArgs(username).
 			WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow(userID))
 		mock.ExpectCommit()
@@ -32,26 +32,18 @@ func TestGetOrCreateUser(t *testing.T) {
 		require.NoError(t, mock.ExpectationsWereMet())
 	})
 
-	t.Run("new user", func(t *testing.T) {
+	t.Run("error", func(t *testing.T) {
 		db, mock := SetupMockDB(t)
 		defer db.Close()
 
-		ctx := context.Background()
-		username := "newuser"
-		userID := 2
-
 		mock.ExpectBegin()
-		mock.ExpectQuery("SELECT id FROM users WHERE username = \\$1").
-			WithArgs(username).
-			WillReturnError(sql.ErrNoRows)
-		mock.ExpectQuery("INSERT INTO users .* RETURNING id").
-			WithArgs(username).
-			WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow(userID))
-		mock.ExpectCommit()
+		mock.ExpectQuery("INSERT INTO users").
+			WithArgs("newuser").
+			WillReturnError(errors.New("db error"))
+		mock.ExpectRollback()
 
-		id, err := db.getOrCreateUser(ctx, username)
-		require.NoError(t, err)
-		require.Equal(t, userID, id)
+		_, err := db.getOrCreateUser(context.Background(), "newuser")
+		require.ErrorContains(t, err, "couldn't get or create user")
 		require.NoError(t, mock.ExpectationsWereMet())
 	})
 }
@@ -68,10 +60,6 @@ func TestGetOrCreateUserStats(t *testing.T) {
 
 		mock.ExpectBegin()
 
-		mock.ExpectQuery("SELECT id FROM users WHERE username = \\$1").
-			WithArgs(username).
-			WillReturnError(sql.ErrNoRows)
-
 		mock.ExpectQuery("INSERT INTO users .* RETURNING id").
 			WithArgs(username).
 			WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow(userID))
@@ -126,7 +114,7 @@ func TestGetOrCreateUserStats(t *testing.T) {
 
 		mock.ExpectBegin()
 
-		mock.ExpectQuery("SELECT id FROM users WHERE username = \\$1").
+		mock.ExpectQuery("INSERT INTO users .* RETURNING id").
 			WithArgs(username).
 			WillReturnError(errors.New("db error"))
 
@@ -138,3 +126,96 @@ func TestGetOrCreateUserStats(t *testing.T) {
 		require.NoError(t, mock.ExpectationsWereMet())
 	})
 }
+
+func TestRespecUserStats(t *testing.T) {
+	t.Run("success", func(t *testing.T) {
+		db, mock, err := sqlmock.New()
+		require.NoError(t, err)
+		defer db.Close()
+
+		ctx := context.Background()
+		userID := 1
+
+		mock.ExpectBegin()
+		mock.ExpectQuery("SELECT id from users WHERE username = \\$1").
+			WithArgs("testuser").
+			WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow(userID))
+		mock.ExpectQuery("SELECT last_respec_at FROM user_respecs WHERE user_id = \\$1").
+			WithArgs(userID).
+			WillReturnError(sql.ErrNoRows)
+		mock.ExpectQuery("SELECT COALESCE\\(SUM\\(us.value - st.default_value\\), 0\\)").
+			WithArgs(userID).
+			WillReturnRows(sqlmock.NewRows([]string{"sum"}).AddRow(6))
+		mock.ExpectExec("UPDATE user_stats AS us SET value = st.default_value").
+			WithArgs(userID).
+			WillReturnResult(sqlmock.NewResult(0, 7))
+		mock.ExpectExec("UPDATE user_stats AS us SET value = LEAST\\(us.value \\+ \\$2, st.max_value\\)").
+			WithArgs(userID, 6).
+			WillReturnResult(sqlmock.NewResult(0, 1))
+		mock.ExpectExec("INSERT INTO user_respecs").
+			WithArgs(userID).
+			WillReturnResult(sqlmock.NewResult(0, 1))
+		mock.ExpectCommit()
+
+		database := &Database{db: db}
+		refunded, _, err := database.RespecUserStats(ctx, "testuser", time.Hour)
+
+		require.NoError(t, err)
+		require.Equal(t, 6, refunded)
+		require.NoError(t, mock.ExpectationsWereMet())
+	})
+
+	t.Run("no free-points row", func(t *testing.T) {
+		db, mock, err := sqlmock.New()
+		require.NoError(t, err)
+		defer db.Close()
+
+		mock.ExpectBegin()
+		mock.ExpectQuery("SELECT id from users WHERE username = \\$1").
+			WithArgs("testuser").
+			WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow(1))
+		mock.ExpectQuery("SELECT last_respec_at FROM user_respecs WHERE user_id = \\$1").
+			WithArgs(1).
+			WillReturnError(sql.ErrNoRows)
+		mock.ExpectQuery("SELECT COALESCE\\(SUM\\(us.value - st.default_value\\), 0\\)").
+			WithArgs(1).
+			WillReturnRows(sqlmock.NewRows([]string{"sum"}).AddRow(6))
+		mock.ExpectExec("UPDATE user_stats AS us SET value = st.default_value").
+			WithArgs(1).
+			WillReturnResult(sqlmock.NewResult(0, 7))
+		mock.ExpectExec("UPDATE user_stats AS us SET value = LEAST\\(us.value \\+ \\$2, st.max_value\\)").
+			WithArgs(1, 6).
+			WillReturnResult(sqlmock.NewResult(0, 0))
+		mock.ExpectRollback()
+
+		database := &Database{db: db}
+		_, _, err = database.RespecUserStats(context.Background(), "testuser", time.Hour)
+
+		require.ErrorContains(t, err, "has no free-points stat")
+		require.NoError(t, mock.ExpectationsWereMet())
+	})
+
+	t.Run("on cooldown", func(t *testing.T) {
+		db, mock, err := sqlmock.New()
+		require.NoError(t, err)
+		defer db.Close()
+
+		ctx := context.Background()
+
+		mock.ExpectBegin()
+		mock.ExpectQuery("SELECT id from users WHERE username = \\$1").
+			WithArgs("testuser").
+			WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow(1))
+		mock.ExpectQuery("SELECT last_respec_at FROM user_respecs WHERE user_id = \\$1").
+			WithArgs(1).
+			WillReturnRows(sqlmock.NewRows([]string{"last_respec_at"}).AddRow(time.Now().Add(-30 * time.Minute)))
+		mock.ExpectRollback()
+
+		database := &Database{db: db}
+		_, remaining, err := database.RespecUserStats(ctx, "testuser", time.Hour)
+
+		require.ErrorIs(t, err, ErrRespecOnCooldown)
+		require.InDelta(t, float64(30*time.Minute), float64(remaining), float64(time.Minute))
+		require.NoError(t, mock.ExpectationsWereMet())
+	})
+}
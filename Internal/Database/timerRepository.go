@@ -0,0 +1,110 @@
+package database
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"time"
+)
+
+type Timer struct {
+	ID           int
+	Name         string
+	Message      string
+	Interval     time.Duration
+	MinLines     int
+	Enabled      bool
+	LastPostedAt sql.NullTime
+}
+
+func (d *Database) SaveTimer(ctx context.Context, name string, message string, interval time.Duration, minLines int) (*Timer, error) {
+	timer := Timer{Enabled: true}
+	err := d.WithTransaction(ctx, func(tx *sql.Tx) error {
+		const query = `
+			INSERT INTO timers (name, message, interval_seconds, min_lines)
+			VALUES ($1, $2, $3, $4)
+			ON CONFLICT (name) DO UPDATE
+			SET message = EXCLUDED.message, interval_seconds = EXCLUDED.interval_seconds, min_lines = EXCLUDED.min_lines, enabled = TRUE
+			RETURNING id, name, message, interval_seconds, min_lines, last_posted_at
+		`
+
+		var intervalSeconds int
+		err := tx.QueryRowContext(ctx, query, name, message, int(interval.Seconds()), minLines).Scan(
+			&timer.ID,
+			&timer.Name,
+			&timer.Message,
+			&intervalSeconds,
+			&timer.MinLines,
+			&timer.LastPostedAt,
+		)
+		timer.Interval = time.Duration(intervalSeconds) * time.Second
+		return err
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to save timer: %w", err)
+	}
+
+	return &timer, nil
+}
+
+func (d *Database) RemoveTimer(ctx context.Context, name string) (bool, error) {
+	var removed bool
+	err := d.WithTransaction(ctx, func(tx *sql.Tx) error {
+		res, err := tx.ExecContext(ctx, `DELETE FROM timers WHERE name = $1`, name)
+		if err != nil {
+			return err
+		}
+
+		affected, err := res.RowsAffected()
+		removed = affected > 0
+		return err
+	})
+	if err != nil {
+		return false, fmt.Errorf("failed to remove timer: %w", err)
+	}
+
+	return removed, nil
+}
+
+func (d *Database) GetTimers(ctx context.Context) ([]Timer, error) {
+	var timers []Timer
+	err := d.WithTransaction(ctx, func(tx *sql.Tx) error {
+		const query = `
+			SELECT id, name, message, interval_seconds, min_lines, enabled, last_posted_at
+			FROM timers
+			ORDER BY name
+		`
+		rows, err := tx.QueryContext(ctx, query)
+		if err != nil {
+			return err
+		}
+		defer rows.Close()
+
+		for rows.Next() {
+			var timer Timer
+			var intervalSeconds int
+			if err := rows.Scan(&timer.ID, &timer.Name, &timer.Message, &intervalSeconds, &timer.MinLines, &timer.Enabled, &timer.LastPostedAt); err != nil {
+				return fmt.Errorf("failed to scan timer row: %w", err)
+			}
+			timer.Interval = time.Duration(intervalSeconds) * time.Second
+			timers = append(timers, timer)
+		}
+
+		return rows.Err()
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to get timers: %w", err)
+	}
+
+	return timers, nil
+}
+
+func (d *Database) MarkTimerPosted(ctx context.Context, timerID int, postedAt time.Time) error {
+	return d.WithTransaction(ctx, func(tx *sql.Tx) error {
+		_, err := tx.ExecContext(ctx, `UPDATE timers SET last_posted_at = $1 WHERE id = $2`, postedAt, timerID)
+		if err != nil {
+			return fmt.Errorf("failed to update timer: %w", err)
+		}
+		return nil
+	})
+}
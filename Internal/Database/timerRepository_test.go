@@ -0,0 +1,96 @@
+package database
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSaveTimer(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	require.NoError(t, err)
+	defer db.Close()
+
+	mock.ExpectBegin()
+	mock.ExpectQuery("INSERT INTO timers .* ON CONFLICT \\(name\\) DO UPDATE .* RETURNING .*").
+		WithArgs("discord", "Follow us on Discord", 900, 5).
+		WillReturnRows(sqlmock.NewRows([]string{"id", "name", "message", "interval_seconds", "min_lines", "last_posted_at"}).
+			AddRow(1, "discord", "Follow us on Discord", 900, 5, nil))
+	mock.ExpectCommit()
+
+	database := &Database{db: db}
+	timer, err := database.SaveTimer(context.Background(), "discord", "Follow us on Discord", 15*time.Minute, 5)
+
+	require.NoError(t, err)
+	require.Equal(t, 15*time.Minute, timer.Interval)
+	require.Equal(t, 5, timer.MinLines)
+	require.True(t, timer.Enabled)
+	require.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestRemoveTimer(t *testing.T) {
+	t.Run("existing timer", func(t *testing.T) {
+		db, mock, err := sqlmock.New()
+		require.NoError(t, err)
+		defer db.Close()
+
+		mock.ExpectBegin()
+		mock.ExpectExec("DELETE FROM timers WHERE name = \\$1").
+			WithArgs("discord").
+			WillReturnResult(sqlmock.NewResult(0, 1))
+		mock.ExpectCommit()
+
+		database := &Database{db: db}
+		removed, err := database.RemoveTimer(context.Background(), "discord")
+
+		require.NoError(t, err)
+		require.True(t, removed)
+		require.NoError(t, mock.ExpectationsWereMet())
+	})
+
+	t.Run("db error", func(t *testing.T) {
+		db, mock, err := sqlmock.New()
+		require.NoError(t, err)
+		defer db.Close()
+
+		mock.ExpectBegin()
+		mock.ExpectExec("DELETE FROM timers WHERE name = \\$1").
+			WithArgs("discord").
+			WillReturnError(errors.New("database error"))
+		mock.ExpectRollback()
+
+		database := &Database{db: db}
+		_, err = database.RemoveTimer(context.Background(), "discord")
+
+		require.EqualError(t, err, "failed to remove timer: database error")
+		require.NoError(t, mock.ExpectationsWereMet())
+	})
+}
+
+func TestGetTimers(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	require.NoError(t, err)
+	defer db.Close()
+
+	now := time.Now()
+	mock.ExpectBegin()
+	mock.ExpectQuery("SELECT id, name, message, interval_seconds, min_lines, enabled, last_posted_at FROM timers").
+		WillReturnRows(sqlmock.NewRows([]string{"id", "name", "message", "interval_seconds", "min_lines", "enabled", "last_posted_at"}).
+			AddRow(1, "discord", "Follow us on Discord", 900, 5, true, now).
+			AddRow(2, "rules", "Be nice", 1800, 0, false, nil))
+	mock.ExpectCommit()
+
+	database := &Database{db: db}
+	timers, err := database.GetTimers(context.Background())
+
+	require.NoError(t, err)
+	require.Len(t, timers, 2)
+	require.True(t, timers[0].LastPostedAt.Valid)
+	require.Equal(t, 30*time.Minute, timers[1].Interval)
+	require.False(t, timers[1].LastPostedAt.Valid)
+	require.NoError(t, mock.ExpectationsWereMet())
+}
@@ -6,8 +6,21 @@ import (
 	"fmt"
 )
 
+// WithTransaction runs fn in a new transaction. On a tx-scoped Database (see InTransaction) fn joins
+// the ambient transaction instead, so an error anywhere rolls back the whole outer operation.
 func (d *Database) WithTransaction(ctx context.Context, fn func(tx *sql.Tx) error) error {
-	tx, err := d.db.(*sql.DB).BeginTx(ctx, nil)
+	switch db := d.db.(type) {
+	case *sql.Tx:
+		return fn(db)
+	case *sql.DB:
+		return runInNewTransaction(ctx, db, fn)
+	default:
+		return fmt.Errorf("failed to begin transaction: unsupported connection %T", d.db)
+	}
+}
+
+func runInNewTransaction(ctx context.Context, db *sql.DB, fn func(tx *sql.Tx) error) error {
+	tx, err := db.BeginTx(ctx, nil)
 	if err != nil {
 		return fmt.Errorf("failed to begin transaction: %w", err)
 	}
@@ -28,25 +41,15 @@ func (d *Database) WithTransaction(ctx context.Context, fn func(tx *sql.Tx) erro
 	return tx.Commit()
 }
 
-func (d *Database) WithTransactionResult(ctx context.Context, fn func(tx *sql.Tx) (any, error)) (any, error) {
-	tx, err := d.db.(*sql.DB).BeginTx(ctx, nil)
-	if err != nil {
-		return nil, fmt.Errorf("failed to begin transaction: %w", err)
-	}
-
-	defer func() {
-		if p := recover(); p != nil {
-			tx.Rollback()
-			panic(p)
-		}
-	}()
+// InTransaction runs fn with a Database scoped to one transaction, so several repository calls
+// made on repo are atomic. Nested InTransaction and WithTransaction calls on repo join it.
+func (d *Database) InTransaction(ctx context.Context, fn func(repo *Database) error) error {
+	return d.WithTransaction(ctx, func(tx *sql.Tx) error {
+		return fn(d.scoped(tx))
+	})
+}
 
-	result, err := fn(tx)
-	if err != nil {
-		if rbErr := tx.Rollback(); rbErr != nil {
-			return nil, fmt.Errorf("tx err: %v, rb err: %w", err, rbErr)
-		}
-		return nil, err
-	}
-	return result, tx.Commit()
+// scoped returns the Database scoped to tx, repository calls on it join the transaction.
+func (d *Database) scoped(tx *sql.Tx) *Database {
+	return &Database{db: tx, dialect: d.dialect}
 }
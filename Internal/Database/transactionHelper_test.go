@@ -0,0 +1,37 @@
+package database
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/stretchr/testify/require"
+)
+
+func TestInTransactionJoinsNestedCalls(t *testing.T) {
+	db, mock := SetupMockDB(t)
+	defer db.Close()
+
+	//One Begin for the whole operation, the failure rolls back the already created user
+	mock.ExpectBegin()
+	mock.ExpectQuery("INSERT INTO users \\(username\\)").
+		WithArgs("testuser").
+		WillReturnRows(sqlmock.NewRows([]string{"id", "username", "created_at", "updated_at"}).
+			AddRow(1, "testuser", time.Now(), time.Now()))
+	mock.ExpectRollback()
+
+	errFailed := errors.New("second step failed")
+	err := db.InTransaction(context.Background(), func(repo *Database) error {
+		if _, err := repo.CreateUser(context.Background(), "testuser"); err != nil {
+			return err
+		}
+		return repo.InTransaction(context.Background(), func(repo *Database) error {
+			return errFailed
+		})
+	})
+
+	require.ErrorIs(t, err, errFailed)
+	require.NoError(t, mock.ExpectationsWereMet())
+}
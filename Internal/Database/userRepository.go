@@ -3,6 +3,7 @@ package database
 import (
 	"context"
 	"database/sql"
+	"errors"
 	"fmt"
 	"time"
 )
@@ -55,6 +56,9 @@ func (d *Database) GetUser(ctx context.Context, username string) (*User, error)
 		)
 	})
 
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, fmt.Errorf("failed to get user %s: %w", username, ErrUserNotFound)
+	}
 	if err != nil {
 		return nil, fmt.Errorf("failed to get user: %w", err)
 	}
@@ -0,0 +1,117 @@
+package hltb
+
+import (
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+
+	"github.com/Gladarfin/GetInfoFromHLTB/models"
+	"github.com/Gladarfin/GetInfoFromHLTB/utils"
+)
+
+const gameURL = "https://howlongtobeat.com/game/%d"
+
+var pickPattern = regexp.MustCompile(`^(.*?)\s*#(\d+)$`)
+
+// ParseQuery splits "<title> #N" into the title and the number of the match (1-based), without "#N" it's the first match.
+func ParseQuery(args string) (string, int) {
+	args = strings.TrimSpace(args)
+	match := pickPattern.FindStringSubmatch(args)
+	if match == nil {
+		return args, 1
+	}
+
+	pick, err := strconv.Atoi(match[2])
+	if err != nil {
+		return args, 1
+	}
+	return match[1], pick
+}
+
+// Pick returns the N-th match (1-based).
+func Pick(games []models.GameData, pick int) (models.GameData, error) {
+	if pick < 1 || pick > len(games) {
+		return models.GameData{}, fmt.Errorf("there are only %d match(es), pick #1-#%d", len(games), len(games))
+	}
+	return games[pick-1], nil
+}
+
+type completionTime struct {
+	name    string
+	seconds int
+}
+
+func completionTimes(game models.GameData) []completionTime {
+	return []completionTime{
+		{"Main story", game.CompMain},
+		{"Main + Extra", game.CompPlus},
+		{"Completionist", game.Comp100},
+		{"All styles", game.CompAll},
+	}
+}
+
+func gameTitle(game models.GameData) string {
+	if game.ReleaseWorld > 0 {
+		return fmt.Sprintf("%s (%d)", game.GameName, game.ReleaseWorld)
+	}
+	return game.GameName
+}
+
+// FormatLine is the one-line layout for chat: "Celeste (2018) | Main story: 8.1 h | ... | Rating: 92/100 | Other matches: #2 ...".
+func FormatLine(games []models.GameData, pick int) string {
+	game := games[pick-1]
+	parts := []string{gameTitle(game)}
+	for _, completion := range completionTimes(game) {
+		if completion.seconds > 0 {
+			parts = append(parts, fmt.Sprintf("%s: %s", completion.name, utils.FormatHours(utils.SecondsToHours(completion.seconds))))
+		}
+	}
+	if len(parts) == 1 {
+		parts = append(parts, "no completion times yet")
+	}
+	if game.ReviewScore > 0 {
+		parts = append(parts, fmt.Sprintf("Rating: %d/100", game.ReviewScore))
+	}
+	if others := otherMatches(games, pick); others != "" {
+		parts = append(parts, "Other matches: "+others)
+	}
+	return strings.Join(parts, " | ")
+}
+
+// FormatCard is the multi-line layout for Telegram.
+func FormatCard(games []models.GameData, pick int) string {
+	game := games[pick-1]
+
+	var card strings.Builder
+	card.WriteString(fmt.Sprintf("🎮 %s\n", gameTitle(game)))
+	hasTimes := false
+	for _, completion := range completionTimes(game) {
+		if completion.seconds > 0 {
+			card.WriteString(fmt.Sprintf("⏱ %s: %s\n", completion.name, utils.FormatHours(utils.SecondsToHours(completion.seconds))))
+			hasTimes = true
+		}
+	}
+	if !hasTimes {
+		card.WriteString("⏱ No completion times yet\n")
+	}
+	if game.ReviewScore > 0 {
+		card.WriteString(fmt.Sprintf("⭐ Rating: %d/100\n", game.ReviewScore))
+	}
+	card.WriteString(fmt.Sprintf("🔗 "+gameURL+"\n", game.GameID))
+
+	if others := otherMatches(games, pick); others != "" {
+		card.WriteString(fmt.Sprintf("\nMatch %d of %d. Other matches: %s", pick, len(games), others))
+	}
+	return card.String()
+}
+
+func otherMatches(games []models.GameData, pick int) string {
+	var others []string
+	for i, game := range games {
+		if i+1 != pick {
+			others = append(others, fmt.Sprintf("#%d %s", i+1, game.GameName))
+		}
+	}
+	return strings.Join(others, ", ")
+}
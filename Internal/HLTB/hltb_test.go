@@ -0,0 +1,114 @@
+package hltb
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/Gladarfin/GetInfoFromHLTB/models"
+	"github.com/stretchr/testify/require"
+)
+
+type fakeCache struct {
+	entries map[string][]byte
+}
+
+func (c *fakeCache) GetHLTBCache(ctx context.Context, query string, maxAge time.Duration) ([]byte, error) {
+	return c.entries[query], nil
+}
+
+func (c *fakeCache) SaveHLTBCache(ctx context.Context, query string, results []byte) error {
+	c.entries[query] = results
+	return nil
+}
+
+type fakeClient struct {
+	searches int
+	games    []models.GameData
+	err      error
+}
+
+func (c *fakeClient) Search(gameName string, options models.SearchOptions) (*models.SearchResponse, error) {
+	c.searches++
+	if c.err != nil {
+		return nil, c.err
+	}
+	return &models.SearchResponse{Data: c.games}, nil
+}
+
+func newTestService(cache Cache, clients ...*fakeClient) *Service {
+	next := 0
+	newClient := func() searcher {
+		next++
+		return clients[next-1]
+	}
+	return &Service{client: newClient(), newClient: newClient, cache: cache, ttl: time.Hour, maxResults: 5}
+}
+
+func TestSearchUsesCache(t *testing.T) {
+	cache := &fakeCache{entries: map[string][]byte{}}
+	hltbClient := &fakeClient{games: []models.GameData{{GameID: 42818, GameName: "Celeste"}}}
+	service := newTestService(cache, hltbClient)
+
+	games, err := service.Search(context.Background(), "Celeste")
+	require.NoError(t, err)
+	require.Len(t, games, 1)
+
+	//Same title with other spacing and case hits the cache
+	games, err = service.Search(context.Background(), "  celeste ")
+	require.NoError(t, err)
+	require.Equal(t, "Celeste", games[0].GameName)
+	require.Equal(t, 1, hltbClient.searches)
+}
+
+func TestSearchReplacesClientAfterError(t *testing.T) {
+	cache := &fakeCache{entries: map[string][]byte{}}
+	broken := &fakeClient{err: errors.New("token expired")}
+	fresh := &fakeClient{}
+	service := newTestService(cache, broken, fresh)
+
+	_, err := service.Search(context.Background(), "Celeste")
+	require.Error(t, err)
+	require.Empty(t, cache.entries)
+
+	games, err := service.Search(context.Background(), "Celeste")
+	require.NoError(t, err)
+	require.Empty(t, games)
+	require.Equal(t, 1, fresh.searches)
+	require.Equal(t, []byte("[]"), cache.entries["celeste"])
+}
+
+func TestParseQuery(t *testing.T) {
+	for _, tc := range []struct {
+		args  string
+		title string
+		pick  int
+	}{
+		{"Celeste", "Celeste", 1},
+		{"Dark Souls #2", "Dark Souls", 2},
+		{"Persona 5#3", "Persona 5", 3},
+		{"  Hades  ", "Hades", 1},
+		{"#2", "", 2},
+	} {
+		title, pick := ParseQuery(tc.args)
+		require.Equal(t, tc.title, title, tc.args)
+		require.Equal(t, tc.pick, pick, tc.args)
+	}
+}
+
+func TestFormat(t *testing.T) {
+	games := []models.GameData{
+		{GameID: 1, GameName: "Dark Souls", ReleaseWorld: 2011, CompMain: 153000, Comp100: 360000, ReviewScore: 90},
+		{GameID: 2, GameName: "Dark Souls II", ReleaseWorld: 2014},
+	}
+
+	require.Equal(t, "Dark Souls (2011) | Main story: 42.5 h | Completionist: 100.0 h | Rating: 90/100 | Other matches: #2 Dark Souls II",
+		FormatLine(games, 1))
+	require.Equal(t, "Dark Souls II (2014) | no completion times yet | Other matches: #1 Dark Souls", FormatLine(games, 2))
+	require.Equal(t, "🎮 Dark Souls II (2014)\n⏱ No completion times yet\n🔗 https://howlongtobeat.com/game/2\n\nMatch 2 of 2. Other matches: #1 Dark Souls",
+		FormatCard(games, 2))
+
+	_, err := Pick(games, 3)
+	require.EqualError(t, err, "there are only 2 match(es), pick #1-#2")
+}
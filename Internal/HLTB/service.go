@@ -0,0 +1,102 @@
+package hltb
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/Gladarfin/GetInfoFromHLTB/client"
+	"github.com/Gladarfin/GetInfoFromHLTB/models"
+)
+
+// Cache keeps search results between restarts, *database.Database implements it.
+type Cache interface {
+	// GetHLTBCache returns nil if there are no results younger than maxAge.
+	GetHLTBCache(ctx context.Context, query string, maxAge time.Duration) ([]byte, error)
+	SaveHLTBCache(ctx context.Context, query string, results []byte) error
+}
+
+type searcher interface {
+	Search(gameName string, options models.SearchOptions) (*models.SearchResponse, error)
+}
+
+// Service searches HowLongToBeat through one shared client and caches the results.
+type Service struct {
+	mutex sync.Mutex
+	//The client keeps the auth token, so it's reused and only one search runs at a time
+	client     searcher
+	newClient  func() searcher
+	cache      Cache
+	ttl        time.Duration
+	maxResults int
+}
+
+func NewService(cache Cache, ttl time.Duration, maxResults int) *Service {
+	newClient := func() searcher { return client.New() }
+	return &Service{
+		client:     newClient(),
+		newClient:  newClient,
+		cache:      cache,
+		ttl:        ttl,
+		maxResults: maxResults,
+	}
+}
+
+// Search returns the top matches for the title (DLCs and mods are filtered out), from the cache if they are fresh enough.
+func (s *Service) Search(ctx context.Context, title string) ([]models.GameData, error) {
+	query := normalizeQuery(title)
+	if query == "" {
+		return nil, errors.New("game title is empty")
+	}
+
+	cached, err := s.cache.GetHLTBCache(ctx, query, s.ttl)
+	if err != nil {
+		log.Printf("[%s] ⚠️ HLTB cache lookup failed: %v", time.Now().Format("15:04:05"), err)
+	}
+	if cached != nil {
+		var games []models.GameData
+		if err := json.Unmarshal(cached, &games); err == nil {
+			return games, nil
+		}
+		log.Printf("[%s] ⚠️ Broken HLTB cache entry for '%s': %v", time.Now().Format("15:04:05"), query, err)
+	}
+
+	s.mutex.Lock()
+	response, err := s.client.Search(title, models.SearchOptions{
+		FilterDLC:  true,
+		FilterMods: true,
+		MaxResults: s.maxResults,
+	})
+	if err != nil {
+		//The auth token might have expired, the next search starts with a fresh client
+		s.client = s.newClient()
+	}
+	s.mutex.Unlock()
+	if err != nil {
+		return nil, fmt.Errorf("HLTB search failed: %w", err)
+	}
+
+	//Empty results are cached too, so unknown titles don't hit the site every time
+	games := response.Data
+	if games == nil {
+		games = []models.GameData{}
+	}
+	data, err := json.Marshal(games)
+	if err == nil {
+		err = s.cache.SaveHLTBCache(ctx, query, data)
+	}
+	if err != nil {
+		log.Printf("[%s] ⚠️ Couldn't cache HLTB results for '%s': %v", time.Now().Format("15:04:05"), query, err)
+	}
+
+	return games, nil
+}
+
+func normalizeQuery(title string) string {
+	return strings.ToLower(strings.Join(strings.Fields(title), " "))
+}
@@ -1,7 +1,12 @@
 package botInterfaces
 
+import "github.com/Gladarfin/GetInfoFromHLTB/models"
+
 type TwitchBotInterface interface {
 	GetStreamUptime() (string, error)
+	HandleTimerCommand(args []string) (string, error)
+	FindHowLongToBeat(args string) ([]models.GameData, int, error)
+	ReloadConfigFiles() string
 }
 
 type TelegramNotifierInterface interface {
@@ -6,7 +6,14 @@ func GetBotCommands() []tgbotapi.BotCommand {
 	return []tgbotapi.BotCommand{
 		{Command: "uptime", Description: "Get stream uptime"},
 		{Command: "stats", Description: "Get twitch user stats by username"},
-		{Command: "math", Description: "Do simple math (e.g. a + b)"},
+		{Command: "math", Description: "Calculate an expression, supports functions, pi, e, ans and variables (x = 5)"},
+		{Command: "convert", Description: "Convert units of length, mass, temperature, time, data size and speed (e.g. 10 km to mi)"},
+		{Command: "hltb", Description: "Get HowLongToBeat times by title (current game without a title), #N picks another match"},
+		{Command: "timer", Description: "Manage chat timers (add/remove/list)"},
+		{Command: "quote", Description: "Get quote by id or search text, 'export' to get all quotes as JSON"},
+		{Command: "raffle", Description: "Show the status of the latest raffle"},
+		{Command: "streaks", Description: "Show the longest active !daily streaks"},
+		{Command: "reload", Description: "Reload greetings, duels and friends from the files (bot chat only)"},
 		{Command: "help", Description: "Show help"},
 	}
 }
@@ -0,0 +1,43 @@
+package telegramBot
+
+import (
+	db "TelTwBot/Internal/Database"
+	"context"
+	"encoding/json"
+	"fmt"
+	"strings"
+)
+
+func GetQuote(quoteRepo db.QuoteRepository, query string) (string, error) {
+	quote, err := quoteRepo.FindQuote(context.Background(), query)
+	if err != nil {
+		return "", err
+	}
+
+	if quote == nil {
+		return fmt.Sprintf("❌ No quotes found for '%s'.", query), nil
+	}
+
+	var message strings.Builder
+	message.WriteString(fmt.Sprintf("💬 Quote #%d\n\n", quote.ID))
+	message.WriteString(fmt.Sprintf("\"%s\"\n— %s\n\n", quote.Text, quote.Author))
+	if quote.Game != "" {
+		message.WriteString(fmt.Sprintf("🎮 %s\n", quote.Game))
+	}
+	message.WriteString(fmt.Sprintf("📅 %s, added by %s", quote.CreatedAt.Format("2006-01-02"), quote.AddedBy))
+
+	return message.String(), nil
+}
+
+func ExportQuotes(quoteRepo db.QuoteRepository) ([]byte, error) {
+	quotes, err := quoteRepo.GetAllQuotes(context.Background())
+	if err != nil {
+		return nil, err
+	}
+
+	if quotes == nil {
+		quotes = []db.Quote{}
+	}
+
+	return json.MarshalIndent(quotes, "", "  ")
+}
@@ -0,0 +1,37 @@
+package telegramBot
+
+import (
+	db "TelTwBot/Internal/Database"
+	"context"
+	"fmt"
+	"strings"
+)
+
+func GetRaffleStatus(raffleRepo db.RaffleRepository) (string, error) {
+	raffle, err := raffleRepo.GetLatestRaffle(context.Background())
+	if err != nil {
+		return "", err
+	}
+
+	if raffle == nil {
+		return "🎟 There were no raffles yet.", nil
+	}
+
+	var message strings.Builder
+	message.WriteString(fmt.Sprintf("🎟 Raffle #%d\n", raffle.ID))
+	message.WriteString(fmt.Sprintf("🔑 Keyword: %s\n", raffle.Keyword))
+	message.WriteString(fmt.Sprintf("⚖️ Weighting: %s\n", raffle.Weighting))
+	message.WriteString(fmt.Sprintf("👤 Started by %s at %s\n", raffle.StartedBy, raffle.StartedAt.Format("2006-01-02 15:04")))
+	message.WriteString(fmt.Sprintf("👥 Entries: %d, draws: %d\n", raffle.EntriesCount, raffle.DrawsCount))
+
+	switch {
+	case !raffle.EndedAt.Valid:
+		message.WriteString("⏳ In progress")
+	case raffle.Winner.Valid:
+		message.WriteString(fmt.Sprintf("🏆 Winner: %s", raffle.Winner.String))
+	default:
+		message.WriteString("❌ Ended without a winner")
+	}
+
+	return message.String(), nil
+}
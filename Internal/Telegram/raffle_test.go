@@ -0,0 +1,49 @@
+package telegramBot
+
+import (
+	db "TelTwBot/Internal/Database"
+	"context"
+	"database/sql"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+type latestRaffle struct {
+	db.RaffleRepository
+	raffle *db.RaffleInfo
+}
+
+func (r latestRaffle) GetLatestRaffle(ctx context.Context) (*db.RaffleInfo, error) {
+	return r.raffle, nil
+}
+
+func TestGetRaffleStatus(t *testing.T) {
+	msg, err := GetRaffleStatus(latestRaffle{})
+	require.NoError(t, err)
+	require.Equal(t, "🎟 There were no raffles yet.", msg)
+
+	raffle := &db.RaffleInfo{
+		ID:           4,
+		Keyword:      "!join",
+		Weighting:    "sub",
+		StartedBy:    "mod",
+		StartedAt:    time.Date(2026, 10, 19, 20, 30, 0, 0, time.UTC),
+		EntriesCount: 12,
+		DrawsCount:   2,
+	}
+	msg, err = GetRaffleStatus(latestRaffle{raffle: raffle})
+	require.NoError(t, err)
+	require.Equal(t, "🎟 Raffle #4\n🔑 Keyword: !join\n⚖️ Weighting: sub\n👤 Started by mod at 2026-10-19 20:30\n👥 Entries: 12, draws: 2\n⏳ In progress", msg)
+
+	raffle.EndedAt = sql.NullTime{Time: raffle.StartedAt.Add(time.Minute), Valid: true}
+	msg, err = GetRaffleStatus(latestRaffle{raffle: raffle})
+	require.NoError(t, err)
+	require.Contains(t, msg, "❌ Ended without a winner")
+
+	raffle.Winner = sql.NullString{String: "lucky", Valid: true}
+	msg, err = GetRaffleStatus(latestRaffle{raffle: raffle})
+	require.NoError(t, err)
+	require.Contains(t, msg, "🏆 Winner: lucky")
+}
@@ -3,15 +3,15 @@ package telegramBot
 import (
 	db "TelTwBot/Internal/Database"
 	"context"
+	"errors"
 	"fmt"
 	"strings"
 )
 
-func GetStats(username string) (string, error) {
-	database := db.GetInstance()
-	stats, err := database.GetTwitchUserStats(context.Background(), username)
+func GetStats(statsRepo db.StatsRepository, username string) (string, error) {
+	stats, err := statsRepo.GetTwitchUserStats(context.Background(), username)
 	if err != nil {
-		if isUserNotFoundError(err) {
+		if errors.Is(err, db.ErrUserNotFound) {
 			return fmt.Sprintf("❌ User %s not found in the database.", username), nil
 		}
 		return "", err
@@ -39,8 +39,3 @@ func GetStats(username string) (string, error) {
 
 	return message.String(), nil
 }
-
-func isUserNotFoundError(err error) bool {
-	return strings.Contains(err.Error(), "user not found") ||
-		strings.Contains(err.Error(), "no rows in result set")
-}
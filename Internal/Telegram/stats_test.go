@@ -0,0 +1,24 @@
+package telegramBot
+
+import (
+	memory "TelTwBot/Internal/Database/Memory"
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestGetStats(t *testing.T) {
+	stats := memory.NewStore().Repositories().Stats
+
+	msg, err := GetStats(stats, "ghost")
+	require.NoError(t, err)
+	require.Equal(t, "❌ User ghost not found in the database.", msg)
+
+	_, err = stats.GetOrCreateUserStats(context.Background(), "viewer")
+	require.NoError(t, err)
+	msg, err = GetStats(stats, "viewer")
+	require.NoError(t, err)
+	require.Contains(t, msg, "📊 viewer's stats:")
+	require.Contains(t, msg, "💪 strength 1")
+}
@@ -0,0 +1,29 @@
+package telegramBot
+
+import (
+	config "TelTwBot/Internal/Config"
+	constants "TelTwBot/Internal/Config/Constants"
+	db "TelTwBot/Internal/Database"
+	"context"
+	"fmt"
+	"strings"
+)
+
+func GetTopStreaks(dailyRepo db.DailyRepository) (string, error) {
+	streaks, err := dailyRepo.GetTopDailyStreaks(context.Background(), config.ChannelToday(), constants.DailyGraceDays, 10)
+	if err != nil {
+		return "", err
+	}
+
+	if len(streaks) == 0 {
+		return "🔥 There are no active daily streaks.", nil
+	}
+
+	var message strings.Builder
+	message.WriteString("🔥 Longest active daily streaks:\n")
+	for i, streak := range streaks {
+		message.WriteString(fmt.Sprintf("%d. %s - %d day(s) (best: %d)\n", i+1, streak.Username, streak.Streak, streak.BestStreak))
+	}
+
+	return message.String(), nil
+}
@@ -0,0 +1,32 @@
+package telegramBot
+
+import (
+	db "TelTwBot/Internal/Database"
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+type topStreaks struct {
+	db.DailyRepository
+	streaks []db.DailyStreak
+}
+
+func (s topStreaks) GetTopDailyStreaks(ctx context.Context, today time.Time, graceDays int, limit int) ([]db.DailyStreak, error) {
+	return s.streaks, nil
+}
+
+func TestGetTopStreaks(t *testing.T) {
+	msg, err := GetTopStreaks(topStreaks{})
+	require.NoError(t, err)
+	require.Equal(t, "🔥 There are no active daily streaks.", msg)
+
+	msg, err = GetTopStreaks(topStreaks{streaks: []db.DailyStreak{
+		{Username: "regular", Streak: 12, BestStreak: 30},
+		{Username: "newcomer", Streak: 1, BestStreak: 1},
+	}})
+	require.NoError(t, err)
+	require.Equal(t, "🔥 Longest active daily streaks:\n1. regular - 12 day(s) (best: 30)\n2. newcomer - 1 day(s) (best: 1)\n", msg)
+}
@@ -1,12 +1,13 @@
 package telegramBot
 
 import (
+	calc "TelTwBot/Internal/Calc"
+	db "TelTwBot/Internal/Database"
+	hltb "TelTwBot/Internal/HLTB"
 	botInterfaces "TelTwBot/Internal/Interfaces"
-	helpers "TelTwBot/Internal/Telegram/Helpers"
-	"bufio"
 	"fmt"
 	"log"
-	"os"
+	"slices"
 	"strconv"
 	"strings"
 
@@ -16,14 +17,12 @@ import (
 type TelegramNotifier struct {
 	bot    *tgbotapi.BotAPI
 	chatID int64
+	//ans and variables of /math, per Telegram user
+	mathSessions calc.Sessions
+	repos        db.Repositories
 }
 
-type BotConfig struct {
-	BotToken string
-	ChatID   int64
-}
-
-func NewTelegramNotifier(botToken string, chatID int64) (*TelegramNotifier, error) {
+func NewTelegramNotifier(botToken string, chatID int64, repos db.Repositories) (*TelegramNotifier, error) {
 	bot, err := tgbotapi.NewBotAPI(botToken)
 	if err != nil {
 		return nil, err
@@ -34,17 +33,10 @@ func NewTelegramNotifier(botToken string, chatID int64) (*TelegramNotifier, erro
 	return &TelegramNotifier{
 		bot:    bot,
 		chatID: chatID,
+		repos:  repos,
 	}, nil
 }
 
-func NewTelegramNotifierFromConfigFile(filename string) (*TelegramNotifier, error) {
-	config, err := LoadConfigFromFile(filename)
-	if err != nil {
-		return nil, err
-	}
-	return NewTelegramNotifier(config.BotToken, config.ChatID)
-}
-
 func setBotCommands(bot *tgbotapi.BotAPI) error {
 	commands := GetBotCommands()
 	config := tgbotapi.NewSetMyCommands(commands...)
@@ -52,52 +44,6 @@ func setBotCommands(bot *tgbotapi.BotAPI) error {
 	return err
 }
 
-func LoadConfigFromFile(filename string) (*BotConfig, error) {
-	file, err := os.Open(filename)
-	if err != nil {
-		return nil, err
-	}
-	defer file.Close()
-
-	config := &BotConfig{}
-	scanner := bufio.NewScanner(file)
-
-	for scanner.Scan() {
-		line := scanner.Text()
-		if strings.HasPrefix(line, "#") || strings.TrimSpace(line) == "" {
-			continue
-		}
-
-		parts := strings.SplitN(line, " ", 2)
-		if len(parts) != 2 {
-			continue
-		}
-
-		key := strings.TrimSpace(parts[0])
-		value := strings.TrimSpace(parts[1])
-
-		switch key {
-		case "botToken":
-			config.BotToken = value
-		case "chatId":
-			id, err := strconv.ParseInt(value, 10, 64)
-			if err != nil {
-				return nil, fmt.Errorf("invalid chatId: %v", err)
-			}
-			config.ChatID = id
-		}
-	}
-
-	if config.BotToken == "" {
-		return nil, fmt.Errorf("botToken not found in config file")
-	}
-	if config.ChatID == 0 {
-		return nil, fmt.Errorf("chatId not found in config file")
-	}
-
-	return config, nil
-}
-
 func (tn *TelegramNotifier) SendMessage(text string) error {
 	msg := tgbotapi.NewMessage(tn.chatID, text)
 	_, err := tn.bot.Send(msg)
@@ -134,8 +80,22 @@ func (tn *TelegramNotifier) handleCommand(update tgbotapi.Update, twitchBot botI
 		tn.handleHelpCommand(update)
 	case "math":
 		tn.handleMathCommand(update, args)
+	case "convert":
+		tn.handleConvertCommand(update, args)
+	case "hltb":
+		tn.handleHLTBCommand(update, args, twitchBot)
 	case "stats":
 		tn.handleStatsCommand(update, args)
+	case "timer":
+		tn.handleTimerCommand(update, args, twitchBot)
+	case "quote":
+		tn.handleQuoteCommand(update, args)
+	case "raffle":
+		tn.handleRaffleCommand(update)
+	case "streaks":
+		tn.handleStreaksCommand(update)
+	case "reload":
+		tn.handleReloadCommand(update, twitchBot)
 	default:
 		tn.sendMessage(update.Message.Chat.ID, "Unknown command. Try /help")
 	}
@@ -172,17 +132,29 @@ func (tn *TelegramNotifier) handleMessage(update tgbotapi.Update) {
 
 func (tn *TelegramNotifier) handleMathCommand(update tgbotapi.Update, args string) {
 	if args == "" {
-		tn.sendMessage(update.Message.Chat.ID, "Incorrect input. Usage: /math <expression> (e.g. /math 2+2)")
+		tn.sendMessage(update.Message.Chat.ID, "Incorrect input. Usage: /math <expression> (e.g. /math 2+2, /math x = sqrt(2), /math ans * x)")
 		return
 	}
+	if calc.IsConversion(args) {
+		tn.handleConvertCommand(update, args)
+		return
+	}
+
+	user := strconv.FormatInt(update.Message.Chat.ID, 10)
+	if update.Message.From != nil {
+		user = strconv.FormatInt(update.Message.From.ID, 10)
+	}
 
-	result, err := helpers.EvaluateExpression(args)
+	result, err := tn.mathSessions.Evaluate(user, args)
 	if err != nil {
-		tn.sendMessage(update.Message.Chat.ID, fmt.Sprintf("Error: %s\nUsage: /math <num> <op> <num> (e.g., 2 + 2)", err.Error()))
+		tn.sendMessage(update.Message.Chat.ID, fmt.Sprintf("Error: %s\nUsage: /math <expression> (e.g. /math (2+3)^2, /math round(pi, 2), /math x = 5)", err.Error()))
 		return
 	}
 
-	response := fmt.Sprintf("🧮Result: %s = %v", args, result)
+	response := fmt.Sprintf("🧮Result: %s", result)
+	if !strings.Contains(args, "=") {
+		response = fmt.Sprintf("🧮Result: %s = %s", args, result)
+	}
 	tn.sendMessage(update.Message.Chat.ID, response)
 }
 
@@ -194,7 +166,7 @@ func (tn *TelegramNotifier) handleStatsCommand(update tgbotapi.Update, args stri
 
 	parts := strings.Fields(args)
 	//For this, I decide not to overthink much, so we just take the first argument after the command and don't throw errors if there is more than one argument.
-	stats, err := GetStats(parts[0])
+	stats, err := GetStats(tn.repos.Stats, parts[0])
 	if err != nil {
 		tn.sendMessage(update.Message.Chat.ID, fmt.Sprintf("Error: %s", err))
 		return
@@ -203,6 +175,73 @@ func (tn *TelegramNotifier) handleStatsCommand(update tgbotapi.Update, args stri
 	tn.SendMessage(stats)
 }
 
+func (tn *TelegramNotifier) handleTimerCommand(update tgbotapi.Update, args string, twitchBot botInterfaces.TwitchBotInterface) {
+	fields := strings.Fields(args)
+	//Changing timers is only for the bot's own chat, like the mods-only !timer on Twitch
+	if len(fields) > 0 && slices.Contains([]string{"add", "remove"}, strings.ToLower(fields[0])) && update.Message.Chat.ID != tn.chatID {
+		tn.sendMessage(update.Message.Chat.ID, "Timers can only be changed in the bot chat.")
+		return
+	}
+
+	response, err := twitchBot.HandleTimerCommand(fields)
+	if err != nil {
+		tn.sendMessage(update.Message.Chat.ID, fmt.Sprintf("Error: %s", err))
+		return
+	}
+
+	tn.sendMessage(update.Message.Chat.ID, "⏰"+response)
+}
+
+func (tn *TelegramNotifier) handleQuoteCommand(update tgbotapi.Update, args string) {
+	args = strings.TrimSpace(args)
+	if strings.ToLower(args) == "export" {
+		if update.Message.Chat.ID != tn.chatID {
+			tn.sendMessage(update.Message.Chat.ID, "Export is only available in the bot chat.")
+			return
+		}
+
+		data, err := ExportQuotes(tn.repos.Quotes)
+		if err != nil {
+			tn.sendMessage(update.Message.Chat.ID, fmt.Sprintf("Error: %s", err))
+			return
+		}
+
+		doc := tgbotapi.NewDocument(update.Message.Chat.ID, tgbotapi.FileBytes{Name: "quotes.json", Bytes: data})
+		if _, err := tn.bot.Send(doc); err != nil {
+			log.Printf("Error sending quotes export: %v", err)
+		}
+		return
+	}
+
+	quote, err := GetQuote(tn.repos.Quotes, args)
+	if err != nil {
+		tn.sendMessage(update.Message.Chat.ID, fmt.Sprintf("Error: %s", err))
+		return
+	}
+
+	tn.sendMessage(update.Message.Chat.ID, quote)
+}
+
+func (tn *TelegramNotifier) handleRaffleCommand(update tgbotapi.Update) {
+	status, err := GetRaffleStatus(tn.repos.Raffles)
+	if err != nil {
+		tn.sendMessage(update.Message.Chat.ID, fmt.Sprintf("Error: %s", err))
+		return
+	}
+
+	tn.sendMessage(update.Message.Chat.ID, status)
+}
+
+func (tn *TelegramNotifier) handleStreaksCommand(update tgbotapi.Update) {
+	streaks, err := GetTopStreaks(tn.repos.Daily)
+	if err != nil {
+		tn.sendMessage(update.Message.Chat.ID, fmt.Sprintf("Error: %s", err))
+		return
+	}
+
+	tn.sendMessage(update.Message.Chat.ID, streaks)
+}
+
 func (tn *TelegramNotifier) sendMessage(chatID int64, text string) {
 	msg := tgbotapi.NewMessage(chatID, text)
 
@@ -211,3 +250,39 @@ func (tn *TelegramNotifier) sendMessage(chatID int64, text string) {
 		log.Printf("Error sending Telegram message: %v", err)
 	}
 }
+
+func (tn *TelegramNotifier) handleConvertCommand(update tgbotapi.Update, args string) {
+	const usage = "Usage: /convert <amount> <unit> to <unit> (e.g. /convert 10 km to mi, /convert 72 F to C, /convert 5'11\" to cm, /convert 1h30m to min)"
+	if args == "" {
+		tn.sendMessage(update.Message.Chat.ID, "Incorrect input. "+usage)
+		return
+	}
+
+	result, err := calc.Convert(args)
+	if err != nil {
+		tn.sendMessage(update.Message.Chat.ID, fmt.Sprintf("Error: %s\n%s", err.Error(), usage))
+		return
+	}
+
+	tn.sendMessage(update.Message.Chat.ID, fmt.Sprintf("📏Result: %s", result))
+}
+
+func (tn *TelegramNotifier) handleHLTBCommand(update tgbotapi.Update, args string, twitchBot botInterfaces.TwitchBotInterface) {
+	games, pick, err := twitchBot.FindHowLongToBeat(args)
+	if err != nil {
+		tn.sendMessage(update.Message.Chat.ID, fmt.Sprintf("Error: %s\nUsage: /hltb [game title] [#N] (e.g. /hltb Celeste, /hltb Dark Souls #2, /hltb for the current game)", err.Error()))
+		return
+	}
+
+	tn.sendMessage(update.Message.Chat.ID, hltb.FormatCard(games, pick))
+}
+
+func (tn *TelegramNotifier) handleReloadCommand(update tgbotapi.Update, twitchBot botInterfaces.TwitchBotInterface) {
+	//Only the bot's own chat, like the mods-only !reload on Twitch
+	if update.Message.Chat.ID != tn.chatID {
+		tn.sendMessage(update.Message.Chat.ID, "Reload is only available in the bot chat.")
+		return
+	}
+
+	tn.sendMessage(update.Message.Chat.ID, "🔄 "+strings.ReplaceAll(twitchBot.ReloadConfigFiles(), "; ", "\n"))
+}
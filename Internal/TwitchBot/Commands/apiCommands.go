@@ -2,19 +2,20 @@ package twBotCommands
 
 import (
 	config "TelTwBot/Internal/Config"
-	constants "TelTwBot/Internal/Config/Constants"
-	"bufio"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"io"
-	"log"
 	"net/http"
-	"os"
 	"strings"
 
 	"github.com/gempir/go-twitch-irc/v4"
 )
 
+var ErrShoutoutCooldown = errors.New("shoutout is on cooldown")
+
+var ErrUserNotFound = errors.New("user not found")
+
 type TwitchAPI struct {
 	ClientID   string
 	OAuthToken string
@@ -32,33 +33,24 @@ type StreamInfo struct {
 	} `json:"data"`
 }
 
-type ApiConfig struct {
-	ClientID   string
-	OAuthToken string
+type ChannelInfo struct {
+	BroadcasterID    string `json:"broadcaster_id"`
+	BroadcasterLogin string `json:"broadcaster_login"`
+	BroadcasterName  string `json:"broadcaster_name"`
+	GameName         string `json:"game_name"`
+	Title            string `json:"title"`
 }
 
-func NewTwitchAPI() *TwitchAPI {
-	helixFile, err := config.ConfigPath(constants.HelixFile)
-	if err != nil {
-		log.Fatalf("Error getting Helix config path: %v", err)
-	}
-
-	conf, err := LoadConfigFromFile(helixFile)
-	if err != nil {
-		log.Fatalf("Error: %v", err)
-		return nil
-	}
-
+// NewTwitchAPI returns the Helix client for the credentials from the settings.
+func NewTwitchAPI(settings config.HelixSettings) *TwitchAPI {
 	return &TwitchAPI{
-		ClientID:   conf.ClientID,
-		OAuthToken: conf.OAuthToken,
+		ClientID:   settings.ClientID,
+		OAuthToken: settings.OAuthToken,
 		BaseApiURL: "https://api.twitch.tv/helix",
 	}
 }
 
-func GetCurrentStreamInfo(broadcasterName string) (StreamInfo, error) {
-	twApi := NewTwitchAPI()
-
+func (twApi *TwitchAPI) GetCurrentStreamInfo(broadcasterName string) (StreamInfo, error) {
 	url := fmt.Sprintf("%s/streams?user_login=%s", twApi.BaseApiURL, broadcasterName)
 
 	req, err := http.NewRequest("GET", url, nil)
@@ -96,72 +88,39 @@ func GetCurrentStreamInfo(broadcasterName string) (StreamInfo, error) {
 	return streamInfo, nil
 }
 
-func GetCurrentGame(broadcasterName string) (string, error) {
-	streamInfo, err := GetCurrentStreamInfo(broadcasterName)
+func (twApi *TwitchAPI) GetCurrentGame(broadcasterName string) (string, error) {
+	gameName, err := twApi.GetCurrentGameName(broadcasterName)
 	if err != nil {
 		return "", err
 	}
 
-	response := fmt.Sprintf("Current game is: %s", streamInfo.Data[0].GameName)
+	response := fmt.Sprintf("Current game is: %s", gameName)
 
 	return response, nil
 }
 
-func GetTitle(broadcasterName string) (string, error) {
-	streamInfo, err := GetCurrentStreamInfo(broadcasterName)
+func (twApi *TwitchAPI) GetCurrentGameName(broadcasterName string) (string, error) {
+	streamInfo, err := twApi.GetCurrentStreamInfo(broadcasterName)
 	if err != nil {
 		return "", err
 	}
 
-	//Maybe in the future, I"ll need some formatting
-	response := streamInfo.Data[0].Title
-	return response, nil
+	return streamInfo.Data[0].GameName, nil
 }
 
-func LoadConfigFromFile(filename string) (*ApiConfig, error) {
-	file, err := os.Open(filename)
+func (twApi *TwitchAPI) GetTitle(broadcasterName string) (string, error) {
+	streamInfo, err := twApi.GetCurrentStreamInfo(broadcasterName)
 	if err != nil {
-		return nil, err
-	}
-	defer file.Close()
-
-	config := &ApiConfig{}
-	scanner := bufio.NewScanner(file)
-
-	for scanner.Scan() {
-		line := scanner.Text()
-		if strings.HasPrefix(line, "#") || strings.TrimSpace(line) == "" {
-			continue
-		}
-
-		parts := strings.SplitN(line, " ", 2)
-		if len(parts) != 2 {
-			continue
-		}
-
-		key := strings.TrimSpace(parts[0])
-		value := strings.TrimSpace(parts[1])
-
-		switch key {
-		case "clientID":
-			config.ClientID = value
-		case "oauth":
-			config.OAuthToken = value
-		}
-	}
-
-	if config.ClientID == "" {
-		return nil, fmt.Errorf("clientID not found in config file")
-	}
-	if config.OAuthToken == "" {
-		return nil, fmt.Errorf("oauth not found in config file")
+		return "", err
 	}
 
-	return config, nil
+	//Maybe in the future, I"ll need some formatting
+	response := streamInfo.Data[0].Title
+	return response, nil
 }
 
-func GetUserInfo(username string) (*twitch.User, error) {
-	user, err := GetUserByLogin(username)
+func (twApi *TwitchAPI) GetUserInfo(username string) (*twitch.User, error) {
+	user, err := twApi.GetUserByLogin(username)
 	if err != nil {
 		return nil, err
 	}
@@ -169,8 +128,7 @@ func GetUserInfo(username string) (*twitch.User, error) {
 	return user, nil
 }
 
-func GetUserByLogin(username string) (*twitch.User, error) {
-	twApi := NewTwitchAPI()
+func (twApi *TwitchAPI) GetUserByLogin(username string) (*twitch.User, error) {
 	url := fmt.Sprintf("%s/users?login=%s", twApi.BaseApiURL, username)
 
 	req, err := http.NewRequest("GET", url, nil)
@@ -211,7 +169,7 @@ func GetUserByLogin(username string) (*twitch.User, error) {
 	}
 
 	if len(response.Data) == 0 {
-		return nil, fmt.Errorf("user not found")
+		return nil, fmt.Errorf("%w: %s", ErrUserNotFound, username)
 	}
 
 	return &twitch.User{
@@ -220,3 +178,119 @@ func GetUserByLogin(username string) (*twitch.User, error) {
 		DisplayName: response.Data[0].DisplayName,
 	}, nil
 }
+
+func (twApi *TwitchAPI) newRequest(method string, endpoint string, body io.Reader) (*http.Request, error) {
+	req, err := http.NewRequest(method, twApi.BaseApiURL+endpoint, body)
+	if err != nil {
+		return nil, err
+	}
+
+	req.Header.Set("Client-ID", twApi.ClientID)
+	req.Header.Set("Authorization", "Bearer "+twApi.OAuthToken)
+	if body != nil {
+		req.Header.Set("Content-Type", "application/json")
+	}
+
+	return req, nil
+}
+
+// GetChannelInfo returns the last known game and title of the channel, it works even if the channel is offline.
+func (twApi *TwitchAPI) GetChannelInfo(broadcasterID string) (*ChannelInfo, error) {
+	req, err := twApi.newRequest("GET", fmt.Sprintf("/channels?broadcaster_id=%s", broadcasterID), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	client := &http.Client{}
+	resp, err := client.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("API request failed with status %d", resp.StatusCode)
+	}
+
+	var response struct {
+		Data []ChannelInfo `json:"data"`
+	}
+
+	if err := json.NewDecoder(resp.Body).Decode(&response); err != nil {
+		return nil, err
+	}
+
+	if len(response.Data) == 0 {
+		return nil, fmt.Errorf("channel not found")
+	}
+
+	return &response.Data[0], nil
+}
+
+// SendShoutout calls the native /shoutout. The token owner (moderatorID) must be a moderator
+// of the channel and have the moderator:manage:shoutouts scope.
+func (twApi *TwitchAPI) SendShoutout(fromBroadcasterID string, toBroadcasterID string, moderatorID string) error {
+	endpoint := fmt.Sprintf("/chat/shoutouts?from_broadcaster_id=%s&to_broadcaster_id=%s&moderator_id=%s",
+		fromBroadcasterID, toBroadcasterID, moderatorID)
+	req, err := twApi.newRequest("POST", endpoint, nil)
+	if err != nil {
+		return err
+	}
+
+	client := &http.Client{}
+	resp, err := client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	switch resp.StatusCode {
+	case http.StatusNoContent:
+		return nil
+	case http.StatusTooManyRequests:
+		return ErrShoutoutCooldown
+	default:
+		body, _ := io.ReadAll(resp.Body)
+		return fmt.Errorf("shoutout request failed with status %d: %s", resp.StatusCode, strings.TrimSpace(string(body)))
+	}
+}
+
+func (twApi *TwitchAPI) IsStreamLive(broadcasterName string) (bool, error) {
+	req, err := twApi.newRequest("GET", fmt.Sprintf("/streams?user_login=%s", broadcasterName), nil)
+	if err != nil {
+		return false, err
+	}
+
+	client := &http.Client{}
+	resp, err := client.Do(req)
+	if err != nil {
+		return false, err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return false, fmt.Errorf("API request failed with status %d", resp.StatusCode)
+	}
+
+	var streamInfo StreamInfo
+	if err := json.NewDecoder(resp.Body).Decode(&streamInfo); err != nil {
+		return false, err
+	}
+
+	return len(streamInfo.Data) > 0, nil
+}
+
+// GetChannelGameName returns the category set on the channel, unlike GetCurrentGameName it doesn't fail if the stream is offline.
+func (twApi *TwitchAPI) GetChannelGameName(broadcasterName string) (string, error) {
+	broadcaster, err := twApi.GetUserByLogin(broadcasterName)
+	if err != nil {
+		return "", err
+	}
+
+	channelInfo, err := twApi.GetChannelInfo(broadcaster.ID)
+	if err != nil {
+		return "", err
+	}
+
+	return channelInfo.GameName, nil
+}
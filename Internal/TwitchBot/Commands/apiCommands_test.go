@@ -0,0 +1,58 @@
+package twBotCommands
+
+import (
+	config "TelTwBot/Internal/Config"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func newTestTwitchAPI(t *testing.T, handler http.HandlerFunc) *TwitchAPI {
+	server := httptest.NewServer(handler)
+	t.Cleanup(server.Close)
+
+	twApi := NewTwitchAPI(config.HelixSettings{ClientID: "client", OAuthToken: "token"})
+	twApi.BaseApiURL = server.URL
+	return twApi
+}
+
+func TestGetUserByLogin(t *testing.T) {
+	twApi := newTestTwitchAPI(t, func(w http.ResponseWriter, r *http.Request) {
+		require.Equal(t, "client", r.Header.Get("Client-ID"))
+		require.Equal(t, "Bearer token", r.Header.Get("Authorization"))
+
+		if login := r.URL.Query().Get("login"); login == "streamer" {
+			fmt.Fprint(w, `{"data":[{"id":"42","login":"streamer","display_name":"Streamer"}]}`)
+			return
+		}
+		fmt.Fprint(w, `{"data":[]}`)
+	})
+
+	user, err := twApi.GetUserByLogin("streamer")
+	require.NoError(t, err)
+	require.Equal(t, "42", user.ID)
+	require.Equal(t, "Streamer", user.DisplayName)
+
+	_, err = twApi.GetUserByLogin("nobody")
+	require.ErrorIs(t, err, ErrUserNotFound)
+}
+
+func TestSendShoutout(t *testing.T) {
+	status := http.StatusNoContent
+	twApi := newTestTwitchAPI(t, func(w http.ResponseWriter, r *http.Request) {
+		require.Equal(t, "/chat/shoutouts", r.URL.Path)
+		require.Equal(t, "2", r.URL.Query().Get("to_broadcaster_id"))
+		w.WriteHeader(status)
+	})
+
+	require.NoError(t, twApi.SendShoutout("1", "2", "3"))
+
+	status = http.StatusTooManyRequests
+	require.ErrorIs(t, twApi.SendShoutout("1", "2", "3"), ErrShoutoutCooldown)
+
+	status = http.StatusUnauthorized
+	require.ErrorContains(t, twApi.SendShoutout("1", "2", "3"), "status 401")
+}
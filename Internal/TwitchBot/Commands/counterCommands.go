@@ -0,0 +1,147 @@
+package twBotCommands
+
+import (
+	db "TelTwBot/Internal/Database"
+	"context"
+	"fmt"
+	"log"
+	"regexp"
+	"strings"
+	"time"
+)
+
+var (
+	counterNamePattern     = regexp.MustCompile(`^[a-z0-9_]+$`)
+	counterTemplatePattern = regexp.MustCompile(`\{count:([a-zA-Z0-9_]+)\}`)
+)
+
+func IsValidCounterName(name string) bool {
+	return counterNamePattern.MatchString(name)
+}
+
+func CreateCounter(counterRepo db.CounterRepository, name string, perGame bool) (string, error) {
+	if !IsValidCounterName(name) {
+		return "Counter name can contain only latin letters, digits and '_'.", nil
+	}
+
+	counter, err := counterRepo.CreateCounter(context.Background(), name, perGame)
+	if err != nil {
+		return "", err
+	}
+
+	if counter.PerGame {
+		return fmt.Sprintf("Counter '%s' created, it's counted separately for every game.", counter.Name), nil
+	}
+	return fmt.Sprintf("Counter '%s' created.", counter.Name), nil
+}
+
+func DeleteCounter(counterRepo db.CounterRepository, name string) (string, error) {
+	deleted, err := counterRepo.DeleteCounter(context.Background(), name)
+	if err != nil {
+		return "", err
+	}
+	if !deleted {
+		return fmt.Sprintf("Counter '%s' not found.", name), nil
+	}
+	return fmt.Sprintf("Counter '%s' deleted.", name), nil
+}
+
+func ListCounters(counterRepo db.CounterRepository) (string, error) {
+	counters, err := counterRepo.GetCounters(context.Background())
+	if err != nil {
+		return "", err
+	}
+	if len(counters) == 0 {
+		return "There are no counters.", nil
+	}
+
+	var names []string
+	for _, counter := range counters {
+		names = append(names, "!"+counter.Name)
+	}
+	return "Counters: " + strings.Join(names, ", "), nil
+}
+
+// ReadCounter returns the counter message, found is false if there is no such counter.
+// currentGame returns the category of the channel, it's called only for per-game counters.
+func ReadCounter(counterRepo db.CounterRepository, currentGame func() (string, error), name string) (message string, found bool, err error) {
+	counter, game, err := getCounterWithGame(counterRepo, currentGame, name)
+	if err != nil || counter == nil {
+		return "", false, err
+	}
+
+	value, err := counterRepo.GetCounterValue(context.Background(), counter.ID, game)
+	if err != nil {
+		return "", true, err
+	}
+
+	return formatCounter(counter.Name, game, value), true, nil
+}
+
+func ChangeCounter(counterRepo db.CounterRepository, currentGame func() (string, error), name string, delta int) (message string, found bool, err error) {
+	counter, game, err := getCounterWithGame(counterRepo, currentGame, name)
+	if err != nil || counter == nil {
+		return "", false, err
+	}
+
+	value, err := counterRepo.AddToCounter(context.Background(), counter.ID, game, delta)
+	if err != nil {
+		return "", true, err
+	}
+
+	return formatCounter(counter.Name, game, value), true, nil
+}
+
+func SetCounter(counterRepo db.CounterRepository, currentGame func() (string, error), name string, value int) (message string, found bool, err error) {
+	counter, game, err := getCounterWithGame(counterRepo, currentGame, name)
+	if err != nil || counter == nil {
+		return "", false, err
+	}
+
+	if err := counterRepo.SetCounter(context.Background(), counter.ID, game, value); err != nil {
+		return "", true, err
+	}
+
+	return formatCounter(counter.Name, game, value), true, nil
+}
+
+// ExpandCounters replaces {count:name} placeholders with current counter values.
+func ExpandCounters(counterRepo db.CounterRepository, currentGame func() (string, error), text string) string {
+	return counterTemplatePattern.ReplaceAllStringFunc(text, func(placeholder string) string {
+		name := strings.ToLower(counterTemplatePattern.FindStringSubmatch(placeholder)[1])
+
+		counter, game, err := getCounterWithGame(counterRepo, currentGame, name)
+		if err != nil || counter == nil {
+			log.Printf("[%s]❌Failed to expand %s: %v", time.Now().Format("15:04:05"), placeholder, err)
+			return placeholder
+		}
+
+		value, err := counterRepo.GetCounterValue(context.Background(), counter.ID, game)
+		if err != nil {
+			log.Printf("[%s]❌Failed to expand %s: %v", time.Now().Format("15:04:05"), placeholder, err)
+			return placeholder
+		}
+		return fmt.Sprintf("%d", value)
+	})
+}
+
+func getCounterWithGame(counterRepo db.CounterRepository, currentGame func() (string, error), name string) (*db.Counter, string, error) {
+	counter, err := counterRepo.GetCounter(context.Background(), name)
+	if err != nil || counter == nil || !counter.PerGame {
+		return counter, "", err
+	}
+
+	game, err := currentGame()
+	if err != nil {
+		return nil, "", fmt.Errorf("failed to get current game: %w", err)
+	}
+
+	return counter, game, nil
+}
+
+func formatCounter(name string, game string, value int) string {
+	if game != "" {
+		return fmt.Sprintf("%s (%s): %d", name, game, value)
+	}
+	return fmt.Sprintf("%s: %d", name, value)
+}
@@ -0,0 +1,44 @@
+package twBotCommands
+
+import (
+	config "TelTwBot/Internal/Config"
+	constants "TelTwBot/Internal/Config/Constants"
+	db "TelTwBot/Internal/Database"
+	"context"
+	"fmt"
+)
+
+// DailyMultiplier is x1 for the first week of the streak and +1 for every next week, up to x4.
+func DailyMultiplier(streak int) int {
+	return min(1+(streak-1)/7, 4)
+}
+
+// ClaimDaily returns the message and the streak, claimed is false if the reward was already claimed today.
+func ClaimDaily(dailyRepo db.DailyRepository, username string) (string, int, bool, error) {
+	result, err := dailyRepo.ClaimDaily(context.Background(), username, config.ChannelToday(), constants.DailyGraceDays, func(streak int) int {
+		return constants.DailyReward * DailyMultiplier(streak)
+	})
+	if err != nil {
+		return "", 0, false, err
+	}
+
+	if !result.Claimed {
+		return fmt.Sprintf("@%s, you've already claimed today's reward, come back tomorrow! Streak: %d day(s).", username, result.Streak), result.Streak, false, nil
+	}
+
+	return fmt.Sprintf("📅 @%s claimed the daily reward: +%d free point(s)! Streak: %d day(s) (x%d), best: %d.",
+		username, result.Reward, result.Streak, DailyMultiplier(result.Streak), result.BestStreak), result.Streak, true, nil
+}
+
+// getActiveStreak returns 0 if the streak is already broken.
+func getActiveStreak(dailyRepo db.DailyRepository, username string) (int, error) {
+	streak, err := dailyRepo.GetDailyStreak(context.Background(), username)
+	if err != nil || streak == nil {
+		return 0, err
+	}
+
+	if !streak.IsActive(config.ChannelToday(), constants.DailyGraceDays) {
+		return 0, nil
+	}
+	return streak.Streak, nil
+}
@@ -0,0 +1,69 @@
+package twBotCommands
+
+import (
+	db "TelTwBot/Internal/Database"
+	"context"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestDailyMultiplier(t *testing.T) {
+	for _, tc := range []struct {
+		streak   int
+		expected int
+	}{
+		{1, 1},
+		{7, 1},
+		{8, 2},
+		{14, 2},
+		{15, 3},
+		{21, 3},
+		{22, 4},
+		{28, 4},
+		{29, 4},
+		{365, 4},
+	} {
+		t.Run(fmt.Sprint(tc.streak), func(t *testing.T) {
+			require.Equal(t, tc.expected, DailyMultiplier(tc.streak))
+		})
+	}
+}
+
+// dailyStub continues the streak by one day, unless today's reward is already claimed.
+type dailyStub struct {
+	db.DailyRepository
+	streak       int
+	claimedToday bool
+}
+
+func (s dailyStub) ClaimDaily(ctx context.Context, username string, day time.Time, graceDays int, rewardForStreak func(streak int) int) (*db.DailyResult, error) {
+	if s.claimedToday {
+		return &db.DailyResult{Streak: s.streak, BestStreak: s.streak}, nil
+	}
+	streak := s.streak + 1
+	return &db.DailyResult{Claimed: true, Streak: streak, BestStreak: max(streak, 10), Reward: rewardForStreak(streak)}, nil
+}
+
+func TestClaimDaily(t *testing.T) {
+	for _, tc := range []struct {
+		name     string
+		repo     dailyStub
+		expected string
+		claimed  bool
+	}{
+		{"first day", dailyStub{}, "📅 @viewer claimed the daily reward: +1 free point(s)! Streak: 1 day(s) (x1), best: 10.", true},
+		{"second week", dailyStub{streak: 7}, "📅 @viewer claimed the daily reward: +2 free point(s)! Streak: 8 day(s) (x2), best: 10.", true},
+		{"max multiplier", dailyStub{streak: 40}, "📅 @viewer claimed the daily reward: +4 free point(s)! Streak: 41 day(s) (x4), best: 41.", true},
+		{"already claimed", dailyStub{streak: 3, claimedToday: true}, "@viewer, you've already claimed today's reward, come back tomorrow! Streak: 3 day(s).", false},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			msg, _, claimed, err := ClaimDaily(tc.repo, "viewer")
+			require.NoError(t, err)
+			require.Equal(t, tc.expected, msg)
+			require.Equal(t, tc.claimed, claimed)
+		})
+	}
+}
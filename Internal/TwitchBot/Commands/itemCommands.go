@@ -0,0 +1,154 @@
+package twBotCommands
+
+import (
+	config "TelTwBot/Internal/Config"
+	db "TelTwBot/Internal/Database"
+	"context"
+	"errors"
+	"fmt"
+	"slices"
+	"strings"
+)
+
+// GetEffectiveStats returns the stats of the user with the modifiers of the equipped items applied.
+func GetEffectiveStats(repos db.Repositories, username string, items config.ItemCatalog) (map[string]int, error) {
+	stats, bonuses, err := getStatsWithBonuses(repos, username, items)
+	if err != nil {
+		return nil, err
+	}
+
+	effective := make(map[string]int, len(stats))
+	for _, stat := range stats {
+		effective[stat.StatType] = max(stat.Value+bonuses[stat.StatType], 0)
+	}
+	return effective, nil
+}
+
+func getStatsWithBonuses(repos db.Repositories, username string, items config.ItemCatalog) ([]db.UserStats, map[string]int, error) {
+	stats, err := repos.Stats.GetOrCreateUserStats(context.Background(), username)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	userItems, err := repos.Items.GetUserItems(context.Background(), username)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	var equipped []string
+	for _, item := range userItems {
+		if item.Equipped {
+			equipped = append(equipped, item.ItemName)
+		}
+	}
+	return stats, items.Bonuses(equipped), nil
+}
+
+func ShopList(items config.ItemCatalog, query string) string {
+	query = strings.TrimSpace(query)
+	if query == "" {
+		parts := make([]string, 0, len(items))
+		for _, item := range items {
+			parts = append(parts, fmt.Sprintf("%s %d", item.Name, item.Price))
+		}
+		return fmt.Sprintf("🛒 Shop (prices in free points): %s. Use !shop <item|slot> for details and !buy <item> to buy.", strings.Join(parts, " | "))
+	}
+
+	if slot := strings.ToLower(query); slices.Contains(config.ItemSlots, slot) {
+		var parts []string
+		for _, item := range items {
+			if item.Slot == slot {
+				parts = append(parts, fmt.Sprintf("%s %d (%s)", item.Name, item.Price, item.FormatModifiers()))
+			}
+		}
+		if len(parts) == 0 {
+			return fmt.Sprintf("🛒 There are no %s items in the shop.", slot)
+		}
+		return fmt.Sprintf("🛒 %s: %s", slot, strings.Join(parts, " | "))
+	}
+
+	item, ok := items.Find(query)
+	if !ok {
+		return fmt.Sprintf("🛒 There is no '%s' in the shop.", query)
+	}
+	return fmt.Sprintf("🛒 %s (%s, %d free points): %s. %s", item.Name, item.Slot, item.Price, item.FormatModifiers(), item.Description)
+}
+
+func BuyItem(itemRepo db.ItemRepository, username string, items config.ItemCatalog, name string) (string, error) {
+	item, ok := items.Find(name)
+	if !ok {
+		return fmt.Sprintf("@%s, there is no such item in the !shop.", username), nil
+	}
+
+	freePoints, err := itemRepo.BuyItem(context.Background(), username, item.Name, item.Slot, item.Price)
+	switch {
+	case errors.Is(err, db.ErrItemAlreadyOwned):
+		return fmt.Sprintf("@%s, you already own %s.", username, item.Name), nil
+	case errors.Is(err, db.ErrNotEnoughFreePoints):
+		return fmt.Sprintf("@%s, %s costs %d free points, you don't have enough.", username, item.Name, item.Price), nil
+	case err != nil:
+		return "", err
+	}
+
+	return fmt.Sprintf("@%s bought %s for %d free points (%d left). Equip it with !equip %s", username, item.Name, item.Price, freePoints, item.Name), nil
+}
+
+func EquipItem(itemRepo db.ItemRepository, username string, items config.ItemCatalog, name string) (string, error) {
+	item, ok := items.Find(name)
+	if !ok {
+		return fmt.Sprintf("@%s, there is no such item.", username), nil
+	}
+
+	replaced, err := itemRepo.EquipItem(context.Background(), username, item.Name)
+	if errors.Is(err, db.ErrItemNotOwned) {
+		return fmt.Sprintf("@%s, you don't have %s, buy it with !buy %s", username, item.Name, item.Name), nil
+	}
+	if err != nil {
+		return "", err
+	}
+
+	message := fmt.Sprintf("@%s equipped %s (%s)", username, item.Name, item.FormatModifiers())
+	if replaced != "" {
+		message += fmt.Sprintf(", %s goes back to the bag", replaced)
+	}
+	return message + ".", nil
+}
+
+// UnequipItem accepts the item name or the slot.
+func UnequipItem(itemRepo db.ItemRepository, username string, items config.ItemCatalog, nameOrSlot string) (string, error) {
+	nameOrSlot = strings.ToLower(strings.TrimSpace(nameOrSlot))
+	if item, ok := items.Find(nameOrSlot); ok {
+		nameOrSlot = item.Name
+	}
+
+	unequipped, err := itemRepo.UnequipItem(context.Background(), username, nameOrSlot)
+	if err != nil {
+		return "", err
+	}
+	if unequipped == "" {
+		return fmt.Sprintf("@%s, you have nothing like that equipped.", username), nil
+	}
+
+	return fmt.Sprintf("@%s unequipped %s.", username, unequipped), nil
+}
+
+func GetInventory(itemRepo db.ItemRepository, username string) (string, error) {
+	userItems, err := itemRepo.GetUserItems(context.Background(), username)
+	if err != nil {
+		return "", err
+	}
+
+	if len(userItems) == 0 {
+		return fmt.Sprintf("%s has no items yet, take a look at the !shop.", username), nil
+	}
+
+	parts := make([]string, 0, len(userItems))
+	for _, item := range userItems {
+		if item.Equipped {
+			parts = append(parts, fmt.Sprintf("%s [%s, equipped]", item.ItemName, item.Slot))
+		} else {
+			parts = append(parts, item.ItemName)
+		}
+	}
+	return fmt.Sprintf("🎒 %s's inventory: %s", username, strings.Join(parts, ", ")), nil
+}
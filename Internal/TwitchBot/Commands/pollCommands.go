@@ -0,0 +1,186 @@
+package twBotCommands
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"slices"
+)
+
+const managePollsScope = "channel:manage:polls"
+
+type TokenInfo struct {
+	ClientID string   `json:"client_id"`
+	Login    string   `json:"login"`
+	UserID   string   `json:"user_id"`
+	Scopes   []string `json:"scopes"`
+}
+
+type HelixPoll struct {
+	ID      string `json:"id"`
+	Title   string `json:"title"`
+	Status  string `json:"status"`
+	Choices []struct {
+		Title string `json:"title"`
+		Votes int    `json:"votes"`
+	} `json:"choices"`
+}
+
+func (twApi *TwitchAPI) ValidateToken() (*TokenInfo, error) {
+	req, err := http.NewRequest("GET", "https://id.twitch.tv/oauth2/validate", nil)
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("Authorization", "OAuth "+twApi.OAuthToken)
+
+	client := &http.Client{}
+	resp, err := client.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("token validation failed with status %d", resp.StatusCode)
+	}
+
+	var tokenInfo TokenInfo
+	if err := json.NewDecoder(resp.Body).Decode(&tokenInfo); err != nil {
+		return nil, err
+	}
+
+	return &tokenInfo, nil
+}
+
+// CanManagePolls reports whether the Helix token belongs to the broadcaster and has channel:manage:polls scope.
+func (twApi *TwitchAPI) CanManagePolls(broadcasterID string) bool {
+	tokenInfo, err := twApi.ValidateToken()
+	if err != nil {
+		return false
+	}
+
+	return tokenInfo.UserID == broadcasterID && slices.Contains(tokenInfo.Scopes, managePollsScope)
+}
+
+func (twApi *TwitchAPI) CreatePoll(broadcasterID string, title string, choices []string, durationSeconds int) (string, error) {
+	type choice struct {
+		Title string `json:"title"`
+	}
+	payload := struct {
+		BroadcasterID string   `json:"broadcaster_id"`
+		Title         string   `json:"title"`
+		Choices       []choice `json:"choices"`
+		Duration      int      `json:"duration"`
+	}{
+		BroadcasterID: broadcasterID,
+		Title:         title,
+		Duration:      durationSeconds,
+	}
+	for _, c := range choices {
+		payload.Choices = append(payload.Choices, choice{Title: c})
+	}
+
+	body, err := json.Marshal(payload)
+	if err != nil {
+		return "", err
+	}
+
+	req, err := twApi.newRequest("POST", "/polls", bytes.NewReader(body))
+	if err != nil {
+		return "", err
+	}
+
+	client := &http.Client{}
+	resp, err := client.Do(req)
+	if err != nil {
+		return "", err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return "", fmt.Errorf("API request failed with status %d", resp.StatusCode)
+	}
+
+	var response struct {
+		Data []HelixPoll `json:"data"`
+	}
+	if err := json.NewDecoder(resp.Body).Decode(&response); err != nil {
+		return "", err
+	}
+	if len(response.Data) == 0 {
+		return "", fmt.Errorf("poll wasn't created")
+	}
+
+	return response.Data[0].ID, nil
+}
+
+func (twApi *TwitchAPI) GetPoll(broadcasterID string, pollID string) (*HelixPoll, error) {
+	req, err := twApi.newRequest("GET", fmt.Sprintf("/polls?broadcaster_id=%s&id=%s", broadcasterID, pollID), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	client := &http.Client{}
+	resp, err := client.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("API request failed with status %d", resp.StatusCode)
+	}
+
+	var response struct {
+		Data []HelixPoll `json:"data"`
+	}
+	if err := json.NewDecoder(resp.Body).Decode(&response); err != nil {
+		return nil, err
+	}
+	if len(response.Data) == 0 {
+		return nil, fmt.Errorf("poll not found")
+	}
+
+	return &response.Data[0], nil
+}
+
+// EndPoll terminates an active poll early and returns it with the final votes.
+func (twApi *TwitchAPI) EndPoll(broadcasterID string, pollID string) (*HelixPoll, error) {
+	body, err := json.Marshal(map[string]string{
+		"broadcaster_id": broadcasterID,
+		"id":             pollID,
+		"status":         "TERMINATED",
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := twApi.newRequest("PATCH", "/polls", bytes.NewReader(body))
+	if err != nil {
+		return nil, err
+	}
+
+	client := &http.Client{}
+	resp, err := client.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("API request failed with status %d", resp.StatusCode)
+	}
+
+	var response struct {
+		Data []HelixPoll `json:"data"`
+	}
+	if err := json.NewDecoder(resp.Body).Decode(&response); err != nil {
+		return nil, err
+	}
+	if len(response.Data) == 0 {
+		return nil, fmt.Errorf("poll not found")
+	}
+
+	return &response.Data[0], nil
+}
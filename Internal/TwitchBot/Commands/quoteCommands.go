@@ -0,0 +1,89 @@
+package twBotCommands
+
+import (
+	db "TelTwBot/Internal/Database"
+	"context"
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// GetQuote returns a quote by id, a random quote matching the search text or just a random quote if query is empty.
+func GetQuote(quoteRepo db.QuoteRepository, query string) (string, error) {
+	quote, err := quoteRepo.FindQuote(context.Background(), query)
+	if err != nil {
+		return "", err
+	}
+
+	if quote == nil {
+		if query == "" {
+			return "There are no quotes yet. Add one with !addquote <text> [- author]", nil
+		}
+		return fmt.Sprintf("No quotes found for '%s'.", query), nil
+	}
+
+	return FormatQuote(quote), nil
+}
+
+// AddQuote saves a quote in "<text> [- author]" format, the author defaults to the streamer.
+// currentGame returns the game of the stream, it's saved with the quote.
+func AddQuote(quoteRepo db.QuoteRepository, input string, addedBy string, streamer string, currentGame func() (string, error)) (string, error) {
+	text, author := parseQuoteInput(input, streamer)
+	if text == "" {
+		return "Usage: !addquote <text> [- author]", nil
+	}
+
+	//The game is just a bonus, so if the stream is offline we still save the quote.
+	game, err := currentGame()
+	if err != nil {
+		game = ""
+	}
+
+	quote, err := quoteRepo.AddQuote(context.Background(), text, author, game, addedBy)
+	if err != nil {
+		return "", err
+	}
+
+	return fmt.Sprintf("Quote #%d added.", quote.ID), nil
+}
+
+func DeleteQuote(quoteRepo db.QuoteRepository, input string) (string, error) {
+	id, err := strconv.Atoi(strings.TrimPrefix(input, "#"))
+	if err != nil {
+		return "Usage: !delquote <id>", nil
+	}
+
+	deleted, err := quoteRepo.DeleteQuote(context.Background(), id)
+	if err != nil {
+		return "", err
+	}
+	if !deleted {
+		return fmt.Sprintf("Quote #%d not found.", id), nil
+	}
+
+	return fmt.Sprintf("Quote #%d deleted.", id), nil
+}
+
+func FormatQuote(quote *db.Quote) string {
+	var message strings.Builder
+	message.WriteString(fmt.Sprintf("#%d: \"%s\" - %s", quote.ID, quote.Text, quote.Author))
+	if quote.Game != "" {
+		message.WriteString(fmt.Sprintf(" [%s]", quote.Game))
+	}
+	message.WriteString(fmt.Sprintf(" (%s)", quote.CreatedAt.Format("2006-01-02")))
+	return message.String()
+}
+
+func parseQuoteInput(input string, defaultAuthor string) (string, string) {
+	input = strings.TrimSpace(input)
+	author := defaultAuthor
+
+	if idx := strings.LastIndex(input, " - "); idx >= 0 {
+		if quoteAuthor := strings.TrimSpace(input[idx+3:]); quoteAuthor != "" {
+			author = strings.TrimPrefix(quoteAuthor, "@")
+			input = strings.TrimSpace(input[:idx])
+		}
+	}
+
+	return strings.Trim(input, "\""), author
+}
@@ -58,3 +58,12 @@ func hasBadge(user *twitch.User, badge string) bool {
 	}
 	return false
 }
+
+// IsModerator reports whether the user can run moderator-only commands (the broadcaster counts as a moderator).
+func IsModerator(user *twitch.User) bool {
+	return isModerator(user)
+}
+
+func IsSubscriber(user *twitch.User) bool {
+	return isSubscriber(user)
+}
@@ -1,15 +1,19 @@
 package twBotCommands
 
 import (
+	config "TelTwBot/Internal/Config"
+	constants "TelTwBot/Internal/Config/Constants"
 	db "TelTwBot/Internal/Database"
 	"context"
+	"errors"
 	"fmt"
 	"strings"
+	"time"
 )
 
-func GetStats(username string) (string, error) {
-	database := db.GetInstance()
-	stats, err := database.GetOrCreateUserStats(context.Background(), username)
+// GetStats shows the effective stats, the bonus of the equipped items is shown in brackets.
+func GetStats(repos db.Repositories, username string, items config.ItemCatalog) (string, error) {
+	stats, bonuses, err := getStatsWithBonuses(repos, username, items)
 	if err != nil {
 		return "", err
 	}
@@ -17,17 +21,52 @@ func GetStats(username string) (string, error) {
 	var message strings.Builder
 	message.WriteString(fmt.Sprintf("%s's stats: ", username))
 	for _, stat := range stats {
+		if bonus := bonuses[stat.StatType]; bonus != 0 {
+			message.WriteString(fmt.Sprintf("%s: %d (%+d) | ", stat.StatType, max(stat.Value+bonus, 0), bonus))
+			continue
+		}
 		message.WriteString(fmt.Sprintf("%s: %d | ", stat.StatType, stat.Value))
 	}
+
+	streak, err := getActiveStreak(repos.Daily, username)
+	if err != nil {
+		return "", err
+	}
+	message.WriteString(fmt.Sprintf("daily streak: %d", streak))
 	return message.String(), nil
 }
 
-func UpStat(username string, stat string, val int) (string, error) {
-	database := db.GetInstance()
-	statName, newStatValue, err := database.UpdateUserStat(context.Background(), username, stat, val)
+func UpStat(statsRepo db.StatsRepository, username string, stat string, val int) (string, int, error) {
+	statName, newStatValue, err := statsRepo.UpdateUserStat(context.Background(), username, stat, val)
+	if err != nil {
+		return "", 0, err
+	}
+
+	return fmt.Sprintf("%s's %s is now %d", username, statName, newStatValue), newStatValue, nil
+}
+
+func DownStat(statsRepo db.StatsRepository, username string, stat string, val int) (string, error) {
+	statName, newStatValue, refunded, err := statsRepo.DecreaseUserStat(context.Background(), username, stat, val, constants.StatRefundRatio)
+	if err != nil {
+		return "", err
+	}
+
+	if refunded == 0 {
+		//Refunds are rounded down, so removing a single point may give nothing back
+		return fmt.Sprintf("%s's %s is now %d, nothing refunded (%d%% of the removed points, rounded down)",
+			username, statName, newStatValue, int(constants.StatRefundRatio*100)), nil
+	}
+	return fmt.Sprintf("%s's %s is now %d, %d free point(s) refunded", username, statName, newStatValue, refunded), nil
+}
+
+func RespecStats(statsRepo db.StatsRepository, username string) (string, error) {
+	refunded, remaining, err := statsRepo.RespecUserStats(context.Background(), username, constants.RespecCooldown)
+	if errors.Is(err, db.ErrRespecOnCooldown) {
+		return fmt.Sprintf("@%s, you can respec again in %s.", username, remaining.Round(time.Minute)), nil
+	}
 	if err != nil {
 		return "", err
 	}
 
-	return fmt.Sprintf("%s's %s is now %d", username, statName, newStatValue), nil
+	return fmt.Sprintf("%s's stats were reset, %d free point(s) refunded. Spend them with !up.", username, refunded), nil
 }
@@ -0,0 +1,79 @@
+package twBotCommands
+
+import (
+	config "TelTwBot/Internal/Config"
+	memory "TelTwBot/Internal/Database/Memory"
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestStatCommands(t *testing.T) {
+	stats := memory.NewStore().Repositories().Stats
+	_, err := stats.AddFreePoints(context.Background(), "viewer", 4)
+	require.NoError(t, err)
+
+	msg, value, err := UpStat(stats, "viewer", "strength", 3)
+	require.NoError(t, err)
+	require.Equal(t, 4, value)
+	require.Equal(t, "viewer's strength is now 4", msg)
+
+	_, _, err = UpStat(stats, "viewer", "strength", 3)
+	require.ErrorContains(t, err, "not enough free points")
+
+	msg, err = DownStat(stats, "viewer", "strength", 2)
+	require.NoError(t, err)
+	require.Equal(t, "viewer's strength is now 2, 1 free point(s) refunded", msg)
+
+	msg, err = RespecStats(stats, "viewer")
+	require.NoError(t, err)
+	require.Equal(t, "viewer's stats were reset, 1 free point(s) refunded. Spend them with !up.", msg)
+
+	msg, err = RespecStats(stats, "viewer")
+	require.NoError(t, err)
+	require.Contains(t, msg, "you can respec again in")
+}
+
+func TestDownStatWithoutRefund(t *testing.T) {
+	stats := memory.NewStore().Repositories().Stats
+	_, err := stats.AddFreePoints(context.Background(), "viewer", 1)
+	require.NoError(t, err)
+	_, _, err = UpStat(stats, "viewer", "luck", 1)
+	require.NoError(t, err)
+
+	msg, err := DownStat(stats, "viewer", "luck", 1)
+	require.NoError(t, err)
+	require.Equal(t, "viewer's luck is now 1, nothing refunded (50% of the removed points, rounded down)", msg)
+}
+
+func TestGetEffectiveStats(t *testing.T) {
+	ctx := context.Background()
+	catalog := config.ItemCatalog{
+		{Name: "Sword", Slot: "weapon", Modifiers: map[string]int{"strength": 3}},
+		{Name: "Cursed Ring", Slot: "ring", Modifiers: map[string]int{"luck": -5}},
+		{Name: "Boots", Slot: "feet", Modifiers: map[string]int{"agility": 2}},
+	}
+	repos := memory.NewStore().Repositories()
+
+	stats, err := GetEffectiveStats(repos, "viewer", catalog)
+	require.NoError(t, err)
+	require.Equal(t, 1, stats["strength"])
+
+	_, err = repos.Stats.AddFreePoints(ctx, "viewer", 3)
+	require.NoError(t, err)
+	for _, item := range catalog {
+		_, err := repos.Items.BuyItem(ctx, "viewer", item.Name, item.Slot, 1)
+		require.NoError(t, err)
+	}
+	for _, name := range []string{"Sword", "Cursed Ring"} {
+		_, err := repos.Items.EquipItem(ctx, "viewer", name)
+		require.NoError(t, err)
+	}
+
+	stats, err = GetEffectiveStats(repos, "viewer", catalog)
+	require.NoError(t, err)
+	require.Equal(t, 4, stats["strength"])
+	require.Equal(t, 0, stats["luck"], "bonuses can't take a stat below zero")
+	require.Equal(t, 1, stats["agility"], "items in the bag give nothing")
+}
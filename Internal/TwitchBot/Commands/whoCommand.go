@@ -1,11 +1,6 @@
 package twBotCommands
 
 import (
-	config "TelTwBot/Internal/Config"
-	constants "TelTwBot/Internal/Config/Constants"
-	"bufio"
-	"log"
-	"os"
 	"strings"
 )
 
@@ -14,27 +9,13 @@ type Friend struct {
 	Channel  string
 }
 
-func GetStreamers() (string, error) {
-	frFile, err := config.ConfigPath(constants.FriendsFile)
-	if err != nil {
-		log.Fatalf("Error %v", err)
-	}
-	file, err := os.Open(frFile)
-	if err != nil {
-		return "", err
-	}
-	defer file.Close()
-
-	scanner := bufio.NewScanner(file)
+func GetStreamers(friends []string) string {
 	var friendsText strings.Builder
 	friendsText.WriteString("Our friends (for tonight): ")
 	friendsText.WriteString(" ---------------------------------------------- ")
-	for scanner.Scan() {
-		friendsText.WriteString(scanner.Text())
+	for _, friend := range friends {
+		friendsText.WriteString(friend)
 		friendsText.WriteString("       ")
 	}
-	if err := scanner.Err(); err != nil {
-		return "", err
-	}
-	return friendsText.String(), nil
+	return friendsText.String()
 }
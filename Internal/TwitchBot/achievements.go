@@ -0,0 +1,75 @@
+package bot
+
+import (
+	achievements "TelTwBot/Internal/Achievements"
+	"context"
+	"fmt"
+	"log"
+	"strings"
+	"time"
+
+	"github.com/gempir/go-twitch-irc/v4"
+)
+
+// trackAchievement evaluates the event and announces new achievements in chat and Telegram.
+func (tb *TwitchBot) trackAchievement(event achievements.Event) {
+	unlocked, err := tb.Achievements.Evaluate(context.Background(), event)
+	if err != nil {
+		log.Printf("[%s]❌Failed to evaluate achievements for %s: %v", time.Now().Format("15:04:05"), event.Username, err)
+	}
+
+	for _, achievement := range unlocked {
+		SayAndLog(tb.Client, tb.Channel,
+			fmt.Sprintf("🏆 @%s unlocked the achievement \"%s\": %s!", event.Username, achievement.Name, achievement.Description),
+			tb.BotUsername)
+		tb.tgBot.SendMessage(fmt.Sprintf("[%s] 🏆%s unlocked the achievement \"%s\"", time.Now().Format("15:04:05"), event.Username, achievement.Name))
+	}
+}
+
+func (tb *TwitchBot) onDuelFinished(initiator string, challenger string, winner int, isDraw bool) {
+	tb.grantDuelXP(initiator, challenger, winner, isDraw)
+
+	switch {
+	case isDraw:
+		tb.trackAchievement(achievements.Event{Username: initiator, Type: achievements.EventDuelDraw})
+		tb.trackAchievement(achievements.Event{Username: challenger, Type: achievements.EventDuelDraw})
+	case winner == 1:
+		tb.trackAchievement(achievements.Event{Username: initiator, Type: achievements.EventDuelWin})
+		tb.trackAchievement(achievements.Event{Username: challenger, Type: achievements.EventDuelLose})
+	case winner == 2:
+		tb.trackAchievement(achievements.Event{Username: challenger, Type: achievements.EventDuelWin})
+		tb.trackAchievement(achievements.Event{Username: initiator, Type: achievements.EventDuelLose})
+	}
+}
+
+func (tb *TwitchBot) handleAchievementsCommand(message twitch.PrivateMessage) {
+	username := message.User.Name
+	if args := strings.Fields(message.Message); len(args) > 0 {
+		username = strings.ToLower(strings.TrimPrefix(args[0], "@"))
+	}
+
+	unlocked, err := tb.Repos.Achievements.GetUserAchievements(context.Background(), username)
+	if err != nil {
+		log.Printf("[%s]❌Failed to get achievements for %s: %v", time.Now().Format("15:04:05"), username, err)
+		SayAndLog(tb.Client, tb.Channel, "Sorry, couldn't retrieve the achievements. Please try again later.", tb.BotUsername)
+		return
+	}
+
+	var names []string
+	for _, userAchievement := range unlocked {
+		//Achievements removed from the config are skipped
+		if achievement, ok := tb.Achievements.Find(userAchievement.AchievementID); ok {
+			names = append(names, achievement.Name)
+		}
+	}
+
+	if len(names) == 0 {
+		SayAndLog(tb.Client, tb.Channel, fmt.Sprintf("%s has no achievements yet.", username), tb.BotUsername)
+		return
+	}
+
+	SayAndLog(tb.Client, tb.Channel,
+		fmt.Sprintf("🏆 %s's achievements (%d/%d): %s", username, len(names), tb.Achievements.Count(), strings.Join(names, ", ")),
+		tb.BotUsername)
+	log.Printf("[%s] ✅Processed !achievements command for %s.", time.Now().Format("15:04:05"), message.User.Name)
+}
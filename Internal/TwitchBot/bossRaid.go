@@ -0,0 +1,269 @@
+package bot
+
+import (
+	config "TelTwBot/Internal/Config"
+	db "TelTwBot/Internal/Database"
+	twBotCommands "TelTwBot/Internal/TwitchBot/Commands"
+	"context"
+	"fmt"
+	"log"
+	"math/rand/v2"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/gempir/go-twitch-irc/v4"
+)
+
+const (
+	bossRaidDuration   = 2 * time.Minute
+	bossAttackCooldown = 10 * time.Second
+)
+
+type BossRaid struct {
+	Boss        config.BossMsg
+	HP          int
+	StartedAt   time.Time
+	Timer       *time.Timer
+	Damage      map[string]int
+	LastAttack  map[string]time.Time
+	stats       map[string]map[string]int
+	hpAnnounced int
+}
+
+func (tb *TwitchBot) handleBossRaidCommand(message twitch.PrivateMessage) {
+	if !twBotCommands.IsModerator(&message.User) {
+		SayAndLog(tb.Client, tb.Channel, fmt.Sprintf("@%s, only moderators can summon bosses.", message.User.Name), tb.BotUsername)
+		return
+	}
+
+	tb.BossRaidMutex.Lock()
+	defer tb.BossRaidMutex.Unlock()
+
+	if tb.CurrentBossRaid != nil {
+		SayAndLog(tb.Client, tb.Channel, fmt.Sprintf("The %s is already here! Type !attack to fight it.", tb.CurrentBossRaid.Boss.Name), tb.BotUsername)
+		return
+	}
+
+	boss, ok := pickBoss(tb.Bosses, message.Message)
+	if !ok {
+		SayAndLog(tb.Client, tb.Channel, fmt.Sprintf("@%s, there is no such boss.", message.User.Name), tb.BotUsername)
+		return
+	}
+
+	raid := &BossRaid{
+		Boss:        boss,
+		HP:          boss.HP,
+		StartedAt:   time.Now(),
+		Damage:      make(map[string]int),
+		LastAttack:  make(map[string]time.Time),
+		stats:       make(map[string]map[string]int),
+		hpAnnounced: 100,
+	}
+	raid.Timer = time.AfterFunc(bossRaidDuration, func() {
+		tb.BossRaidMutex.Lock()
+		defer tb.BossRaidMutex.Unlock()
+		if tb.CurrentBossRaid == raid {
+			tb.finishBossRaid(raid)
+		}
+	})
+	tb.CurrentBossRaid = raid
+
+	SayAndLog(tb.Client, tb.Channel,
+		fmt.Sprintf("%s HP: %d. Type !attack in the next %s to fight it together!", fmt.Sprintf(boss.SpawnMessage, boss.Name), boss.HP, bossRaidDuration),
+		tb.BotUsername)
+	log.Printf("[%s] ✅Processed !raid-boss command for %s.", time.Now().Format("15:04:05"), message.User.Name)
+}
+
+func (tb *TwitchBot) handleAttackCommand(message twitch.PrivateMessage) {
+	username := message.User.Name
+
+	tb.BossRaidMutex.Lock()
+	raid := tb.CurrentBossRaid
+	if raid == nil {
+		tb.BossRaidMutex.Unlock()
+		return
+	}
+	if time.Since(raid.LastAttack[username]) < bossAttackCooldown {
+		tb.BossRaidMutex.Unlock()
+		return
+	}
+	raid.LastAttack[username] = time.Now()
+	stats, statsLoaded := raid.stats[username]
+	tb.BossRaidMutex.Unlock()
+
+	//Stats are loaded once per raid, so the DB isn't hit for every attack
+	if !statsLoaded {
+		stats = tb.loadStatsMap(username)
+	}
+
+	tb.BossRaidMutex.Lock()
+	defer tb.BossRaidMutex.Unlock()
+
+	if tb.CurrentBossRaid != raid {
+		return
+	}
+	raid.stats[username] = stats
+
+	damage, isCrit := computeBossDamage(stats, rand.IntN)
+	raid.Damage[username] += damage
+	raid.HP -= damage
+
+	if isCrit {
+		SayAndLog(tb.Client, tb.Channel, fmt.Sprintf("💥 @%s lands a critical hit for %d damage!", username, damage), tb.BotUsername)
+	}
+
+	if raid.HP <= 0 {
+		tb.finishBossRaid(raid)
+		return
+	}
+
+	//Announce every 25% of HP lost instead of every hit, otherwise the chat gets flooded
+	hpPercent := raid.HP * 100 / raid.Boss.HP
+	if threshold := (hpPercent / 25) * 25; threshold < raid.hpAnnounced {
+		raid.hpAnnounced = threshold
+		SayAndLog(tb.Client, tb.Channel, fmt.Sprintf("⚔️ The %s has %d/%d HP left!", raid.Boss.Name, raid.HP, raid.Boss.HP), tb.BotUsername)
+	}
+}
+
+// finishBossRaid must be called with BossRaidMutex locked.
+func (tb *TwitchBot) finishBossRaid(raid *BossRaid) {
+	raid.Timer.Stop()
+	tb.CurrentBossRaid = nil
+
+	defeated := raid.HP <= 0
+	totalDamage := raid.Boss.HP - max(raid.HP, 0)
+
+	rewards := map[string]int{}
+	if defeated {
+		rewards = distributeRewards(raid.Boss.Reward, raid.Damage)
+	}
+
+	var participants []db.BossRaidParticipant
+	for username, damage := range raid.Damage {
+		participants = append(participants, db.BossRaidParticipant{Username: username, Damage: damage, Reward: rewards[username]})
+	}
+	slices.SortFunc(participants, func(a, b db.BossRaidParticipant) int { return b.Damage - a.Damage })
+
+	if !defeated {
+		SayAndLog(tb.Client, tb.Channel, fmt.Sprintf(raid.Boss.DefeatMessage, raid.Boss.Name), tb.BotUsername)
+	} else {
+		var top []string
+		for i, participant := range participants {
+			if i == 3 {
+				break
+			}
+			top = append(top, fmt.Sprintf("%s (%d dmg, +%d FP)", participant.Username, participant.Damage, participant.Reward))
+		}
+		SayAndLog(tb.Client, tb.Channel,
+			fmt.Sprintf("🏆 %s Top fighters: %s. %d heroes took part.", fmt.Sprintf(raid.Boss.VictoryMessage, raid.Boss.Name), strings.Join(top, ", "), len(participants)),
+			tb.BotUsername)
+	}
+
+	result := db.BossRaidResult{
+		BossName:     raid.Boss.Name,
+		MaxHP:        raid.Boss.HP,
+		DamageDealt:  totalDamage,
+		Defeated:     defeated,
+		StartedAt:    raid.StartedAt,
+		Participants: participants,
+	}
+
+	//DB writes go to background, we are holding the raid mutex here
+	go func() {
+		ctx := context.Background()
+		if _, err := tb.Repos.BossRaids.SaveBossRaid(ctx, result); err != nil {
+			log.Printf("[%s]❌Failed to save boss raid: %v", time.Now().Format("15:04:05"), err)
+		}
+		for _, participant := range result.Participants {
+			if participant.Reward == 0 {
+				continue
+			}
+			if _, err := tb.Repos.Stats.AddFreePoints(ctx, participant.Username, participant.Reward); err != nil {
+				log.Printf("[%s]❌Failed to reward %s: %v", time.Now().Format("15:04:05"), participant.Username, err)
+			}
+		}
+	}()
+}
+
+func pickBoss(bosses []config.BossMsg, name string) (config.BossMsg, bool) {
+	if len(bosses) == 0 {
+		return config.BossMsg{}, false
+	}
+
+	name = strings.ToLower(strings.TrimSpace(name))
+	if name == "" {
+		return bosses[rand.IntN(len(bosses))], true
+	}
+
+	for _, boss := range bosses {
+		if strings.HasPrefix(strings.ToLower(boss.Name), name) {
+			return boss, true
+		}
+	}
+	return config.BossMsg{}, false
+}
+
+// loadStatsMap returns the effective stats (with equipped items), if they can't be loaded the defaults are used.
+func (tb *TwitchBot) loadStatsMap(username string) map[string]int {
+	stats, err := twBotCommands.GetEffectiveStats(tb.Repos, username, tb.Items)
+	if err != nil {
+		log.Printf("[%s]❌Failed to get stats for %s, using defaults: %v", time.Now().Format("15:04:05"), username, err)
+		return make(map[string]int)
+	}
+	return stats
+}
+
+// computeBossDamage: strength is the main damage source, intelligence adds a bit of magic, agility widens
+// the random roll and luck (with a little help from perception) gives a chance of a double damage critical hit.
+// roll returns a number in [0, n) like rand.IntN.
+func computeBossDamage(stats map[string]int, roll func(n int) int) (int, bool) {
+	strength := max(stats["strength"], 1)
+	damage := 5 + strength*2 + stats["intelligence"] + roll(max(stats["agility"], 1)*2+1)
+
+	critChance := stats["luck"]*3 + stats["perception"]
+	if roll(100) < critChance {
+		return damage * 2, true
+	}
+	return damage, false
+}
+
+// distributeRewards splits the reward pool by contribution using the largest remainder method,
+// so the whole pool is handed out and the biggest damage dealers get the leftovers.
+func distributeRewards(pool int, damage map[string]int) map[string]int {
+	rewards := make(map[string]int)
+
+	total := 0
+	for _, dmg := range damage {
+		total += dmg
+	}
+	if total == 0 || pool <= 0 {
+		return rewards
+	}
+
+	type remainder struct {
+		username string
+		value    int
+	}
+
+	var remainders []remainder
+	distributed := 0
+	for username, dmg := range damage {
+		share := pool * dmg
+		rewards[username] = share / total
+		distributed += share / total
+		remainders = append(remainders, remainder{username, share % total})
+	}
+
+	slices.SortFunc(remainders, func(a, b remainder) int {
+		if a.value != b.value {
+			return b.value - a.value
+		}
+		return strings.Compare(a.username, b.username)
+	})
+	for i := 0; i < pool-distributed; i++ {
+		rewards[remainders[i].username]++
+	}
+
+	return rewards
+}
@@ -0,0 +1,141 @@
+package bot
+
+import (
+	config "TelTwBot/Internal/Config"
+	db "TelTwBot/Internal/Database"
+	"context"
+	"slices"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestComputeBossDamage(t *testing.T) {
+	fighter := map[string]int{"strength": 3, "intelligence": 2, "agility": 2, "luck": 5, "perception": 1}
+
+	for _, tc := range []struct {
+		name     string
+		stats    map[string]int
+		rolls    []int
+		ranges   []int
+		expected int
+		isCrit   bool
+	}{
+		{"no stats", map[string]int{}, []int{0, 0}, []int{3, 100}, 7, false},
+		{"max damage roll", map[string]int{}, []int{2, 99}, []int{3, 100}, 9, false},
+		{"stats", fighter, []int{4, 16}, []int{5, 100}, 17, false},
+		{"critical hit", fighter, []int{4, 15}, []int{5, 100}, 34, true},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			var ranges []int
+			damage, isCrit := computeBossDamage(tc.stats, func(n int) int {
+				ranges = append(ranges, n)
+				return tc.rolls[len(ranges)-1]
+			})
+			require.Equal(t, tc.expected, damage)
+			require.Equal(t, tc.isCrit, isCrit)
+			require.Equal(t, tc.ranges, ranges)
+		})
+	}
+}
+
+func TestDistributeRewards(t *testing.T) {
+	for _, tc := range []struct {
+		name     string
+		pool     int
+		damage   map[string]int
+		expected map[string]int
+	}{
+		{"empty pool", 0, map[string]int{"alice": 10}, map[string]int{}},
+		{"no damage", 5, map[string]int{}, map[string]int{}},
+		{"single fighter", 6, map[string]int{"alice": 5}, map[string]int{"alice": 6}},
+		{"even split", 4, map[string]int{"alice": 50, "bob": 50}, map[string]int{"alice": 2, "bob": 2}},
+		{"tied remainders go alphabetically", 3, map[string]int{"bob": 50, "alice": 50}, map[string]int{"alice": 2, "bob": 1}},
+		{"largest remainder", 5, map[string]int{"alice": 70, "bob": 20, "carol": 10}, map[string]int{"alice": 4, "bob": 1, "carol": 0}},
+		{"larger remainder wins", 2, map[string]int{"alice": 10, "bob": 50}, map[string]int{"alice": 0, "bob": 2}},
+		{"three way", 10, map[string]int{"alice": 1, "bob": 1, "carol": 1}, map[string]int{"alice": 4, "bob": 3, "carol": 3}},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			rewards := distributeRewards(tc.pool, tc.damage)
+			require.Equal(t, tc.expected, rewards)
+
+			total := 0
+			for _, reward := range rewards {
+				total += reward
+			}
+			if len(tc.expected) > 0 {
+				require.Equal(t, tc.pool, total, "the whole pool is handed out")
+			}
+		})
+	}
+}
+
+// savedRaids records the saved boss raids.
+type savedRaids struct {
+	db.BossRaidRepository
+	mutex sync.Mutex
+	raids []db.BossRaidResult
+}
+
+func (r *savedRaids) SaveBossRaid(ctx context.Context, raid db.BossRaidResult) (int, error) {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+	r.raids = append(r.raids, raid)
+	return len(r.raids), nil
+}
+
+func (r *savedRaids) saved() []db.BossRaidResult {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+	return slices.Clone(r.raids)
+}
+
+func TestFinishBossRaid(t *testing.T) {
+	slime := config.BossMsg{Name: "Slime", HP: 100, Reward: 5, VictoryMessage: "The %s melts.", DefeatMessage: "The %s wins."}
+
+	t.Run("victory", func(t *testing.T) {
+		raids := &savedRaids{}
+		tb, _ := newTestBot(func(repos *db.Repositories) { repos.BossRaids = raids })
+		raid := &BossRaid{Boss: slime, HP: -4, Timer: time.AfterFunc(time.Hour, func() {}), Damage: map[string]int{"alice": 70, "bob": 20, "carol": 14}}
+		tb.CurrentBossRaid = raid
+
+		tb.finishBossRaid(raid)
+		require.Nil(t, tb.CurrentBossRaid)
+
+		require.Eventually(t, func() bool { return len(raids.saved()) == 1 }, time.Second, 10*time.Millisecond)
+		result := raids.saved()[0]
+		require.True(t, result.Defeated)
+		require.Equal(t, 100, result.DamageDealt, "overkill isn't counted")
+		require.Equal(t, []db.BossRaidParticipant{
+			{Username: "alice", Damage: 70, Reward: 3},
+			{Username: "bob", Damage: 20, Reward: 1},
+			{Username: "carol", Damage: 14, Reward: 1},
+		}, result.Participants)
+
+		for username, reward := range map[string]int{"alice": 3, "bob": 1, "carol": 1} {
+			require.Eventually(t, func() bool {
+				stats, err := tb.Repos.Stats.GetTwitchUserStats(context.Background(), username)
+				return err == nil && statValue(stats, "free-points") == reward
+			}, time.Second, 10*time.Millisecond, username)
+		}
+	})
+
+	t.Run("defeat", func(t *testing.T) {
+		raids := &savedRaids{}
+		tb, _ := newTestBot(func(repos *db.Repositories) { repos.BossRaids = raids })
+		raid := &BossRaid{Boss: slime, HP: 40, Timer: time.AfterFunc(time.Hour, func() {}), Damage: map[string]int{"alice": 60}}
+
+		tb.finishBossRaid(raid)
+
+		require.Eventually(t, func() bool { return len(raids.saved()) == 1 }, time.Second, 10*time.Millisecond)
+		result := raids.saved()[0]
+		require.False(t, result.Defeated)
+		require.Equal(t, 60, result.DamageDealt)
+		require.Equal(t, []db.BossRaidParticipant{{Username: "alice", Damage: 60}}, result.Participants)
+
+		_, err := tb.Repos.Stats.GetTwitchUserStats(context.Background(), "alice")
+		require.ErrorIs(t, err, db.ErrUserNotFound, "nobody is rewarded for a lost raid")
+	})
+}
@@ -1,17 +1,17 @@
 package bot
 
 import (
+	achievements "TelTwBot/Internal/Achievements"
+	calc "TelTwBot/Internal/Calc"
 	constants "TelTwBot/Internal/Config/Constants"
 	twBotCommands "TelTwBot/Internal/TwitchBot/Commands"
+	"errors"
 	"fmt"
 	"log"
 	"strconv"
 	"strings"
 	"time"
 
-	"github.com/Gladarfin/GetInfoFromHLTB/client"
-	"github.com/Gladarfin/GetInfoFromHLTB/models"
-	"github.com/Gladarfin/GetInfoFromHLTB/utils"
 	"github.com/gempir/go-twitch-irc/v4"
 )
 
@@ -23,29 +23,33 @@ func (tb *TwitchBot) InitCommands() {
 			Handler: func(tb *TwitchBot, message twitch.PrivateMessage) {
 				commandsList := GetAllCommands(tb)
 				for _, msg := range commandsList {
-					SayAndLog(tb.Client, constants.Channel, msg, constants.BotUsername)
+					SayAndLog(tb.Client, tb.Channel, msg, tb.BotUsername)
 				}
 			},
 		},
 		{
 			Name:        "!hello",
-			Description: "Displays a random greeting to user.",
+			Description: "Displays a greeting to user. Usage: !hello [language|list|learn|random]",
 			Handler: func(tb *TwitchBot, message twitch.PrivateMessage) {
-				greeting := tb.Greeter.GetRandomGreeting()
-				response := fmt.Sprintf("@%s, %s * means 'hello' in %s *", greeting.Text, message.User.Name, greeting.Language)
-				SayAndLog(tb.Client, constants.Channel, response, constants.BotUsername)
-				log.Printf("[%s] ✅Processed !hello command for %s.", time.Now().Format("15:04:05"), message.User.Name)
+				tb.handleHelloCommand(message)
+			},
+		},
+		{
+			Name:        "!nogreet",
+			Description: "Turns off automatic greetings for you. Usage: !nogreet [off]",
+			Handler: func(tb *TwitchBot, message twitch.PrivateMessage) {
+				tb.handleNoGreetCommand(message)
 			},
 		},
 		{
 			Name:        "!title",
 			Description: "Displays the current stream title.",
 			Handler: func(tb *TwitchBot, message twitch.PrivateMessage) {
-				title, err := twBotCommands.GetTitle(constants.Channel)
+				title, err := tb.Helix.GetTitle(tb.Channel)
 				if err != nil {
 					log.Printf("[%s]❌Failed to get title of the stream. Error: %s", time.Now().Format("15:04:05"), err)
 				}
-				SayAndLog(tb.Client, constants.Channel, title, constants.BotUsername)
+				SayAndLog(tb.Client, tb.Channel, title, tb.BotUsername)
 				log.Printf("[%s] ✅Processed !title command for %s.", time.Now().Format("15:04:05"), message.User.Name)
 			},
 		},
@@ -53,11 +57,11 @@ func (tb *TwitchBot) InitCommands() {
 			Name:        "!game",
 			Description: "Shows what game is currently being played.",
 			Handler: func(tb *TwitchBot, message twitch.PrivateMessage) {
-				game, err := twBotCommands.GetCurrentGame(constants.Channel)
+				game, err := tb.Helix.GetCurrentGame(tb.Channel)
 				if err != nil {
 					log.Printf("[%s]❌Failed to get game name. Error: %s", time.Now().Format("15:04:05"), err)
 				}
-				SayAndLog(tb.Client, constants.Channel, game, constants.BotUsername)
+				SayAndLog(tb.Client, tb.Channel, game, tb.BotUsername)
 				log.Printf("[%s] ✅Processed !game command for %s.", time.Now().Format("15:04:05"), message.User.Name)
 			},
 		},
@@ -65,11 +69,7 @@ func (tb *TwitchBot) InitCommands() {
 			Name:        "!who",
 			Description: "Shows participating streamers.",
 			Handler: func(tb *TwitchBot, message twitch.PrivateMessage) {
-				friends, err := twBotCommands.GetStreamers()
-				if err != nil {
-					log.Printf("[%s]❌Failed to get streamers list.", time.Now().Format("15:04:05"))
-				}
-				SayAndLog(tb.Client, constants.Channel, friends, constants.BotUsername)
+				SayAndLog(tb.Client, tb.Channel, twBotCommands.GetStreamers(*tb.friends.Load()), tb.BotUsername)
 				log.Printf("[%s] ✅Processed !who command for %s.", time.Now().Format("15:04:05"), message.User.Name)
 			},
 		},
@@ -93,32 +93,107 @@ func (tb *TwitchBot) InitCommands() {
 				if err != nil {
 					log.Printf("[%s]❌%s has no special roles in this channel.", time.Now().Format("15:04:05"), message.User.Name)
 					msg := fmt.Sprintf("%s has no special roles in this channel.", message.User.Name)
-					SayAndLog(tb.Client, constants.Channel, msg, constants.BotUsername)
+					SayAndLog(tb.Client, tb.Channel, msg, tb.BotUsername)
 				}
 
-				SayAndLog(tb.Client, constants.Channel, roles, constants.BotUsername)
+				SayAndLog(tb.Client, tb.Channel, roles, tb.BotUsername)
 				log.Printf("[%s] ✅Processed !role command for %s", time.Now().Format("15:04:05"), targetUser)
 			},
 		},
+		{
+			Name:        "!calc",
+			Description: "Calculates the expression, ans is the last result and variables can be set with x = 5. Usage: !calc <expression>",
+			Handler: func(tb *TwitchBot, message twitch.PrivateMessage) {
+				if strings.TrimSpace(message.Message) == "" {
+					SayAndLog(tb.Client, tb.Channel, "Usage: !calc <expression>, e.g. !calc (2+3)^2, !calc round(pi, 2), !calc x = 5", tb.BotUsername)
+					return
+				}
+
+				evaluate := func(input string) (string, error) { return tb.Calc.Evaluate(message.User.Name, input) }
+				if calc.IsConversion(message.Message) {
+					evaluate = calc.Convert
+				}
+
+				result, err := evaluate(message.Message)
+				if err != nil {
+					SayAndLog(tb.Client, tb.Channel, fmt.Sprintf("@%s, %s", message.User.Name, err), tb.BotUsername)
+					return
+				}
+
+				SayAndLog(tb.Client, tb.Channel, fmt.Sprintf("🧮 @%s %s", message.User.Name, result), tb.BotUsername)
+				log.Printf("[%s] ✅Processed !calc command for %s.", time.Now().Format("15:04:05"), message.User.Name)
+			},
+		},
+		{
+			Name:        "!convert",
+			Description: "Converts units of length, mass, temperature, time, data size and speed. Usage: !convert <amount> <unit> to <unit>",
+			Handler: func(tb *TwitchBot, message twitch.PrivateMessage) {
+				if strings.TrimSpace(message.Message) == "" {
+					SayAndLog(tb.Client, tb.Channel, "Usage: !convert <amount> <unit> to <unit>, e.g. !convert 72 F to C, !convert 5'11\" to cm, !convert 1h30m to min", tb.BotUsername)
+					return
+				}
+
+				result, err := calc.Convert(message.Message)
+				if err != nil {
+					SayAndLog(tb.Client, tb.Channel, fmt.Sprintf("@%s, %s", message.User.Name, err), tb.BotUsername)
+					return
+				}
+
+				SayAndLog(tb.Client, tb.Channel, fmt.Sprintf("📏 @%s %s", message.User.Name, result), tb.BotUsername)
+				log.Printf("[%s] ✅Processed !convert command for %s.", time.Now().Format("15:04:05"), message.User.Name)
+			},
+		},
 		{
 			Name:        "!stats",
 			Description: "Shows user stats.",
 			Handler: func(tb *TwitchBot, message twitch.PrivateMessage) {
-				stats, err := twBotCommands.GetStats(message.User.Name)
+				stats, err := twBotCommands.GetStats(tb.Repos, message.User.Name, tb.Items)
 				if err != nil {
 					log.Printf("[%s]❌ Failed to get stats for %s: %v", time.Now().Format("15:04:05"), message.User.Name, err)
-					SayAndLog(tb.Client, constants.Channel, "Sorry, couldn't retrieve your stats. Please try again later.", constants.BotUsername)
+					SayAndLog(tb.Client, tb.Channel, "Sorry, couldn't retrieve your stats. Please try again later.", tb.BotUsername)
 					return
 				}
-				SayAndLog(tb.Client, constants.Channel, stats, constants.BotUsername)
+				SayAndLog(tb.Client, tb.Channel, stats, tb.BotUsername)
 				log.Printf("[%s] ✅Processed !stats command for %s.", time.Now().Format("15:04:05"), message.User.Name)
 			},
 		},
+		{
+			Name:        "!level",
+			Description: "Shows user level and progress to the next level. Usage: !level [user]",
+			Handler: func(tb *TwitchBot, message twitch.PrivateMessage) {
+				tb.handleLevelCommand(message)
+			},
+		},
+		{
+			Name:        "!daily",
+			Description: "Claims the daily reward, the longer the streak the bigger the reward.",
+			Handler: func(tb *TwitchBot, message twitch.PrivateMessage) {
+				response, streak, claimed, err := twBotCommands.ClaimDaily(tb.Repos.Daily, message.User.Name)
+				if err != nil {
+					log.Printf("[%s]❌Failed to claim daily reward for %s: %s.", time.Now().Format("15:04:05"), message.User.Name, err)
+					SayAndLog(tb.Client, tb.Channel, "Failed to claim the daily reward.", tb.BotUsername)
+					return
+				}
+
+				SayAndLog(tb.Client, tb.Channel, response, tb.BotUsername)
+				if claimed {
+					go tb.trackAchievement(achievements.Event{Username: message.User.Name, Type: achievements.EventDailyStreak, Value: streak})
+				}
+				log.Printf("[%s] ✅Processed !daily command for %s.", time.Now().Format("15:04:05"), message.User.Name)
+			},
+		},
+		{
+			Name:        "!achievements",
+			Description: "Shows unlocked achievements. Usage: !achievements [user]",
+			Handler: func(tb *TwitchBot, message twitch.PrivateMessage) {
+				tb.handleAchievementsCommand(message)
+			},
+		},
 		{
 			Name:        "!duel",
 			Description: "Starts the duel with other user.",
 			Handler: func(tb *TwitchBot, message twitch.PrivateMessage) {
-				tb.StartDuel(message.User.Name, tb.Duels)
+				tb.StartDuel(message.User.Name, *tb.duels.Load())
 			},
 		},
 		{
@@ -129,90 +204,347 @@ func (tb *TwitchBot) InitCommands() {
 
 				if len(args) != 2 {
 					log.Printf("[%s]❌Failed to increase stat for %s. The command contains an incorrect number of arguments.", time.Now().Format("15:04:05"), message.User.Name)
-					SayAndLog(tb.Client, constants.Channel, "The stat command should contain the name of the stat that you want to increase and value: !up <stat_to_increse> <value>.", constants.BotUsername)
+					SayAndLog(tb.Client, tb.Channel, "The stat command should contain the name of the stat that you want to increase and value: !up <stat_to_increse> <value>.", tb.BotUsername)
 					return
 				}
 
 				val, err := strconv.Atoi(args[1])
 				if err != nil {
 					log.Printf("[%s]❌Error converting '%s' to int: %v", time.Now().Format("15:04:05"), args[1], err)
-					SayAndLog(tb.Client, constants.Channel, "Second argument in command should be integer value.", constants.BotUsername)
+					SayAndLog(tb.Client, tb.Channel, "Second argument in command should be integer value.", tb.BotUsername)
 					return
 				}
 
 				if val <= 0 {
 					log.Printf("[%s]❌Error, value argument should be greater than 0.", time.Now().Format("15:04:05"))
-					SayAndLog(tb.Client, constants.Channel, "Second argument in command should be greater than 0.", constants.BotUsername)
+					SayAndLog(tb.Client, tb.Channel, "Second argument in command should be greater than 0.", tb.BotUsername)
 					return
 				}
 
-				if args[0] == "free-points" {
+				if strings.ToLower(args[0]) == "free-points" {
 					log.Printf("[%s]❌Error, you can't increase free-points with this command.", time.Now().Format("15:04:05"))
-					SayAndLog(tb.Client, constants.Channel, "Can't increase free-points stat this way.", constants.BotUsername)
+					SayAndLog(tb.Client, tb.Channel, "Can't increase free-points stat this way.", tb.BotUsername)
 					return
 				}
 
-				stats, err := twBotCommands.UpStat(message.User.Name, args[0], val)
+				stat := strings.ToLower(args[0])
+				stats, newValue, err := twBotCommands.UpStat(tb.Repos.Stats, message.User.Name, stat, val)
 				if err != nil {
 					log.Printf("[%s]❌Failed to increase stat for %s: %s.", time.Now().Format("15:04:05"), message.User.Name, err)
-					SayAndLog(tb.Client, constants.Channel, "Failed to increase stat.", constants.BotUsername)
+					SayAndLog(tb.Client, tb.Channel, "Failed to increase stat.", tb.BotUsername)
 					return
 				}
 
-				SayAndLog(tb.Client, constants.Channel, stats, constants.BotUsername)
+				SayAndLog(tb.Client, tb.Channel, stats, tb.BotUsername)
+				go tb.trackAchievement(achievements.Event{Username: message.User.Name, Type: achievements.EventStat, Stat: stat, Value: newValue})
 				log.Printf("[%s] ✅Processed !up command for %s.", time.Now().Format("15:04:05"), message.User.Name)
 			},
 		},
 		{
-			Name:        "!hl",
-			Description: "Shows game completion times from HowLongToBeat.com. Usage: !hl <game title>",
+			Name:        "!down",
+			Description: fmt.Sprintf("Decrease selected stat, %d%% of the points are refunded. Usage: !down <stat> <value>", int(constants.StatRefundRatio*100)),
 			Handler: func(tb *TwitchBot, message twitch.PrivateMessage) {
-				args := strings.Fields(message.Message)
+				args := strings.Fields(strings.ToLower(message.Message))
 
-				if len(args) < 2 {
-					SayAndLog(tb.Client, constants.Channel,
-						fmt.Sprintf("@%s Usage: !hl <game title>", message.User.Name),
-						constants.BotUsername)
+				if len(args) != 2 {
+					SayAndLog(tb.Client, tb.Channel, "Usage: !down <stat_to_decrease> <value>.", tb.BotUsername)
 					return
 				}
 
-				gameTitle := strings.Join(args, " ")
-				hltbClient := client.New()
-				options := models.SearchOptions{
-					FilterDLC:  true,
-					FilterMods: true,
-					MaxResults: 1,
+				val, err := strconv.Atoi(args[1])
+				if err != nil || val <= 0 {
+					SayAndLog(tb.Client, tb.Channel, "Second argument in command should be integer value greater than 0.", tb.BotUsername)
+					return
 				}
 
-				response, err := hltbClient.Search(gameTitle, options)
+				if args[0] == "free-points" || args[0] == "total-free-points" {
+					SayAndLog(tb.Client, tb.Channel, fmt.Sprintf("Can't decrease %s stat this way.", args[0]), tb.BotUsername)
+					return
+				}
+
+				stats, err := twBotCommands.DownStat(tb.Repos.Stats, message.User.Name, args[0], val)
 				if err != nil {
-					log.Printf("[%s] ❌ HLTB search failed: %v", time.Now().Format("15:04:05"), err)
-					SayAndLog(tb.Client, constants.Channel,
-						fmt.Sprintf("@%s Error searching for '%s'", message.User.Name, gameTitle),
-						constants.BotUsername)
+					log.Printf("[%s]❌Failed to decrease stat for %s: %s.", time.Now().Format("15:04:05"), message.User.Name, err)
+					SayAndLog(tb.Client, tb.Channel, "Failed to decrease stat.", tb.BotUsername)
 					return
 				}
 
-				if len(response.Data) == 0 {
-					SayAndLog(tb.Client, constants.Channel,
-						fmt.Sprintf("@%s No results found for '%s'", message.User.Name, gameTitle),
-						constants.BotUsername)
+				SayAndLog(tb.Client, tb.Channel, stats, tb.BotUsername)
+				log.Printf("[%s] ✅Processed !down command for %s.", time.Now().Format("15:04:05"), message.User.Name)
+			},
+		},
+		{
+			Name:        "!respec",
+			Description: "Resets all your stats to default values and refunds the points into free points.",
+			Handler: func(tb *TwitchBot, message twitch.PrivateMessage) {
+				response, err := twBotCommands.RespecStats(tb.Repos.Stats, message.User.Name)
+				if err != nil {
+					log.Printf("[%s]❌Failed to respec stats for %s: %s.", time.Now().Format("15:04:05"), message.User.Name, err)
+					SayAndLog(tb.Client, tb.Channel, "Failed to reset stats.", tb.BotUsername)
 					return
 				}
 
-				game := response.Data[0]
-				formattedInfo := formatForTwitch(game, message.User.Name)
+				SayAndLog(tb.Client, tb.Channel, response, tb.BotUsername)
+				log.Printf("[%s] ✅Processed !respec command for %s.", time.Now().Format("15:04:05"), message.User.Name)
+			},
+		},
+		{
+			Name:        "!shop",
+			Description: "Lists shop items. Usage: !shop [item|slot]",
+			Handler: func(tb *TwitchBot, message twitch.PrivateMessage) {
+				SayAndLog(tb.Client, tb.Channel, twBotCommands.ShopList(tb.Items, message.Message), tb.BotUsername)
+				log.Printf("[%s] ✅Processed !shop command for %s.", time.Now().Format("15:04:05"), message.User.Name)
+			},
+		},
+		{
+			Name:        "!buy",
+			Description: "Buys the item for free points. Usage: !buy <item>",
+			Handler: func(tb *TwitchBot, message twitch.PrivateMessage) {
+				if strings.TrimSpace(message.Message) == "" {
+					SayAndLog(tb.Client, tb.Channel, "Usage: !buy <item>, see the !shop for items.", tb.BotUsername)
+					return
+				}
 
-				SayAndLog(tb.Client, constants.Channel, formattedInfo, constants.BotUsername)
+				response, err := twBotCommands.BuyItem(tb.Repos.Items, message.User.Name, tb.Items, message.Message)
+				if err != nil {
+					log.Printf("[%s]❌Failed to buy item for %s: %s.", time.Now().Format("15:04:05"), message.User.Name, err)
+					SayAndLog(tb.Client, tb.Channel, "Failed to buy the item.", tb.BotUsername)
+					return
+				}
 
-				log.Printf("[%s] ✅ HLTB: %s", time.Now().Format("15:04:05"), game.GameName)
+				SayAndLog(tb.Client, tb.Channel, response, tb.BotUsername)
+				log.Printf("[%s] ✅Processed !buy command for %s.", time.Now().Format("15:04:05"), message.User.Name)
 			},
 		},
-	}
-}
+		{
+			Name:        "!equip",
+			Description: "Equips the item from your inventory. Usage: !equip <item>",
+			Handler: func(tb *TwitchBot, message twitch.PrivateMessage) {
+				if strings.TrimSpace(message.Message) == "" {
+					SayAndLog(tb.Client, tb.Channel, "Usage: !equip <item>, see your items with !inv.", tb.BotUsername)
+					return
+				}
+
+				response, err := twBotCommands.EquipItem(tb.Repos.Items, message.User.Name, tb.Items, message.Message)
+				if err != nil {
+					log.Printf("[%s]❌Failed to equip item for %s: %s.", time.Now().Format("15:04:05"), message.User.Name, err)
+					SayAndLog(tb.Client, tb.Channel, "Failed to equip the item.", tb.BotUsername)
+					return
+				}
+
+				SayAndLog(tb.Client, tb.Channel, response, tb.BotUsername)
+				log.Printf("[%s] ✅Processed !equip command for %s.", time.Now().Format("15:04:05"), message.User.Name)
+			},
+		},
+		{
+			Name:        "!unequip",
+			Description: "Unequips the item. Usage: !unequip <item|slot>",
+			Handler: func(tb *TwitchBot, message twitch.PrivateMessage) {
+				if strings.TrimSpace(message.Message) == "" {
+					SayAndLog(tb.Client, tb.Channel, "Usage: !unequip <item|slot>", tb.BotUsername)
+					return
+				}
+
+				response, err := twBotCommands.UnequipItem(tb.Repos.Items, message.User.Name, tb.Items, message.Message)
+				if err != nil {
+					log.Printf("[%s]❌Failed to unequip item for %s: %s.", time.Now().Format("15:04:05"), message.User.Name, err)
+					SayAndLog(tb.Client, tb.Channel, "Failed to unequip the item.", tb.BotUsername)
+					return
+				}
 
-func formatForTwitch(game models.GameData, username string) string {
-	baseInfo := utils.FormatGameInfo(game)
-	baseInfo = strings.TrimPrefix(baseInfo, "Game: ")
-	return fmt.Sprintf("@%s %s", username, baseInfo)
+				SayAndLog(tb.Client, tb.Channel, response, tb.BotUsername)
+				log.Printf("[%s] ✅Processed !unequip command for %s.", time.Now().Format("15:04:05"), message.User.Name)
+			},
+		},
+		{
+			Name:        "!inv",
+			Description: "Shows the inventory. Usage: !inv [user]",
+			Handler: func(tb *TwitchBot, message twitch.PrivateMessage) {
+				username := message.User.Name
+				if args := strings.Fields(message.Message); len(args) > 0 {
+					username = strings.ToLower(strings.TrimPrefix(args[0], "@"))
+				}
+
+				response, err := twBotCommands.GetInventory(tb.Repos.Items, username)
+				if err != nil {
+					log.Printf("[%s]❌Failed to get inventory for %s: %s.", time.Now().Format("15:04:05"), username, err)
+					SayAndLog(tb.Client, tb.Channel, "Sorry, couldn't retrieve the inventory. Please try again later.", tb.BotUsername)
+					return
+				}
+
+				SayAndLog(tb.Client, tb.Channel, response, tb.BotUsername)
+				log.Printf("[%s] ✅Processed !inv command for %s.", time.Now().Format("15:04:05"), message.User.Name)
+			},
+		},
+		{
+			Name:        "!so",
+			Description: "Gives a shoutout to other streamer (mods only). Usage: !so @user",
+			Handler: func(tb *TwitchBot, message twitch.PrivateMessage) {
+				if !twBotCommands.IsModerator(&message.User) {
+					SayAndLog(tb.Client, tb.Channel, fmt.Sprintf("@%s, only moderators can give shoutouts.", message.User.Name), tb.BotUsername)
+					return
+				}
+
+				args := strings.Fields(message.Message)
+				if len(args) == 0 {
+					SayAndLog(tb.Client, tb.Channel, fmt.Sprintf("@%s Usage: !so @user", message.User.Name), tb.BotUsername)
+					return
+				}
+
+				if err := tb.Shoutout(args[0]); err != nil {
+					log.Printf("[%s]❌Failed to shoutout %s: %v", time.Now().Format("15:04:05"), args[0], err)
+					var msg string
+					switch {
+					case errors.Is(err, ErrChannelNotFound):
+						msg = fmt.Sprintf("@%s, couldn't find channel %s.", message.User.Name, args[0])
+					case errors.Is(err, ErrShoutoutOnCooldown):
+						msg = fmt.Sprintf("@%s, %s.", message.User.Name, err)
+					case errors.Is(err, ErrNativeShoutoutFailed):
+						msg = fmt.Sprintf("@%s, the shoutout is posted, but Twitch's /shoutout failed.", message.User.Name)
+					default:
+						msg = fmt.Sprintf("@%s, couldn't give a shoutout to %s, try again later.", message.User.Name, args[0])
+					}
+					SayAndLog(tb.Client, tb.Channel, msg, tb.BotUsername)
+					return
+				}
+				log.Printf("[%s] ✅Processed !so command for %s.", time.Now().Format("15:04:05"), message.User.Name)
+			},
+		},
+		{
+			Name:        "!timer",
+			Description: "Manages recurring chat announcements (mods only). Usage: !timer add|remove|list",
+			Handler: func(tb *TwitchBot, message twitch.PrivateMessage) {
+				if !twBotCommands.IsModerator(&message.User) {
+					SayAndLog(tb.Client, tb.Channel, fmt.Sprintf("@%s, only moderators can manage timers.", message.User.Name), tb.BotUsername)
+					return
+				}
+
+				response, err := tb.HandleTimerCommand(strings.Fields(message.Message))
+				if err != nil {
+					log.Printf("[%s]❌Failed to process !timer command: %v", time.Now().Format("15:04:05"), err)
+					SayAndLog(tb.Client, tb.Channel, "Failed to update timers.", tb.BotUsername)
+					return
+				}
+				SayAndLog(tb.Client, tb.Channel, response, tb.BotUsername)
+				log.Printf("[%s] ✅Processed !timer command for %s.", time.Now().Format("15:04:05"), message.User.Name)
+			},
+		},
+		{
+			Name:        "!quote",
+			Description: "Shows a random quote, quote by id or quote that contains text. Usage: !quote [id|search]",
+			Handler: func(tb *TwitchBot, message twitch.PrivateMessage) {
+				quote, err := twBotCommands.GetQuote(tb.Repos.Quotes, message.Message)
+				if err != nil {
+					log.Printf("[%s]❌Failed to get quote: %v", time.Now().Format("15:04:05"), err)
+					SayAndLog(tb.Client, tb.Channel, "Sorry, couldn't retrieve the quote. Please try again later.", tb.BotUsername)
+					return
+				}
+				SayAndLog(tb.Client, tb.Channel, quote, tb.BotUsername)
+				log.Printf("[%s] ✅Processed !quote command for %s.", time.Now().Format("15:04:05"), message.User.Name)
+			},
+		},
+		{
+			Name:        "!addquote",
+			Description: "Adds a quote (mods and subs only). Usage: !addquote <text> [- author]",
+			Handler: func(tb *TwitchBot, message twitch.PrivateMessage) {
+				if !twBotCommands.IsModerator(&message.User) && !twBotCommands.IsSubscriber(&message.User) {
+					SayAndLog(tb.Client, tb.Channel, fmt.Sprintf("@%s, only moderators and subscribers can add quotes.", message.User.Name), tb.BotUsername)
+					return
+				}
+
+				response, err := twBotCommands.AddQuote(tb.Repos.Quotes, message.Message, message.User.Name, tb.Channel, tb.streamGame)
+				if err != nil {
+					log.Printf("[%s]❌Failed to add quote: %v", time.Now().Format("15:04:05"), err)
+					SayAndLog(tb.Client, tb.Channel, "Failed to add quote.", tb.BotUsername)
+					return
+				}
+				SayAndLog(tb.Client, tb.Channel, response, tb.BotUsername)
+				log.Printf("[%s] ✅Processed !addquote command for %s.", time.Now().Format("15:04:05"), message.User.Name)
+			},
+		},
+		{
+			Name:        "!delquote",
+			Description: "Deletes a quote (mods only). Usage: !delquote <id>",
+			Handler: func(tb *TwitchBot, message twitch.PrivateMessage) {
+				if !twBotCommands.IsModerator(&message.User) {
+					SayAndLog(tb.Client, tb.Channel, fmt.Sprintf("@%s, only moderators can delete quotes.", message.User.Name), tb.BotUsername)
+					return
+				}
+
+				response, err := twBotCommands.DeleteQuote(tb.Repos.Quotes, message.Message)
+				if err != nil {
+					log.Printf("[%s]❌Failed to delete quote: %v", time.Now().Format("15:04:05"), err)
+					SayAndLog(tb.Client, tb.Channel, "Failed to delete quote.", tb.BotUsername)
+					return
+				}
+				SayAndLog(tb.Client, tb.Channel, response, tb.BotUsername)
+				log.Printf("[%s] ✅Processed !delquote command for %s.", time.Now().Format("15:04:05"), message.User.Name)
+			},
+		},
+		{
+			Name:        "!counter",
+			Description: "Shows or manages counters, every counter also works as !<name>, !<name>+ and !<name>- (mods only). Usage: !counter <name>|list|create|set|delete",
+			Handler: func(tb *TwitchBot, message twitch.PrivateMessage) {
+				tb.handleCounterCommand(message)
+			},
+		},
+		{
+			Name:        "!poll",
+			Description: "Starts a chat poll (mods only) or shows the current one. Usage: !poll \"Question?\" opt1 | opt2 | opt3 60s, !poll end",
+			Handler: func(tb *TwitchBot, message twitch.PrivateMessage) {
+				tb.handlePollCommand(message)
+			},
+		},
+		{
+			Name:        "!vote",
+			Description: "Votes in the current poll. Usage: !vote <number>",
+			Handler: func(tb *TwitchBot, message twitch.PrivateMessage) {
+				if !tb.registerPollVote(message.User.Name, message.Message) {
+					log.Printf("[%s]❌Invalid vote '%s' from %s.", time.Now().Format("15:04:05"), message.Message, message.User.Name)
+				}
+			},
+		},
+		{
+			Name:        "!raffle",
+			Description: "Shows the current raffle or manages it (mods only). Usage: !raffle start <keyword> [duration] [sub|luck], !raffle draw, !raffle cancel",
+			Handler: func(tb *TwitchBot, message twitch.PrivateMessage) {
+				tb.handleRaffleCommand(message)
+			},
+		},
+		{
+			Name:        "!trivia",
+			Description: "Asks a trivia question, the first correct answer in chat wins free points. Usage: !trivia [category] [easy|medium|hard]",
+			Handler: func(tb *TwitchBot, message twitch.PrivateMessage) {
+				tb.handleTriviaCommand(message)
+			},
+		},
+		{
+			Name:        "!raid-boss",
+			Description: "Summons a boss that the chat fights together (mods only). Usage: !raid-boss [boss name]",
+			Handler: func(tb *TwitchBot, message twitch.PrivateMessage) {
+				tb.handleBossRaidCommand(message)
+			},
+		},
+		{
+			Name:        "!attack",
+			Description: "Attacks the current boss, damage depends on your stats.",
+			Handler: func(tb *TwitchBot, message twitch.PrivateMessage) {
+				tb.handleAttackCommand(message)
+			},
+		},
+		{
+			Name:        "!reload",
+			Description: "Reloads greetings, duels and friends from the files, an invalid file keeps its previous version (mods only).",
+			Handler: func(tb *TwitchBot, message twitch.PrivateMessage) {
+				tb.handleReloadCommand(message)
+			},
+		},
+		{
+			Name:        "!hl",
+			Description: "Shows game completion times from HowLongToBeat.com, the current game without a title. Usage: !hl [game title] [#N]",
+			Handler: func(tb *TwitchBot, message twitch.PrivateMessage) {
+				tb.handleHLTBCommand(message)
+			},
+		},
+	}
 }
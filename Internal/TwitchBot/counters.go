@@ -0,0 +1,195 @@
+package bot
+
+import (
+	twBotCommands "TelTwBot/Internal/TwitchBot/Commands"
+	"context"
+	"fmt"
+	"log"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/gempir/go-twitch-irc/v4"
+)
+
+// CounterNames caches the names of the existing counters, so unknown !commands in chat don't query the database.
+type CounterNames struct {
+	mutex  sync.Mutex
+	names  map[string]bool
+	loaded bool
+}
+
+// contains reports whether the counter exists, load is called only if the names aren't loaded yet or were invalidated.
+func (cn *CounterNames) contains(name string, load func() ([]string, error)) (bool, error) {
+	cn.mutex.Lock()
+	defer cn.mutex.Unlock()
+
+	if !cn.loaded {
+		names, err := load()
+		if err != nil {
+			return false, err
+		}
+		cn.names = make(map[string]bool, len(names))
+		for _, name := range names {
+			cn.names[name] = true
+		}
+		cn.loaded = true
+	}
+	return cn.names[name], nil
+}
+
+// invalidate makes the next contains call reload the names, it's called after a counter is created or deleted.
+func (cn *CounterNames) invalidate() {
+	cn.mutex.Lock()
+	defer cn.mutex.Unlock()
+	cn.loaded = false
+}
+
+func (tb *TwitchBot) loadCounterNames() ([]string, error) {
+	counters, err := tb.Repos.Counters.GetCounters(context.Background())
+	if err != nil {
+		return nil, err
+	}
+
+	names := make([]string, 0, len(counters))
+	for _, counter := range counters {
+		names = append(names, counter.Name)
+	}
+	return names, nil
+}
+
+func (tb *TwitchBot) handleCounterCommand(message twitch.PrivateMessage) {
+	const usage = "Usage: !counter <name> | !counter list | !counter create <name> [game] | !counter set <name> <value> | !counter delete <name>"
+
+	args := strings.Fields(strings.ToLower(message.Message))
+	if len(args) == 0 {
+		SayAndLog(tb.Client, tb.Channel, usage, tb.BotUsername)
+		return
+	}
+
+	isMod := twBotCommands.IsModerator(&message.User)
+	var response string
+	var err error
+
+	switch args[0] {
+	case "list":
+		response, err = twBotCommands.ListCounters(tb.Repos.Counters)
+	case "create", "set", "delete":
+		if !isMod {
+			response = fmt.Sprintf("@%s, only moderators can change counters.", message.User.Name)
+			break
+		}
+		response, err = tb.manageCounter(args, usage)
+	default:
+		var found bool
+		response, found, err = twBotCommands.ReadCounter(tb.Repos.Counters, tb.channelGame, args[0])
+		if err == nil && !found {
+			response = fmt.Sprintf("Counter '%s' not found.", args[0])
+		}
+	}
+
+	if err != nil {
+		log.Printf("[%s]❌Failed to process !counter command: %v", time.Now().Format("15:04:05"), err)
+		SayAndLog(tb.Client, tb.Channel, "Failed to process counter command.", tb.BotUsername)
+		return
+	}
+
+	SayAndLog(tb.Client, tb.Channel, response, tb.BotUsername)
+	log.Printf("[%s] ✅Processed !counter command for %s.", time.Now().Format("15:04:05"), message.User.Name)
+}
+
+func (tb *TwitchBot) manageCounter(args []string, usage string) (string, error) {
+	switch {
+	case args[0] == "create" && (len(args) == 2 || len(args) == 3 && args[2] == "game"):
+		if tb.isCommandName("!" + args[1]) {
+			return fmt.Sprintf("Counter '%s' would clash with existing !%s command.", args[1], args[1]), nil
+		}
+		defer tb.CounterNames.invalidate()
+		return twBotCommands.CreateCounter(tb.Repos.Counters, args[1], len(args) == 3)
+
+	case args[0] == "delete" && len(args) == 2:
+		defer tb.CounterNames.invalidate()
+		return twBotCommands.DeleteCounter(tb.Repos.Counters, args[1])
+
+	case args[0] == "set" && len(args) == 3:
+		value, err := strconv.Atoi(args[2])
+		if err != nil || value < 0 {
+			return "Counter value should be a non-negative integer.", nil
+		}
+
+		response, found, err := twBotCommands.SetCounter(tb.Repos.Counters, tb.channelGame, args[1], value)
+		if err == nil && !found {
+			response = fmt.Sprintf("Counter '%s' not found.", args[1])
+		}
+		return response, err
+	}
+
+	return usage, nil
+}
+
+// handleCounterAlias handles !<name>, !<name>+ and !<name>- for existing counters. It returns false if
+// the message is not a counter command, so unknown commands are still ignored silently.
+func (tb *TwitchBot) handleCounterAlias(cmdName string, message twitch.PrivateMessage) bool {
+	name := strings.TrimPrefix(cmdName, "!")
+	delta := 0
+	switch {
+	case strings.HasSuffix(name, "+"):
+		delta = 1
+	case strings.HasSuffix(name, "-"):
+		delta = -1
+	}
+	name = strings.TrimRight(name, "+-")
+
+	if !twBotCommands.IsValidCounterName(name) {
+		return false
+	}
+	if exists, err := tb.CounterNames.contains(name, tb.loadCounterNames); err != nil || !exists {
+		if err != nil {
+			log.Printf("[%s]❌Failed to load counters: %v", time.Now().Format("15:04:05"), err)
+		}
+		return false
+	}
+
+	if delta == 0 {
+		response, found, err := twBotCommands.ReadCounter(tb.Repos.Counters, tb.channelGame, name)
+		return tb.sayCounterResult(message, response, found, err)
+	}
+
+	if !twBotCommands.IsModerator(&message.User) {
+		return false
+	}
+
+	//!deaths+ 3 changes the counter by 3
+	if args := strings.Fields(message.Message); len(args) > 0 {
+		if amount, err := strconv.Atoi(args[0]); err == nil && amount > 0 {
+			delta *= amount
+		}
+	}
+
+	response, found, err := twBotCommands.ChangeCounter(tb.Repos.Counters, tb.channelGame, name, delta)
+	return tb.sayCounterResult(message, response, found, err)
+}
+
+func (tb *TwitchBot) sayCounterResult(message twitch.PrivateMessage, response string, found bool, err error) bool {
+	if err != nil {
+		log.Printf("[%s]❌Failed to process counter command: %v", time.Now().Format("15:04:05"), err)
+		return found
+	}
+	if !found {
+		return false
+	}
+
+	SayAndLog(tb.Client, tb.Channel, response, tb.BotUsername)
+	log.Printf("[%s] ✅Processed counter command for %s.", time.Now().Format("15:04:05"), message.User.Name)
+	return true
+}
+
+func (tb *TwitchBot) isCommandName(name string) bool {
+	for _, cmd := range tb.commands {
+		if cmd.Name == name {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,37 @@
+package bot
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestCounterNames(t *testing.T) {
+	var counterNames CounterNames
+	loads := 0
+	load := func() ([]string, error) {
+		loads++
+		return []string{"deaths"}, nil
+	}
+
+	for name, exists := range map[string]bool{"deaths": true, "lurk": false, "hydrate": false} {
+		found, err := counterNames.contains(name, load)
+		require.NoError(t, err)
+		require.Equal(t, exists, found, name)
+	}
+	require.Equal(t, 1, loads, "unknown commands don't reload the counters")
+
+	counterNames.invalidate()
+	_, err := counterNames.contains("deaths", load)
+	require.NoError(t, err)
+	require.Equal(t, 2, loads)
+
+	//A failed load is retried on the next message
+	counterNames.invalidate()
+	_, err = counterNames.contains("deaths", func() ([]string, error) { return nil, errors.New("db is down") })
+	require.Error(t, err)
+	found, err := counterNames.contains("deaths", load)
+	require.NoError(t, err)
+	require.True(t, found)
+}
@@ -2,7 +2,7 @@ package bot
 
 import (
 	config "TelTwBot/Internal/Config"
-	constants "TelTwBot/Internal/Config/Constants"
+	"errors"
 	"fmt"
 	"log"
 	"math"
@@ -10,20 +10,53 @@ import (
 	"time"
 )
 
+// StartDuel issues a duel challenge or accepts the active one. The stats of both duelists are loaded
+// before taking DuelMutex, if the challenge changes in the meantime they are loaded again.
 func (tb *TwitchBot) StartDuel(username string, duels []config.DuelMsg) {
+	for {
+		tb.DuelMutex.Lock()
+		initiator := tb.activeDuelInitiator()
+		tb.DuelMutex.Unlock()
+
+		var initiatorStats, challengerStats map[string]int
+		if initiator != "" && initiator != username {
+			initiatorStats = tb.loadStatsMap(initiator)
+			challengerStats = tb.loadStatsMap(username)
+		}
+
+		if tb.handleDuel(username, duels, initiator, initiatorStats, challengerStats) {
+			return
+		}
+	}
+}
+
+// activeDuelInitiator returns the user who issued the active challenge or "" if there is none, DuelMutex should be held.
+func (tb *TwitchBot) activeDuelInitiator() string {
+	if tb.CurrentDuel != nil && tb.CurrentDuel.IsActive {
+		return tb.CurrentDuel.Initiator
+	}
+	return ""
+}
+
+// handleDuel returns false without doing anything if the active challenge isn't the one of initiator anymore.
+func (tb *TwitchBot) handleDuel(username string, duels []config.DuelMsg, initiator string, initiatorStats map[string]int, challengerStats map[string]int) bool {
 	tb.DuelMutex.Lock()
 	defer tb.DuelMutex.Unlock()
 
+	if tb.activeDuelInitiator() != initiator {
+		return false
+	}
+
 	curTime := time.Now()
 
 	if tb.IsDuelCooldownActive && curTime.Sub(tb.LastDuelTime) < 5*time.Minute {
 		remainingTime := time.Until(tb.LastDuelTime.Add(5 * time.Minute)).Round(time.Second)
 		SayAndLog(
 			tb.Client,
-			constants.Channel,
+			tb.Channel,
 			fmt.Sprintf("@%s, duels are on on cooldown. Please wait %s before challenging again.", username, remainingTime),
-			constants.BotUsername)
-		return
+			tb.BotUsername)
+		return true
 	}
 
 	//If there is active duel
@@ -31,49 +64,46 @@ func (tb *TwitchBot) StartDuel(username string, duels []config.DuelMsg) {
 		if tb.CurrentDuel.Initiator == username {
 			SayAndLog(
 				tb.Client,
-				constants.Channel,
+				tb.Channel,
 				fmt.Sprintf("%s, you've already challenged someone, wait for a response.", username),
-				constants.BotUsername)
+				tb.BotUsername)
+			return true
 		}
 
 		//Accept the duel
 		tb.CurrentDuel.Challenger = username
 		tb.CurrentDuel.Timer.Stop()
 		tb.CurrentDuel.IsActive = false
-		curDuel, winner, err := getDuel(duels)
+		curDuel, winner, err := getDuel(duels, duelBonus(initiatorStats), duelBonus(challengerStats))
 		if err != nil {
-			log.Fatalf("%s", err)
+			log.Printf("[%s]❌Failed to pick a duel: %v", time.Now().Format("15:04:05"), err)
 			SayAndLog(
 				tb.Client,
-				constants.Channel,
+				tb.Channel,
 				"There is some error! Contact the administrator.",
-				constants.BotUsername)
-			return
+				tb.BotUsername)
+			return true
 		}
-		formatedAnnounce := fmt.Sprintf(curDuel.AnnounceMessage, tb.CurrentDuel.Initiator, username)
-		SayAndLog(
-			tb.Client,
-			constants.Channel,
-			formatedAnnounce,
-			constants.BotUsername)
-
-		var formatedDuelMessage string
-		switch winner {
-		case 0:
-			formatedDuelMessage = curDuel.DuelMessage
-
-		case 1:
-			formatedDuelMessage = fmt.Sprintf(curDuel.DuelMessage, tb.CurrentDuel.Initiator)
-		case 2:
-			formatedDuelMessage = fmt.Sprintf(curDuel.DuelMessage, username)
+
+		roles := config.DuelRoles{Initiator: tb.CurrentDuel.Initiator, Challenger: username, Stats: initiatorStats}
+		if !curDuel.IsDraw {
+			roles.Winner, roles.Loser = tb.CurrentDuel.Initiator, username
+			if winner == 2 {
+				roles.Winner, roles.Loser = username, tb.CurrentDuel.Initiator
+				roles.Stats = challengerStats
+			}
 		}
-		if winner >= 0 {
-			SayAndLog(
-				tb.Client,
-				constants.Channel,
-				formatedDuelMessage,
-				constants.BotUsername)
+
+		for _, template := range []string{curDuel.AnnounceMessage, curDuel.DuelMessage} {
+			//Templates are validated when the file is loaded, so this can't really fail
+			message, err := config.RenderDuelTemplate(template, roles, rand.IntN)
+			if err != nil {
+				log.Printf("[%s]❌Failed to render duel template %q: %v", time.Now().Format("15:04:05"), template, err)
+				continue
+			}
+			SayAndLog(tb.Client, tb.Channel, message, tb.BotUsername)
 		}
+		go tb.onDuelFinished(tb.CurrentDuel.Initiator, username, winner, curDuel.IsDraw)
 		//Set cooldown between duels
 		tb.LastDuelTime = curTime
 		tb.IsDuelCooldownActive = true
@@ -85,11 +115,11 @@ func (tb *TwitchBot) StartDuel(username string, duels []config.DuelMsg) {
 			tb.DuelMutex.Unlock()
 			SayAndLog(
 				tb.Client,
-				constants.Channel,
+				tb.Channel,
 				"🔄The duel cooldown has ended! You can now challenge others again with !duel",
-				constants.BotUsername)
+				tb.BotUsername)
 		})
-		return
+		return true
 	}
 
 	//Clean expired duel
@@ -108,31 +138,35 @@ func (tb *TwitchBot) StartDuel(username string, duels []config.DuelMsg) {
 			if tb.CurrentDuel != nil && tb.CurrentDuel.IsActive {
 				SayAndLog(
 					tb.Client,
-					constants.Channel,
+					tb.Channel,
 					fmt.Sprintf("@%s's duel challenge has expired with no takers.", tb.CurrentDuel.Initiator),
-					constants.BotUsername)
+					tb.BotUsername)
 			}
 		}),
 	}
 
 	SayAndLog(
 		tb.Client,
-		constants.Channel,
+		tb.Channel,
 		fmt.Sprintf("@%s has issued a duel challenge! Type !duel in the next 60 seconds to accept!", username),
-		constants.BotUsername)
+		tb.BotUsername)
+	return true
 }
 
-func getDuel(duels []config.DuelMsg) (config.DuelMsg, int, error) {
-	newDuel, winner := getRandomDuel(duels)
+func getDuel(duels []config.DuelMsg, initiatorBonus int, challengerBonus int) (config.DuelMsg, int, error) {
+	newDuel, winner := getRandomDuel(duels, initiatorBonus, challengerBonus)
+	if newDuel.DuelMessage == "" {
+		return config.DuelMsg{}, 0, errors.New("no duel messages for this outcome")
+	}
 
 	return newDuel, winner, nil
 }
 
-func getRandomDuel(duels []config.DuelMsg) (config.DuelMsg, int) {
+func getRandomDuel(duels []config.DuelMsg, user1Bonus int, user2Bonus int) (config.DuelMsg, int) {
 	const MAX_DIFF = 5
 
-	user1Result := rand.IntN(100)
-	user2Result := rand.IntN(100)
+	user1Result := rand.IntN(100) + user1Bonus
+	user2Result := rand.IntN(100) + user2Bonus
 	//Close duels == draw, so i set difference between rolls to 5 (MAX_DIFF)
 	isCloseDuel := math.Abs(float64(user1Result)-float64(user2Result)) <= float64(MAX_DIFF)
 	//res = 0 - draw (default), 1 - first win, 2 - second win
@@ -142,11 +176,13 @@ func getRandomDuel(duels []config.DuelMsg) (config.DuelMsg, int) {
 	} else {
 		res = 2
 	}
-	sortedDuels := getSortedDuels(duels, isCloseDuel)
 
-	duelNumber := rand.IntN(len(sortedDuels))
+	return pickWeightedDuel(getSortedDuels(duels, isCloseDuel)), res
+}
 
-	return sortedDuels[duelNumber], res
+// duelBonus is added to the 0-99 duel roll, so stats and gear tip the odds without making duels predictable.
+func duelBonus(stats map[string]int) int {
+	return (stats["strength"] + stats["agility"] + stats["luck"]) / 2
 }
 
 func getSortedDuels(allDuels []config.DuelMsg, isDraw bool) []config.DuelMsg {
@@ -159,3 +195,37 @@ func getSortedDuels(allDuels []config.DuelMsg, isDraw bool) []config.DuelMsg {
 	}
 	return duels
 }
+
+// pickWeightedDuel picks a duel with the chance proportional to its Weight (0 counts as 1).
+func pickWeightedDuel(duels []config.DuelMsg) config.DuelMsg {
+	total := 0
+	for _, duel := range duels {
+		total += max(duel.Weight, 1)
+	}
+	if total == 0 {
+		return config.DuelMsg{}
+	}
+
+	roll := rand.IntN(total)
+	for _, duel := range duels {
+		roll -= max(duel.Weight, 1)
+		if roll < 0 {
+			return duel
+		}
+	}
+	return duels[len(duels)-1]
+}
+
+func (tb *TwitchBot) grantDuelXP(initiator string, challenger string, winner int, isDraw bool) {
+	switch {
+	case isDraw:
+		tb.GrantXP(initiator, tb.Levels.DuelDrawXP)
+		tb.GrantXP(challenger, tb.Levels.DuelDrawXP)
+	case winner == 1:
+		tb.GrantXP(initiator, tb.Levels.DuelWinXP)
+		tb.GrantXP(challenger, tb.Levels.DuelLoseXP)
+	case winner == 2:
+		tb.GrantXP(challenger, tb.Levels.DuelWinXP)
+		tb.GrantXP(initiator, tb.Levels.DuelLoseXP)
+	}
+}
@@ -1,24 +1,51 @@
 package bot
 
 import (
+	config "TelTwBot/Internal/Config"
 	"bufio"
+	"context"
 	"errors"
+	"fmt"
+	"log"
 	"math/rand"
 	"os"
+	"strconv"
 	"strings"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/gempir/go-twitch-irc/v4"
 )
 
+const helloListPageSize = 20
+
 type Greeting struct {
 	Language string
 	Text     string
 }
 
 type Greeter struct {
-	greetings []Greeting
-	rand      *rand.Rand
+	//Replaced as a whole by Reload, so readers always see a complete list
+	greetings atomic.Pointer[[]Greeting]
+	//rand.Rand isn't safe for concurrent use, greetings are also sent from the welcome goroutines
+	mutex sync.Mutex
+	rand  *rand.Rand
 }
 
 func NewGreeter(filename string, rnd *rand.Rand) (*Greeter, error) {
+	greetings, err := LoadGreetings(filename)
+	if err != nil {
+		return nil, err
+	}
+
+	greeter := &Greeter{rand: rnd}
+	greeter.greetings.Store(&greetings)
+	return greeter, nil
+}
+
+// LoadGreetings reads "Language: greeting" lines, lines without a colon are skipped.
+func LoadGreetings(filename string) ([]Greeting, error) {
 	file, err := os.Open(filename)
 	if err != nil {
 		return nil, err
@@ -32,37 +59,214 @@ func NewGreeter(filename string, rnd *rand.Rand) (*Greeter, error) {
 		line := scanner.Text()
 		parts := strings.SplitN(line, ":", 2)
 		if len(parts) == 2 {
-			greetings = append(greetings, Greeting{
+			greeting := Greeting{
 				Language: strings.TrimSpace(parts[0]),
 				Text:     strings.TrimSpace(parts[1]),
-			})
+			}
+			if greeting.Language == "" || greeting.Text == "" {
+				return nil, fmt.Errorf("line '%s' should be 'Language: greeting'", line)
+			}
+			greetings = append(greetings, greeting)
 		}
 	}
 	if err := scanner.Err(); err != nil {
 		return nil, err
 	}
 
-	return &Greeter{
-		greetings: greetings,
-		rand:      rnd,
-	}, nil
+	if len(greetings) == 0 {
+		return nil, errors.New("no greetings loaded")
+	}
+	return greetings, nil
+}
+
+// Reload swaps the greetings for the ones from the file, if the file is invalid the current ones are kept.
+func (g *Greeter) Reload(filename string) (int, error) {
+	greetings, err := LoadGreetings(filename)
+	if err != nil {
+		return 0, err
+	}
+
+	g.greetings.Store(&greetings)
+	return len(greetings), nil
+}
+
+func (g *Greeter) list() []Greeting {
+	return *g.greetings.Load()
 }
 
 func (g *Greeter) GetRandomGreeting() Greeting {
-	if len(g.greetings) == 0 {
+	greetings := g.list()
+	if len(greetings) == 0 {
 		return Greeting{}
 	}
 
-	return g.greetings[g.rand.Intn(len(g.greetings))]
+	g.mutex.Lock()
+	defer g.mutex.Unlock()
+	return greetings[g.rand.Intn(len(greetings))]
 }
 
 func (g *Greeter) Count() int {
-	return len(g.greetings)
+	return len(g.list())
+}
+
+// FindLanguage matches the language case-insensitively, by the full name or the prefix of any of its names
+// (e.g. "khmer" for "Cambodian/Khmer"). An exact match wins over prefix matches.
+func (g *Greeter) FindLanguage(query string) []Greeting {
+	query = strings.ToLower(strings.TrimSpace(query))
+	if query == "" {
+		return nil
+	}
+
+	var matches []Greeting
+	for _, greeting := range g.list() {
+		language := strings.ToLower(greeting.Language)
+		if language == query {
+			return []Greeting{greeting}
+		}
+
+		names := append([]string{language}, strings.FieldsFunc(language, func(r rune) bool { return r == '/' || r == ' ' })...)
+		for _, name := range names {
+			if strings.HasPrefix(name, query) {
+				matches = append(matches, greeting)
+				break
+			}
+		}
+	}
+	return matches
+}
+
+func (g *Greeter) Languages() []string {
+	greetings := g.list()
+	languages := make([]string, 0, len(greetings))
+	for _, greeting := range greetings {
+		languages = append(languages, greeting.Language)
+	}
+	return languages
+}
+
+// GreetingOfTheDay goes through all greetings in a shuffled order, one per day, so nothing repeats until every
+// language was shown. The order of every cycle depends only on the cycle number, so it survives restarts.
+func (g *Greeter) GreetingOfTheDay(day time.Time) Greeting {
+	greetings := g.list()
+	if len(greetings) == 0 {
+		return Greeting{}
+	}
+
+	dayNumber := int(time.Date(day.Year(), day.Month(), day.Day(), 0, 0, 0, 0, time.UTC).Unix() / (24 * 60 * 60))
+	cycle, position := dayNumber/len(greetings), dayNumber%len(greetings)
+
+	return greetings[cycleOrder(cycle, len(greetings))[position]]
+}
+
+// cycleOrder shuffles the greetings of a cycle. If the cycle would start with the last greeting of the previous
+// one, the first two are swapped, so the same greeting isn't shown two days in a row. Two greetings just alternate.
+func cycleOrder(cycle int, count int) []int {
+	if count == 2 {
+		cycle = 0
+	}
+
+	order := rand.New(rand.NewSource(int64(cycle))).Perm(count)
+	if count > 2 {
+		//The swap never touches the last position, so the previous cycle ends with the same greeting after its own swap
+		previous := rand.New(rand.NewSource(int64(cycle - 1))).Perm(count)
+		if order[0] == previous[count-1] {
+			order[0], order[1] = order[1], order[0]
+		}
+	}
+	return order
+}
+
+func (tb *TwitchBot) handleHelloCommand(message twitch.PrivateMessage) {
+	username := message.User.Name
+	args := strings.Fields(message.Message)
+
+	var response string
+	switch {
+	case len(args) == 0:
+		response = formatGreeting(username, tb.preferredGreeting(username))
+	case strings.ToLower(args[0]) == "list":
+		response = tb.helloListPage(args[1:])
+	case strings.ToLower(args[0]) == "learn":
+		greeting := tb.Greeter.GreetingOfTheDay(config.ChannelToday())
+		response = fmt.Sprintf("📚 Greeting of the day: %s - %s", greeting.Language, greeting.Text)
+	case strings.ToLower(args[0]) == "random":
+		if err := tb.Repos.Greetings.SetGreetingLanguage(context.Background(), username, ""); err != nil {
+			log.Printf("[%s]❌Failed to reset greeting language for %s: %v", time.Now().Format("15:04:05"), username, err)
+		}
+		response = formatGreeting(username, tb.Greeter.GetRandomGreeting())
+	default:
+		response = tb.greetInLanguage(username, strings.Join(args, " "))
+	}
+
+	SayAndLog(tb.Client, tb.Channel, response, tb.BotUsername)
+	log.Printf("[%s] ✅Processed !hello command for %s.", time.Now().Format("15:04:05"), username)
+}
+
+// preferredGreeting uses the language saved by !hello <language>, otherwise a random one.
+func (tb *TwitchBot) preferredGreeting(username string) Greeting {
+	language, err := tb.Repos.Greetings.GetGreetingLanguage(context.Background(), username)
+	if err != nil {
+		log.Printf("[%s]❌Failed to get greeting language for %s: %v", time.Now().Format("15:04:05"), username, err)
+	}
+
+	if matches := tb.Greeter.FindLanguage(language); len(matches) == 1 {
+		return matches[0]
+	}
+	return tb.Greeter.GetRandomGreeting()
 }
 
-func (g *Greeter) Validate() error {
-	if len(g.greetings) == 0 {
-		return errors.New("no greetings loaded")
+func (tb *TwitchBot) greetInLanguage(username string, query string) string {
+	matches := tb.Greeter.FindLanguage(query)
+	switch {
+	case len(matches) == 0:
+		return fmt.Sprintf("@%s, I don't know how to say hello in %s. See !hello list", username, query)
+	case len(matches) > 1:
+		languages := make([]string, 0, len(matches))
+		for _, greeting := range matches {
+			languages = append(languages, greeting.Language)
+		}
+		return fmt.Sprintf("@%s, did you mean: %s?", username, truncate(strings.Join(languages, ", "), 300))
+	}
+
+	greeting := matches[0]
+	response := formatGreeting(username, greeting)
+
+	saved, err := tb.Repos.Greetings.GetGreetingLanguage(context.Background(), username)
+	if err != nil {
+		log.Printf("[%s]❌Failed to get greeting language for %s: %v", time.Now().Format("15:04:05"), username, err)
+		return response
 	}
-	return nil
+	if saved == greeting.Language {
+		return response
+	}
+
+	if err := tb.Repos.Greetings.SetGreetingLanguage(context.Background(), username, greeting.Language); err != nil {
+		log.Printf("[%s]❌Failed to save greeting language for %s: %v", time.Now().Format("15:04:05"), username, err)
+		return response
+	}
+	return response + fmt.Sprintf(" From now on !hello will greet you in %s, !hello random to reset.", greeting.Language)
+}
+
+func (tb *TwitchBot) helloListPage(args []string) string {
+	languages := tb.Greeter.Languages()
+	pages := max((len(languages)+helloListPageSize-1)/helloListPageSize, 1)
+
+	page := 1
+	if len(args) > 0 {
+		if n, err := strconv.Atoi(args[0]); err == nil {
+			page = min(max(n, 1), pages)
+		}
+	}
+
+	from := (page - 1) * helloListPageSize
+	to := min(from+helloListPageSize, len(languages))
+	response := fmt.Sprintf("🌍 Languages (page %d/%d): %s.", page, pages, strings.Join(languages[from:to], ", "))
+	if page < pages {
+		response += fmt.Sprintf(" Next: !hello list %d", page+1)
+	}
+	return response
+}
+
+func formatGreeting(username string, greeting Greeting) string {
+	return fmt.Sprintf("@%s, %s * means 'hello' in %s *", username, greeting.Text, greeting.Language)
 }
@@ -0,0 +1,154 @@
+package bot
+
+import (
+	db "TelTwBot/Internal/Database"
+	"context"
+	"fmt"
+	"math/rand"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func newTestGreeter(greetings ...Greeting) *Greeter {
+	greeter := &Greeter{rand: rand.New(rand.NewSource(1))}
+	greeter.greetings.Store(&greetings)
+	return greeter
+}
+
+func TestFindLanguage(t *testing.T) {
+	greeter := newTestGreeter(
+		Greeting{"Cambodian/Khmer", "Suostei"},
+		Greeting{"Spanish", "Hola"},
+		Greeting{"Scottish Gaelic", "Halò"},
+		Greeting{"Scots", "Hullo"},
+		Greeting{"Swedish", "Hej"},
+	)
+
+	for _, tc := range []struct {
+		query    string
+		expected []string
+	}{
+		{"spanish", []string{"Spanish"}},
+		{"  SPANISH ", []string{"Spanish"}},
+		{"khmer", []string{"Cambodian/Khmer"}},
+		{"cambodian/khmer", []string{"Cambodian/Khmer"}},
+		{"gaelic", []string{"Scottish Gaelic"}},
+		{"scots", []string{"Scots"}},
+		{"sc", []string{"Scottish Gaelic", "Scots"}},
+		{"s", []string{"Spanish", "Scottish Gaelic", "Scots", "Swedish"}},
+		{"klingon", nil},
+		{"", nil},
+	} {
+		t.Run(tc.query, func(t *testing.T) {
+			var languages []string
+			for _, greeting := range greeter.FindLanguage(tc.query) {
+				languages = append(languages, greeting.Language)
+			}
+			require.Equal(t, tc.expected, languages)
+		})
+	}
+}
+
+func TestGreetingOfTheDay(t *testing.T) {
+	start := time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)
+
+	for _, count := range []int{1, 2, 3, 5, 50} {
+		t.Run(fmt.Sprint(count), func(t *testing.T) {
+			var greetings []Greeting
+			for i := range count {
+				greetings = append(greetings, Greeting{Language: fmt.Sprint(i), Text: "hi"})
+			}
+			greeter := newTestGreeter(greetings...)
+
+			var previous Greeting
+			seen := make(map[string]int)
+			for day := range 40 * count {
+				greeting := greeter.GreetingOfTheDay(start.AddDate(0, 0, day))
+				if count > 1 && day > 0 {
+					require.NotEqual(t, previous, greeting, "day %d repeats the greeting", day)
+				}
+				previous = greeting
+				seen[greeting.Language]++
+			}
+
+			//Every greeting is shown once per cycle, so equally often over whole cycles (the start isn't aligned to one)
+			for _, greeting := range greetings {
+				require.InDelta(t, 40, seen[greeting.Language], 1, greeting.Language)
+			}
+
+			//The time of the day and restarts don't change the greeting
+			day := start.AddDate(0, 0, 17)
+			require.Equal(t, greeter.GreetingOfTheDay(day), greeter.GreetingOfTheDay(day.Add(23*time.Hour)))
+			require.Equal(t, greeter.GreetingOfTheDay(day), newTestGreeter(greetings...).GreetingOfTheDay(day))
+		})
+	}
+
+	require.Equal(t, Greeting{}, newTestGreeter().GreetingOfTheDay(start))
+}
+
+func TestHelloListPage(t *testing.T) {
+	var greetings []Greeting
+	for i := 1; i <= 45; i++ {
+		greetings = append(greetings, Greeting{Language: fmt.Sprintf("L%d", i), Text: "hi"})
+	}
+	tb := &TwitchBot{Greeter: newTestGreeter(greetings...)}
+
+	for _, tc := range []struct {
+		name     string
+		args     []string
+		expected string
+	}{
+		{"first page", nil, "🌍 Languages (page 1/3): L1, L2, L3, L4, L5, L6, L7, L8, L9, L10, L11, L12, L13, L14, L15, L16, L17, L18, L19, L20. Next: !hello list 2"},
+		{"middle page", []string{"2"}, "🌍 Languages (page 2/3): L21, L22, L23, L24, L25, L26, L27, L28, L29, L30, L31, L32, L33, L34, L35, L36, L37, L38, L39, L40. Next: !hello list 3"},
+		{"last page", []string{"3"}, "🌍 Languages (page 3/3): L41, L42, L43, L44, L45."},
+		{"past the end", []string{"9"}, "🌍 Languages (page 3/3): L41, L42, L43, L44, L45."},
+		{"before the start", []string{"-1"}, "🌍 Languages (page 1/3): L1, L2, L3, L4, L5, L6, L7, L8, L9, L10, L11, L12, L13, L14, L15, L16, L17, L18, L19, L20. Next: !hello list 2"},
+		{"not a number", []string{"two"}, "🌍 Languages (page 1/3): L1, L2, L3, L4, L5, L6, L7, L8, L9, L10, L11, L12, L13, L14, L15, L16, L17, L18, L19, L20. Next: !hello list 2"},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			require.Equal(t, tc.expected, tb.helloListPage(tc.args))
+		})
+	}
+
+	tb = &TwitchBot{Greeter: newTestGreeter(Greeting{"English", "Hello"})}
+	require.Equal(t, "🌍 Languages (page 1/1): English.", tb.helloListPage(nil))
+}
+
+// greetingLanguages keeps the !hello <language> preferences.
+type greetingLanguages struct {
+	db.GreetingRepository
+	languages map[string]string
+}
+
+func (g *greetingLanguages) GetGreetingLanguage(ctx context.Context, username string) (string, error) {
+	return g.languages[username], nil
+}
+
+func (g *greetingLanguages) SetGreetingLanguage(ctx context.Context, username string, language string) error {
+	g.languages[username] = language
+	return nil
+}
+
+func TestGreetInLanguage(t *testing.T) {
+	preferences := &greetingLanguages{languages: make(map[string]string)}
+	tb, _ := newTestBot(func(repos *db.Repositories) { repos.Greetings = preferences })
+	tb.Greeter = newTestGreeter(
+		Greeting{"Spanish", "Hola"},
+		Greeting{"Scottish Gaelic", "Halò"},
+		Greeting{"Scots", "Hullo"},
+	)
+
+	require.Equal(t, "@viewer, I don't know how to say hello in klingon. See !hello list", tb.greetInLanguage("viewer", "klingon"))
+	require.Equal(t, "@viewer, did you mean: Scottish Gaelic, Scots?", tb.greetInLanguage("viewer", "sc"))
+	require.Empty(t, preferences.languages, "nothing is saved for unknown or ambiguous languages")
+
+	require.Equal(t, "@viewer, Hola * means 'hello' in Spanish * From now on !hello will greet you in Spanish, !hello random to reset.",
+		tb.greetInLanguage("viewer", "spanish"))
+	require.Equal(t, "Spanish", preferences.languages["viewer"])
+	require.Equal(t, "@viewer, Hola * means 'hello' in Spanish *", tb.greetInLanguage("viewer", "SPANISH"), "the saved language isn't announced again")
+
+	//Plain !hello uses the saved language
+	require.Equal(t, Greeting{"Spanish", "Hola"}, tb.preferredGreeting("viewer"))
+}
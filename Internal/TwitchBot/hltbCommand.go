@@ -0,0 +1,55 @@
+package bot
+
+import (
+	hltb "TelTwBot/Internal/HLTB"
+	"context"
+	"errors"
+	"fmt"
+	"log"
+	"time"
+
+	"github.com/Gladarfin/GetInfoFromHLTB/models"
+	"github.com/gempir/go-twitch-irc/v4"
+)
+
+// FindHowLongToBeat searches "<title> [#N]", without a title it searches the game set on the channel.
+// It returns the top matches and the number of the picked one (1-based).
+func (tb *TwitchBot) FindHowLongToBeat(args string) ([]models.GameData, int, error) {
+	title, pick := hltb.ParseQuery(args)
+	if title == "" {
+		gameName, err := tb.Helix.GetChannelGameName(tb.Channel)
+		if err != nil {
+			log.Printf("[%s] ❌ Couldn't get the channel game for HLTB: %v", time.Now().Format("15:04:05"), err)
+			return nil, 0, errors.New("couldn't get the current game, try it with a title")
+		}
+		if gameName == "" {
+			return nil, 0, errors.New("no game is set on the channel, try it with a title")
+		}
+		title = gameName
+	}
+
+	games, err := tb.HLTB.Search(context.Background(), title)
+	if err != nil {
+		log.Printf("[%s] ❌ HLTB search failed: %v", time.Now().Format("15:04:05"), err)
+		return nil, 0, fmt.Errorf("error searching for '%s'", title)
+	}
+	if len(games) == 0 {
+		return nil, 0, fmt.Errorf("no results found for '%s'", title)
+	}
+
+	if _, err := hltb.Pick(games, pick); err != nil {
+		return nil, 0, err
+	}
+	return games, pick, nil
+}
+
+func (tb *TwitchBot) handleHLTBCommand(message twitch.PrivateMessage) {
+	games, pick, err := tb.FindHowLongToBeat(message.Message)
+	if err != nil {
+		SayAndLog(tb.Client, tb.Channel, fmt.Sprintf("@%s %s. Usage: !hl [game title] [#N]", message.User.Name, err), tb.BotUsername)
+		return
+	}
+
+	SayAndLog(tb.Client, tb.Channel, fmt.Sprintf("@%s %s", message.User.Name, hltb.FormatLine(games, pick)), tb.BotUsername)
+	log.Printf("[%s] ✅ HLTB: %s", time.Now().Format("15:04:05"), games[pick-1].GameName)
+}
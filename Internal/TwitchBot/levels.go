@@ -0,0 +1,121 @@
+package bot
+
+import (
+	achievements "TelTwBot/Internal/Achievements"
+	"context"
+	"fmt"
+	"log"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/gempir/go-twitch-irc/v4"
+)
+
+const chatXPInterval = time.Minute
+
+type XPTracker struct {
+	mutex      sync.Mutex
+	lastChatXP map[string]time.Time
+	attendance map[string]string
+}
+
+// takeChatXP reports whether the user can get chat XP now (once per chatXPInterval) and if the attendance for today
+// is not checked yet. Both are kept in memory, attendance is double-checked in DB so restarts don't grant it twice.
+func (xt *XPTracker) takeChatXP(username string, now time.Time) (chatXP bool, checkAttendance bool) {
+	xt.mutex.Lock()
+	defer xt.mutex.Unlock()
+
+	if xt.lastChatXP == nil {
+		xt.lastChatXP = make(map[string]time.Time)
+		xt.attendance = make(map[string]string)
+	}
+
+	if now.Sub(xt.lastChatXP[username]) < chatXPInterval {
+		return false, false
+	}
+	xt.lastChatXP[username] = now
+
+	today := now.Format("2006-01-02")
+	if xt.attendance[username] != today {
+		xt.attendance[username] = today
+		checkAttendance = true
+	}
+
+	return true, checkAttendance
+}
+
+func (tb *TwitchBot) grantChatXP(username string) {
+	now := time.Now()
+	chatXP, checkAttendance := tb.XP.takeChatXP(username, now)
+	if !chatXP {
+		return
+	}
+
+	tb.GrantXP(username, tb.Levels.ChatXP)
+
+	if checkAttendance {
+		claimed, err := tb.Repos.Levels.ClaimAttendance(context.Background(), username, now)
+		if err != nil {
+			log.Printf("[%s]❌Failed to check attendance for %s: %v", now.Format("15:04:05"), username, err)
+			return
+		}
+		if claimed {
+			tb.GrantXP(username, tb.Levels.AttendanceXP)
+			tb.trackAchievement(achievements.Event{Username: username, Type: achievements.EventAttendance})
+		}
+	}
+}
+
+// GrantXP adds XP to the user and announces level-ups in chat.
+func (tb *TwitchBot) GrantXP(username string, amount int) {
+	if amount <= 0 {
+		return
+	}
+
+	result, err := tb.Repos.Levels.AddXP(context.Background(), username, amount, tb.Levels.LevelForXP, tb.Levels.FreePointsPerLevel)
+	if err != nil {
+		log.Printf("[%s]❌Failed to add %d XP to %s: %v", time.Now().Format("15:04:05"), amount, username, err)
+		return
+	}
+
+	if result.NewLevel > result.OldLevel {
+		SayAndLog(tb.Client, tb.Channel,
+			fmt.Sprintf("⭐ @%s reached level %d! +%d free point(s), spend them with !up.", username, result.NewLevel, result.FreePointsGranted),
+			tb.BotUsername)
+	}
+}
+
+func (tb *TwitchBot) handleLevelCommand(message twitch.PrivateMessage) {
+	username := message.User.Name
+	if args := strings.Fields(message.Message); len(args) > 0 {
+		username = strings.ToLower(strings.TrimPrefix(args[0], "@"))
+	}
+
+	level, err := tb.Repos.Levels.GetUserLevel(context.Background(), username)
+	if err != nil {
+		log.Printf("[%s]❌Failed to get level for %s: %v", time.Now().Format("15:04:05"), username, err)
+		SayAndLog(tb.Client, tb.Channel, "Sorry, couldn't retrieve the level. Please try again later.", tb.BotUsername)
+		return
+	}
+
+	if level == nil {
+		SayAndLog(tb.Client, tb.Channel, fmt.Sprintf("%s has no XP yet.", username), tb.BotUsername)
+		return
+	}
+
+	SayAndLog(tb.Client, tb.Channel, formatLevelProgress(username, level.XP, level.Level, tb.Levels.XPForLevel, tb.Levels.MaxLevel), tb.BotUsername)
+	log.Printf("[%s] ✅Processed !level command for %s.", time.Now().Format("15:04:05"), message.User.Name)
+}
+
+func formatLevelProgress(username string, xp int, level int, xpForLevel func(int) int, maxLevel int) string {
+	if level >= maxLevel {
+		return fmt.Sprintf("%s is level %d (max level), %d XP.", username, level, xp)
+	}
+
+	levelStart := xpForLevel(level)
+	levelEnd := xpForLevel(level + 1)
+	percent := (xp - levelStart) * 100 / max(levelEnd-levelStart, 1)
+
+	return fmt.Sprintf("%s is level %d: %d/%d XP to level %d (%d%%).", username, level, xp-levelStart, levelEnd-levelStart, level+1, percent)
+}
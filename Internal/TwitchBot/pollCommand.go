@@ -0,0 +1,342 @@
+package bot
+
+import (
+	constants "TelTwBot/Internal/Config/Constants"
+	db "TelTwBot/Internal/Database"
+	twBotCommands "TelTwBot/Internal/TwitchBot/Commands"
+	"context"
+	"errors"
+	"fmt"
+	"log"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/gempir/go-twitch-irc/v4"
+)
+
+const (
+	defaultPollDuration = 60 * time.Second
+	minPollDuration     = 15 * time.Second
+	maxPollDuration     = 30 * time.Minute
+	maxPollOptions      = 10
+	//Helix polls accept up to 5 choices with titles up to 25 characters and a title up to 60 characters
+	maxNativePollOptions     = 5
+	maxNativePollChoiceTitle = 25
+	maxNativePollTitle       = 60
+)
+
+type ChatPoll struct {
+	Question  string
+	Options   []string
+	Votes     map[string]int
+	StartedBy string
+	StartedAt time.Time
+	Timer     *time.Timer
+	//The Twitch poll mirrored from this one, NativeTimer reports its results once Twitch closes it
+	NativePollID        string
+	NativeBroadcasterID string
+	NativeTimer         *time.Timer
+}
+
+func (tb *TwitchBot) handlePollCommand(message twitch.PrivateMessage) {
+	input := strings.TrimSpace(message.Message)
+
+	if input == "" {
+		tb.PollMutex.Lock()
+		poll := tb.CurrentPoll
+		var response string
+		if poll == nil {
+			response = "There is no active poll."
+		} else {
+			response = fmt.Sprintf("📊 %s %s Vote with !vote <number>.", poll.Question, formatPollOptions(poll.Options))
+		}
+		tb.PollMutex.Unlock()
+		SayAndLog(tb.Client, tb.Channel, response, tb.BotUsername)
+		return
+	}
+
+	if !twBotCommands.IsModerator(&message.User) {
+		SayAndLog(tb.Client, tb.Channel, fmt.Sprintf("@%s, only moderators can start polls.", message.User.Name), tb.BotUsername)
+		return
+	}
+
+	if strings.ToLower(input) == "end" {
+		tb.finishPoll(true)
+		return
+	}
+
+	question, options, duration, err := parsePollInput(input)
+	if err != nil {
+		SayAndLog(tb.Client, tb.Channel, fmt.Sprintf("@%s, %s. Usage: !poll \"Question?\" opt1 | opt2 | opt3 60s", message.User.Name, err), tb.BotUsername)
+		return
+	}
+
+	tb.PollMutex.Lock()
+	if tb.CurrentPoll != nil {
+		tb.PollMutex.Unlock()
+		SayAndLog(tb.Client, tb.Channel, "There is already an active poll, end it first with !poll end.", tb.BotUsername)
+		return
+	}
+
+	poll := &ChatPoll{
+		Question:  question,
+		Options:   options,
+		Votes:     make(map[string]int),
+		StartedBy: message.User.Name,
+		StartedAt: time.Now(),
+	}
+	poll.Timer = time.AfterFunc(duration, func() { tb.finishPoll(false) })
+	tb.CurrentPoll = poll
+	tb.PollMutex.Unlock()
+
+	SayAndLog(tb.Client, tb.Channel,
+		fmt.Sprintf("📊 Poll: %s %s Vote with !vote <number> or just type the number! You have %s.", question, formatPollOptions(options), duration),
+		tb.BotUsername)
+	log.Printf("[%s] ✅Processed !poll command for %s.", time.Now().Format("15:04:05"), message.User.Name)
+
+	if constants.MirrorPollsToHelix {
+		go tb.startNativePoll(poll, duration)
+	}
+}
+
+// registerPollVote counts a vote from "!vote 2" args or a bare "2" message. It returns false if the
+// text is not a valid vote, so ordinary chat numbers are ignored when there is no poll.
+func (tb *TwitchBot) registerPollVote(username string, text string) bool {
+	choice, err := strconv.Atoi(strings.TrimSpace(text))
+	if err != nil {
+		return false
+	}
+
+	tb.PollMutex.Lock()
+	defer tb.PollMutex.Unlock()
+
+	if tb.CurrentPoll == nil || choice < 1 || choice > len(tb.CurrentPoll.Options) {
+		return false
+	}
+
+	//Users can change their mind, only the last vote counts
+	tb.CurrentPoll.Votes[username] = choice - 1
+	return true
+}
+
+// finishPoll announces and saves the results of the chat poll. When a moderator ends it early, the mirrored
+// Twitch poll is terminated as well, otherwise Twitch closes it on its own and NativeTimer reports it.
+func (tb *TwitchBot) finishPoll(endedEarly bool) {
+	tb.PollMutex.Lock()
+	poll := tb.CurrentPoll
+	tb.CurrentPoll = nil
+	var nativePollID, broadcasterID string
+	var nativeTimer *time.Timer
+	if poll != nil {
+		nativePollID = poll.NativePollID
+		broadcasterID = poll.NativeBroadcasterID
+		nativeTimer = poll.NativeTimer
+	}
+	tb.PollMutex.Unlock()
+
+	if poll == nil {
+		return
+	}
+	poll.Timer.Stop()
+
+	votes := make([]int, len(poll.Options))
+	for _, choice := range poll.Votes {
+		votes[choice]++
+	}
+
+	SayAndLog(tb.Client, tb.Channel, formatPollResults(poll.Question, poll.Options, votes), tb.BotUsername)
+
+	_, err := tb.Repos.Polls.SavePollResult(context.Background(), db.PollResult{
+		Question:  poll.Question,
+		StartedBy: poll.StartedBy,
+		StartedAt: poll.StartedAt,
+		EndedAt:   time.Now(),
+		Options:   poll.Options,
+		Votes:     votes,
+	})
+	if err != nil {
+		log.Printf("[%s]❌Failed to save poll results: %v", time.Now().Format("15:04:05"), err)
+	}
+
+	//Stop fails if the native results are already being reported
+	if endedEarly && nativeTimer != nil && nativeTimer.Stop() {
+		go tb.finishNativePoll(poll, broadcasterID, nativePollID, true)
+	}
+}
+
+func (tb *TwitchBot) startNativePoll(poll *ChatPoll, duration time.Duration) {
+	if len(poll.Options) > maxNativePollOptions {
+		log.Printf("[%s] Native poll skipped: Twitch supports only %d choices.", time.Now().Format("15:04:05"), maxNativePollOptions)
+		return
+	}
+
+	broadcaster, err := tb.Helix.GetUserByLogin(tb.Channel)
+	if err != nil {
+		log.Printf("[%s]❌Failed to get broadcaster for native poll: %v", time.Now().Format("15:04:05"), err)
+		return
+	}
+
+	if !tb.Helix.CanManagePolls(broadcaster.ID) {
+		log.Printf("[%s] Native poll skipped: Helix token isn't a broadcaster token with channel:manage:polls scope.", time.Now().Format("15:04:05"))
+		return
+	}
+
+	var choices []string
+	for _, option := range poll.Options {
+		choices = append(choices, truncate(option, maxNativePollChoiceTitle))
+	}
+
+	pollID, err := tb.Helix.CreatePoll(broadcaster.ID, truncate(poll.Question, maxNativePollTitle), choices, int(duration.Seconds()))
+	if err != nil {
+		log.Printf("[%s]❌Failed to create native poll: %v", time.Now().Format("15:04:05"), err)
+		return
+	}
+
+	tb.PollMutex.Lock()
+	defer tb.PollMutex.Unlock()
+
+	//The chat poll was ended while Twitch was creating this one
+	if tb.CurrentPoll != poll {
+		go tb.finishNativePoll(poll, broadcaster.ID, pollID, true)
+		return
+	}
+
+	poll.NativePollID = pollID
+	poll.NativeBroadcasterID = broadcaster.ID
+	//Give Twitch a few seconds to close the poll and count the votes
+	poll.NativeTimer = time.AfterFunc(duration+5*time.Second, func() {
+		tb.finishNativePoll(poll, broadcaster.ID, pollID, false)
+	})
+}
+
+// finishNativePoll announces and saves the results of the Twitch poll, terminating it first if it's still running.
+func (tb *TwitchBot) finishNativePoll(poll *ChatPoll, broadcasterID string, pollID string, terminate bool) {
+	var nativePoll *twBotCommands.HelixPoll
+	var err error
+	if terminate {
+		nativePoll, err = tb.Helix.EndPoll(broadcasterID, pollID)
+	} else {
+		nativePoll, err = tb.Helix.GetPoll(broadcasterID, pollID)
+	}
+	if err != nil {
+		log.Printf("[%s]❌Failed to get native poll results: %v", time.Now().Format("15:04:05"), err)
+		return
+	}
+
+	var options []string
+	var votes []int
+	for _, choice := range nativePoll.Choices {
+		options = append(options, choice.Title)
+		votes = append(votes, choice.Votes)
+	}
+
+	SayAndLog(tb.Client, tb.Channel, "Twitch "+formatPollResults(nativePoll.Title, options, votes), tb.BotUsername)
+
+	_, err = tb.Repos.Polls.SavePollResult(context.Background(), db.PollResult{
+		Question:     nativePoll.Title,
+		StartedBy:    poll.StartedBy,
+		StartedAt:    poll.StartedAt,
+		EndedAt:      time.Now(),
+		NativePollID: pollID,
+		Options:      options,
+		Votes:        votes,
+	})
+	if err != nil {
+		log.Printf("[%s]❌Failed to save native poll results: %v", time.Now().Format("15:04:05"), err)
+	}
+}
+
+func parsePollInput(input string) (string, []string, time.Duration, error) {
+	var question, rest string
+
+	if strings.HasPrefix(input, "\"") {
+		end := strings.Index(input[1:], "\"")
+		if end < 0 {
+			return "", nil, 0, errors.New("the question has no closing quote")
+		}
+		question = strings.TrimSpace(input[1 : end+1])
+		rest = input[end+2:]
+	} else {
+		end := strings.Index(input, "?")
+		if end < 0 {
+			return "", nil, 0, errors.New("put the question in quotes or end it with '?'")
+		}
+		question = strings.TrimSpace(input[:end+1])
+		rest = input[end+1:]
+	}
+
+	if question == "" {
+		return "", nil, 0, errors.New("the question is empty")
+	}
+
+	duration := defaultPollDuration
+	if fields := strings.Fields(rest); len(fields) > 0 {
+		last := fields[len(fields)-1]
+		if parsed, err := time.ParseDuration(last); err == nil {
+			duration = min(max(parsed, minPollDuration), maxPollDuration)
+			rest = rest[:strings.LastIndex(rest, last)]
+		}
+	}
+
+	var options []string
+	for _, option := range strings.Split(rest, "|") {
+		if option = strings.TrimSpace(option); option != "" {
+			options = append(options, option)
+		}
+	}
+
+	if len(options) < 2 || len(options) > maxPollOptions {
+		return "", nil, 0, fmt.Errorf("a poll needs from 2 to %d options", maxPollOptions)
+	}
+
+	return question, options, duration, nil
+}
+
+func formatPollOptions(options []string) string {
+	var entries []string
+	for i, option := range options {
+		entries = append(entries, fmt.Sprintf("%d) %s", i+1, option))
+	}
+	return strings.Join(entries, " | ")
+}
+
+func formatPollResults(question string, options []string, votes []int) string {
+	total := 0
+	best := 0
+	for _, count := range votes {
+		total += count
+		best = max(best, count)
+	}
+
+	var entries []string
+	var winners []string
+	for i, option := range options {
+		percent := 0
+		if total > 0 {
+			percent = votes[i] * 100 / total
+		}
+		entries = append(entries, fmt.Sprintf("%s: %d (%d%%)", option, votes[i], percent))
+		if best > 0 && votes[i] == best {
+			winners = append(winners, option)
+		}
+	}
+
+	result := fmt.Sprintf("📊 Poll ended: %s %s", question, strings.Join(entries, " | "))
+	switch len(winners) {
+	case 0:
+		return result + " Nobody voted."
+	case 1:
+		return result + fmt.Sprintf(" Winner: %s!", winners[0])
+	default:
+		return result + fmt.Sprintf(" It's a tie: %s!", strings.Join(winners, ", "))
+	}
+}
+
+func truncate(text string, maxLength int) string {
+	runes := []rune(text)
+	if len(runes) <= maxLength {
+		return text
+	}
+	return string(runes[:maxLength])
+}
@@ -0,0 +1,48 @@
+package bot
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestParsePollInput(t *testing.T) {
+	question, options, duration, err := parsePollInput(`"Next game" Hades | Celeste 2m`)
+	require.NoError(t, err)
+	require.Equal(t, "Next game", question)
+	require.Equal(t, []string{"Hades", "Celeste"}, options)
+	require.Equal(t, 2*time.Minute, duration)
+
+	question, options, duration, err = parsePollInput("Pizza or sushi? pizza | sushi")
+	require.NoError(t, err)
+	require.Equal(t, "Pizza or sushi?", question)
+	require.Equal(t, []string{"pizza", "sushi"}, options)
+	require.Equal(t, defaultPollDuration, duration)
+
+	_, _, duration, err = parsePollInput("Short? a | b 1s")
+	require.NoError(t, err)
+	require.Equal(t, minPollDuration, duration)
+
+	for input, message := range map[string]string{
+		`"Next game Hades | Celeste`: "the question has no closing quote",
+		"Next game Hades | Celeste":  "put the question in quotes or end it with '?'",
+		`"" a | b`:                   "the question is empty",
+		"Only one? a":                "a poll needs from 2 to 10 options",
+	} {
+		_, _, _, err := parsePollInput(input)
+		require.EqualError(t, err, message, input)
+	}
+}
+
+func TestRegisterPollVote(t *testing.T) {
+	tb := &TwitchBot{}
+	require.False(t, tb.registerPollVote("viewer", "1"), "there is no poll")
+
+	tb.CurrentPoll = &ChatPoll{Options: []string{"a", "b"}, Votes: make(map[string]int)}
+	require.True(t, tb.registerPollVote("viewer", " 2 "))
+	require.True(t, tb.registerPollVote("viewer", "1"))
+	require.False(t, tb.registerPollVote("viewer", "3"))
+	require.False(t, tb.registerPollVote("viewer", "gg"))
+	require.Equal(t, map[string]int{"viewer": 0}, tb.CurrentPoll.Votes, "only the last vote counts")
+}
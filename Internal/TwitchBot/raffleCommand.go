@@ -0,0 +1,315 @@
+package bot
+
+import (
+	db "TelTwBot/Internal/Database"
+	twBotCommands "TelTwBot/Internal/TwitchBot/Commands"
+	"context"
+	"fmt"
+	"log"
+	"math/rand/v2"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/gempir/go-twitch-irc/v4"
+)
+
+const (
+	defaultRaffleDuration   = 2 * time.Minute
+	raffleClaimWindow       = time.Minute
+	subscriberRaffleTickets = 2
+
+	raffleWeightingNone = "none"
+	raffleWeightingSub  = "sub"
+	raffleWeightingLuck = "luck"
+)
+
+type Raffle struct {
+	ID          int
+	Keyword     string
+	Weighting   string
+	Entries     map[string]int
+	EntriesOpen bool
+	Timer       *time.Timer
+	Candidate   string
+	ClaimTimer  *time.Timer
+}
+
+func (tb *TwitchBot) handleRaffleCommand(message twitch.PrivateMessage) {
+	const usage = "Usage: !raffle start <keyword> [duration] [sub|luck] | !raffle draw | !raffle cancel"
+
+	args := strings.Fields(message.Message)
+	if len(args) == 0 {
+		SayAndLog(tb.Client, tb.Channel, tb.raffleStatus(), tb.BotUsername)
+		return
+	}
+
+	if !twBotCommands.IsModerator(&message.User) {
+		SayAndLog(tb.Client, tb.Channel, fmt.Sprintf("@%s, only moderators can manage raffles.", message.User.Name), tb.BotUsername)
+		return
+	}
+
+	switch strings.ToLower(args[0]) {
+	case "start":
+		if len(args) < 2 || len(args) > 4 {
+			SayAndLog(tb.Client, tb.Channel, usage, tb.BotUsername)
+			return
+		}
+		tb.startRaffle(message.User.Name, args[1], args[2:])
+	case "draw":
+		tb.drawRaffle()
+	case "cancel":
+		tb.cancelRaffle()
+	default:
+		SayAndLog(tb.Client, tb.Channel, usage, tb.BotUsername)
+	}
+	log.Printf("[%s] ✅Processed !raffle command for %s.", time.Now().Format("15:04:05"), message.User.Name)
+}
+
+func (tb *TwitchBot) startRaffle(startedBy string, keyword string, options []string) {
+	duration := defaultRaffleDuration
+	weighting := raffleWeightingNone
+	for _, option := range options {
+		switch option = strings.ToLower(option); option {
+		case raffleWeightingSub, raffleWeightingLuck, raffleWeightingNone:
+			weighting = option
+		default:
+			parsed, err := time.ParseDuration(option)
+			if err != nil || parsed <= 0 {
+				SayAndLog(tb.Client, tb.Channel, fmt.Sprintf("Invalid raffle option '%s'.", option), tb.BotUsername)
+				return
+			}
+			duration = parsed
+		}
+	}
+
+	tb.RaffleMutex.Lock()
+	if tb.CurrentRaffle != nil {
+		tb.RaffleMutex.Unlock()
+		SayAndLog(tb.Client, tb.Channel, "There is already an active raffle.", tb.BotUsername)
+		return
+	}
+	//Reserve the slot, so the second !raffle start can't sneak in while we create the raffle in DB
+	tb.CurrentRaffle = &Raffle{}
+	tb.RaffleMutex.Unlock()
+
+	raffleID, err := tb.Repos.Raffles.CreateRaffle(context.Background(), keyword, weighting, startedBy)
+	if err != nil {
+		log.Printf("[%s]❌Failed to start raffle: %v", time.Now().Format("15:04:05"), err)
+		tb.RaffleMutex.Lock()
+		tb.CurrentRaffle = nil
+		tb.RaffleMutex.Unlock()
+		SayAndLog(tb.Client, tb.Channel, "Failed to start raffle.", tb.BotUsername)
+		return
+	}
+
+	tb.RaffleMutex.Lock()
+	tb.CurrentRaffle = &Raffle{
+		ID:          raffleID,
+		Keyword:     strings.ToLower(keyword),
+		Weighting:   weighting,
+		Entries:     make(map[string]int),
+		EntriesOpen: true,
+		Timer:       time.AfterFunc(duration, tb.drawRaffle),
+	}
+	tb.RaffleMutex.Unlock()
+
+	announce := fmt.Sprintf("🎉 Raffle #%d started! Type %s in chat to enter, you have %s.", raffleID, keyword, duration)
+	switch weighting {
+	case raffleWeightingSub:
+		announce += fmt.Sprintf(" Subscribers get %d tickets!", subscriberRaffleTickets)
+	case raffleWeightingLuck:
+		announce += " Your luck stat is your number of tickets!"
+	}
+	SayAndLog(tb.Client, tb.Channel, announce, tb.BotUsername)
+}
+
+// handleRaffleMessage processes keyword entries and prize claims. It returns true if the message was consumed.
+func (tb *TwitchBot) handleRaffleMessage(message twitch.PrivateMessage) bool {
+	tb.RaffleMutex.Lock()
+	raffle := tb.CurrentRaffle
+	if raffle == nil || raffle.ID == 0 {
+		tb.RaffleMutex.Unlock()
+		return false
+	}
+
+	username := message.User.Name
+	if raffle.Candidate != "" && raffle.Candidate == username {
+		raffle.ClaimTimer.Stop()
+		tb.CurrentRaffle = nil
+		tb.RaffleMutex.Unlock()
+
+		tb.finishRaffleWithWinner(raffle.ID, username)
+		return false
+	}
+
+	if !raffle.EntriesOpen || strings.ToLower(strings.TrimSpace(message.Message)) != raffle.Keyword {
+		tb.RaffleMutex.Unlock()
+		return false
+	}
+	if _, entered := raffle.Entries[username]; entered {
+		tb.RaffleMutex.Unlock()
+		return true
+	}
+	//Placeholder, so spamming the keyword doesn't enter twice while we count tickets
+	raffle.Entries[username] = 0
+	tb.RaffleMutex.Unlock()
+
+	tickets := raffleTickets(tb.Repos.Stats, raffle.Weighting, &message.User)
+
+	tb.RaffleMutex.Lock()
+	raffle.Entries[username] = tickets
+	tb.RaffleMutex.Unlock()
+
+	if err := tb.Repos.Raffles.AddRaffleEntry(context.Background(), raffle.ID, username, tickets); err != nil {
+		log.Printf("[%s]❌Failed to save raffle entry for %s: %v", time.Now().Format("15:04:05"), username, err)
+	}
+	return true
+}
+
+func (tb *TwitchBot) drawRaffle() {
+	tb.RaffleMutex.Lock()
+	raffle := tb.CurrentRaffle
+	if raffle == nil || raffle.ID == 0 || raffle.Candidate != "" {
+		tb.RaffleMutex.Unlock()
+		return
+	}
+
+	raffle.EntriesOpen = false
+	raffle.Timer.Stop()
+
+	candidate := pickRaffleWinner(raffle.Entries, rand.IntN)
+	if candidate == "" {
+		tb.CurrentRaffle = nil
+		tb.RaffleMutex.Unlock()
+
+		if err := tb.Repos.Raffles.FinishRaffle(context.Background(), raffle.ID, ""); err != nil {
+			log.Printf("[%s]❌Failed to finish raffle: %v", time.Now().Format("15:04:05"), err)
+		}
+		SayAndLog(tb.Client, tb.Channel, fmt.Sprintf("Raffle #%d ended without a winner.", raffle.ID), tb.BotUsername)
+		return
+	}
+
+	raffle.Candidate = candidate
+	raffle.ClaimTimer = time.AfterFunc(raffleClaimWindow, func() {
+		tb.raffleClaimExpired(raffle, candidate)
+	})
+	tb.RaffleMutex.Unlock()
+
+	SayAndLog(tb.Client, tb.Channel,
+		fmt.Sprintf("🎉 @%s, you won the raffle! Type anything in chat in the next %s to claim the prize.", candidate, raffleClaimWindow),
+		tb.BotUsername)
+}
+
+func (tb *TwitchBot) raffleClaimExpired(raffle *Raffle, candidate string) {
+	tb.RaffleMutex.Lock()
+	if tb.CurrentRaffle != raffle || raffle.Candidate != candidate {
+		tb.RaffleMutex.Unlock()
+		return
+	}
+	delete(raffle.Entries, candidate)
+	raffle.Candidate = ""
+	tb.RaffleMutex.Unlock()
+
+	if err := tb.Repos.Raffles.RecordRaffleDraw(context.Background(), raffle.ID, candidate, false); err != nil {
+		log.Printf("[%s]❌Failed to record raffle draw: %v", time.Now().Format("15:04:05"), err)
+	}
+
+	SayAndLog(tb.Client, tb.Channel, fmt.Sprintf("@%s didn't respond in time. Drawing again...", candidate), tb.BotUsername)
+	tb.drawRaffle()
+}
+
+func (tb *TwitchBot) finishRaffleWithWinner(raffleID int, winner string) {
+	ctx := context.Background()
+	if err := tb.Repos.Raffles.RecordRaffleDraw(ctx, raffleID, winner, true); err != nil {
+		log.Printf("[%s]❌Failed to record raffle draw: %v", time.Now().Format("15:04:05"), err)
+	}
+	if err := tb.Repos.Raffles.FinishRaffle(ctx, raffleID, winner); err != nil {
+		log.Printf("[%s]❌Failed to finish raffle: %v", time.Now().Format("15:04:05"), err)
+	}
+
+	SayAndLog(tb.Client, tb.Channel, fmt.Sprintf("🏆 @%s claimed the prize of raffle #%d! Congratulations!", winner, raffleID), tb.BotUsername)
+	tb.tgBot.SendMessage(fmt.Sprintf("[%s] 🏆 %s won raffle #%d", time.Now().Format("15:04:05"), winner, raffleID))
+}
+
+func (tb *TwitchBot) cancelRaffle() {
+	tb.RaffleMutex.Lock()
+	raffle := tb.CurrentRaffle
+	if raffle == nil || raffle.ID == 0 {
+		tb.RaffleMutex.Unlock()
+		SayAndLog(tb.Client, tb.Channel, "There is no active raffle.", tb.BotUsername)
+		return
+	}
+	raffle.Timer.Stop()
+	if raffle.ClaimTimer != nil {
+		raffle.ClaimTimer.Stop()
+	}
+	tb.CurrentRaffle = nil
+	tb.RaffleMutex.Unlock()
+
+	if err := tb.Repos.Raffles.FinishRaffle(context.Background(), raffle.ID, ""); err != nil {
+		log.Printf("[%s]❌Failed to finish raffle: %v", time.Now().Format("15:04:05"), err)
+	}
+	SayAndLog(tb.Client, tb.Channel, fmt.Sprintf("Raffle #%d was cancelled.", raffle.ID), tb.BotUsername)
+}
+
+func (tb *TwitchBot) raffleStatus() string {
+	tb.RaffleMutex.Lock()
+	defer tb.RaffleMutex.Unlock()
+
+	raffle := tb.CurrentRaffle
+	switch {
+	case raffle == nil || raffle.ID == 0:
+		return "There is no active raffle."
+	case raffle.Candidate != "":
+		return fmt.Sprintf("Raffle #%d: waiting for @%s to claim the prize.", raffle.ID, raffle.Candidate)
+	default:
+		return fmt.Sprintf("Raffle #%d: type %s to enter, %d entries so far.", raffle.ID, raffle.Keyword, len(raffle.Entries))
+	}
+}
+
+func raffleTickets(statsRepo db.StatsRepository, weighting string, user *twitch.User) int {
+	switch weighting {
+	case raffleWeightingSub:
+		if twBotCommands.IsSubscriber(user) {
+			return subscriberRaffleTickets
+		}
+	case raffleWeightingLuck:
+		stats, err := statsRepo.GetOrCreateUserStats(context.Background(), user.Name)
+		if err != nil {
+			log.Printf("[%s]❌Failed to get luck for %s: %v", time.Now().Format("15:04:05"), user.Name, err)
+			return 1
+		}
+		for _, stat := range stats {
+			if stat.StatType == "luck" {
+				return max(stat.Value, 1)
+			}
+		}
+	}
+	return 1
+}
+
+// pickRaffleWinner draws a username weighted by tickets, roll returns a number in [0, n) like rand.IntN.
+func pickRaffleWinner(entries map[string]int, roll func(n int) int) string {
+	usernames := make([]string, 0, len(entries))
+	total := 0
+	for username, tickets := range entries {
+		if tickets > 0 {
+			usernames = append(usernames, username)
+			total += tickets
+		}
+	}
+	if total == 0 {
+		return ""
+	}
+	slices.Sort(usernames)
+
+	ticket := roll(total)
+	for _, username := range usernames {
+		ticket -= entries[username]
+		if ticket < 0 {
+			return username
+		}
+	}
+	return ""
+}
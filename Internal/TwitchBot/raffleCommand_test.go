@@ -0,0 +1,140 @@
+package bot
+
+import (
+	db "TelTwBot/Internal/Database"
+	memory "TelTwBot/Internal/Database/Memory"
+	"context"
+	"fmt"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/gempir/go-twitch-irc/v4"
+	"github.com/stretchr/testify/require"
+)
+
+func TestPickRaffleWinner(t *testing.T) {
+	entries := map[string]int{"carol": 1, "alice": 2, "bob": 3, "lurker": 0}
+
+	for _, tc := range []struct {
+		name     string
+		entries  map[string]int
+		ticket   int
+		total    int
+		expected string
+	}{
+		{"no entries", map[string]int{}, 0, 0, ""},
+		{"no tickets", map[string]int{"lurker": 0}, 0, 0, ""},
+		{"single entrant", map[string]int{"alice": 1}, 0, 1, "alice"},
+		//Entrants are sorted, so alice holds tickets 0-1, bob 2-4 and carol 5
+		{"first ticket", entries, 0, 6, "alice"},
+		{"last ticket of alice", entries, 1, 6, "alice"},
+		{"first ticket of bob", entries, 2, 6, "bob"},
+		{"last ticket of bob", entries, 4, 6, "bob"},
+		{"last ticket", entries, 5, 6, "carol"},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			total := 0
+			winner := pickRaffleWinner(tc.entries, func(n int) int {
+				total = n
+				return tc.ticket
+			})
+			require.Equal(t, tc.expected, winner)
+			require.Equal(t, tc.total, total)
+		})
+	}
+}
+
+func TestRaffleTickets(t *testing.T) {
+	stats := memory.NewStore().Repositories().Stats
+	_, err := stats.AddFreePoints(context.Background(), "lucky", 3)
+	require.NoError(t, err)
+	_, _, err = stats.UpdateUserStat(context.Background(), "lucky", "luck", 3)
+	require.NoError(t, err)
+
+	subscriber := &twitch.User{Name: "sub", Badges: map[string]int{"subscriber": 12}}
+	lucky := &twitch.User{Name: "lucky"}
+	viewer := &twitch.User{Name: "viewer"}
+
+	for _, tc := range []struct {
+		name      string
+		weighting string
+		user      *twitch.User
+		expected  int
+	}{
+		{"no weighting", raffleWeightingNone, subscriber, 1},
+		{"subscriber", raffleWeightingSub, subscriber, subscriberRaffleTickets},
+		{"not a subscriber", raffleWeightingSub, viewer, 1},
+		{"luck", raffleWeightingLuck, lucky, 4},
+		{"default luck", raffleWeightingLuck, viewer, 1},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			require.Equal(t, tc.expected, raffleTickets(stats, tc.weighting, tc.user))
+		})
+	}
+}
+
+// fakeRaffles records what the raffle saves.
+type fakeRaffles struct {
+	db.RaffleRepository
+	mutex   sync.Mutex
+	entries map[string]int
+	draws   []string
+	winner  string
+}
+
+func (r *fakeRaffles) AddRaffleEntry(ctx context.Context, raffleID int, username string, tickets int) error {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+	r.entries[username] = tickets
+	return nil
+}
+
+func (r *fakeRaffles) RecordRaffleDraw(ctx context.Context, raffleID int, username string, responded bool) error {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+	r.draws = append(r.draws, fmt.Sprintf("%s:%t", username, responded))
+	return nil
+}
+
+func (r *fakeRaffles) FinishRaffle(ctx context.Context, raffleID int, winner string) error {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+	r.winner = winner
+	return nil
+}
+
+func TestRaffleEntryDrawAndClaim(t *testing.T) {
+	raffles := &fakeRaffles{entries: make(map[string]int)}
+	tb, notifier := newTestBot(func(repos *db.Repositories) { repos.Raffles = raffles })
+	tb.CurrentRaffle = &Raffle{
+		ID:          3,
+		Keyword:     "!join",
+		Weighting:   raffleWeightingSub,
+		Entries:     make(map[string]int),
+		EntriesOpen: true,
+		Timer:       time.AfterFunc(time.Hour, func() {}),
+	}
+
+	subscriber := twitch.User{Name: "sub", Badges: map[string]int{"subscriber": 3}}
+	require.False(t, tb.handleRaffleMessage(twitch.PrivateMessage{User: subscriber, Message: "hello"}))
+	require.True(t, tb.handleRaffleMessage(twitch.PrivateMessage{User: subscriber, Message: " !JOIN "}))
+	require.True(t, tb.handleRaffleMessage(twitch.PrivateMessage{User: subscriber, Message: "!join"}), "entering twice is consumed")
+	require.Equal(t, map[string]int{"sub": subscriberRaffleTickets}, raffles.entries)
+	require.Equal(t, "Raffle #3: type !join to enter, 1 entries so far.", tb.raffleStatus())
+
+	tb.drawRaffle()
+	require.Equal(t, "Raffle #3: waiting for @sub to claim the prize.", tb.raffleStatus())
+
+	viewer := twitch.User{Name: "viewer"}
+	require.False(t, tb.handleRaffleMessage(twitch.PrivateMessage{User: viewer, Message: "!join"}), "entries are closed")
+
+	//Any message of the winner claims the prize and is still handled as chat
+	require.False(t, tb.handleRaffleMessage(twitch.PrivateMessage{User: subscriber, Message: "gg"}))
+	require.Nil(t, tb.CurrentRaffle)
+	require.Equal(t, []string{"sub:true"}, raffles.draws)
+	require.Equal(t, "sub", raffles.winner)
+	require.Len(t, notifier.messages, 1)
+	require.Contains(t, notifier.messages[0], "sub won raffle #3")
+	require.Equal(t, "There is no active raffle.", tb.raffleStatus())
+}
@@ -0,0 +1,143 @@
+package bot
+
+import (
+	config "TelTwBot/Internal/Config"
+	constants "TelTwBot/Internal/Config/Constants"
+	twBotCommands "TelTwBot/Internal/TwitchBot/Commands"
+	"fmt"
+	"log"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/fsnotify/fsnotify"
+	"github.com/gempir/go-twitch-irc/v4"
+)
+
+// Editors save files in several steps (truncate, write, rename), the reload waits until the events settle down
+const configReloadDelay = 500 * time.Millisecond
+
+// reloadableFile is a config file that can be replaced while the bot is running.
+type reloadableFile struct {
+	name string
+	//Loads and validates the file, then swaps the data in. Returns the number of loaded entries.
+	reload func(path string) (int, error)
+}
+
+func (tb *TwitchBot) reloadableFiles() []reloadableFile {
+	return []reloadableFile{
+		{name: constants.GreetingsFile, reload: tb.Greeter.Reload},
+		{name: constants.DuelsFile, reload: func(path string) (int, error) {
+			duels, err := config.LoadDuels(path)
+			if err != nil {
+				return 0, err
+			}
+			tb.duels.Store(&duels)
+			return len(duels), nil
+		}},
+		{name: constants.FriendsFile, reload: func(path string) (int, error) {
+			friends, err := config.LoadFriends(path)
+			if err != nil {
+				return 0, err
+			}
+			tb.friends.Store(&friends)
+			return len(friends), nil
+		}},
+	}
+}
+
+// ReloadConfigFiles reloads greetings, duels and friends. A file that fails validation keeps its previous version.
+func (tb *TwitchBot) ReloadConfigFiles() string {
+	var results []string
+	for _, file := range tb.reloadableFiles() {
+		results = append(results, tb.reloadConfigFile(file))
+	}
+	return strings.Join(results, "; ")
+}
+
+func (tb *TwitchBot) reloadConfigFile(file reloadableFile) string {
+	path, err := config.ConfigPath(file.name)
+	if err == nil {
+		var count int
+		count, err = file.reload(path)
+		if err == nil {
+			log.Printf("[%s]🔄Reloaded %s: %d entries.", time.Now().Format("15:04:05"), file.name, count)
+			return fmt.Sprintf("%s: %d loaded", file.name, count)
+		}
+	}
+
+	log.Printf("[%s]❌Failed to reload %s, keeping the previous version: %v", time.Now().Format("15:04:05"), file.name, err)
+	//Validation errors can span several lines
+	return fmt.Sprintf("%s: %s, kept the previous version", file.name, strings.ReplaceAll(err.Error(), "\n", ", "))
+}
+
+// WatchConfigFiles reloads greetings, duels and friends when they change on disk. The directory is watched
+// instead of the files, so files replaced by the editor are still picked up.
+func (tb *TwitchBot) WatchConfigFiles() error {
+	dir, err := config.ConfigPath("")
+	if err != nil {
+		return err
+	}
+
+	watcher, err := fsnotify.NewWatcher()
+	if err != nil {
+		return err
+	}
+	if err := watcher.Add(dir); err != nil {
+		watcher.Close()
+		return err
+	}
+
+	go tb.watchConfigFiles(watcher)
+	return nil
+}
+
+func (tb *TwitchBot) watchConfigFiles(watcher *fsnotify.Watcher) {
+	defer watcher.Close()
+
+	files := make(map[string]reloadableFile)
+	for _, file := range tb.reloadableFiles() {
+		files[file.name] = file
+	}
+	pending := make(map[string]*time.Timer)
+
+	for {
+		select {
+		case event, ok := <-watcher.Events:
+			if !ok {
+				return
+			}
+
+			file, watched := files[filepath.Base(event.Name)]
+			//Removed or renamed files are skipped, the new version comes with its own Create event
+			if !watched || !(event.Has(fsnotify.Write) || event.Has(fsnotify.Create)) {
+				continue
+			}
+
+			if timer, ok := pending[file.name]; ok {
+				timer.Reset(configReloadDelay)
+				continue
+			}
+			pending[file.name] = time.AfterFunc(configReloadDelay, func() {
+				result := tb.reloadConfigFile(file)
+				tb.tgBot.SendMessage(fmt.Sprintf("[%s] 🔄%s", time.Now().Format("15:04:05"), result))
+			})
+
+		case err, ok := <-watcher.Errors:
+			if !ok {
+				return
+			}
+			log.Printf("[%s]❌Config watcher error: %v", time.Now().Format("15:04:05"), err)
+		}
+	}
+}
+
+func (tb *TwitchBot) handleReloadCommand(message twitch.PrivateMessage) {
+	if !twBotCommands.IsModerator(&message.User) {
+		SayAndLog(tb.Client, tb.Channel, fmt.Sprintf("@%s, only moderators can reload files.", message.User.Name), tb.BotUsername)
+		return
+	}
+
+	SayAndLog(tb.Client, tb.Channel, fmt.Sprintf("🔄 @%s %s", message.User.Name, tb.ReloadConfigFiles()), tb.BotUsername)
+	log.Printf("[%s] ✅Processed !reload command for %s.", time.Now().Format("15:04:05"), message.User.Name)
+}
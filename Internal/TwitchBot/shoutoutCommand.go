@@ -0,0 +1,142 @@
+package bot
+
+import (
+	twBotCommands "TelTwBot/Internal/TwitchBot/Commands"
+	"errors"
+	"fmt"
+	"log"
+	"strings"
+	"sync"
+	"time"
+)
+
+// Twitch limits native shoutouts to one every 2 minutes and to one per target every 60 minutes.
+const (
+	shoutoutGlobalCooldown = 2 * time.Minute
+	shoutoutTargetCooldown = 60 * time.Minute
+)
+
+var (
+	ErrChannelNotFound    = errors.New("channel not found")
+	ErrShoutoutOnCooldown = errors.New("shoutout is on cooldown")
+	//The chat shoutout was posted, only the native /shoutout failed
+	ErrNativeShoutoutFailed = errors.New("native shoutout failed")
+)
+
+type ShoutoutCooldowns struct {
+	mutex        sync.Mutex
+	lastShoutout time.Time
+	lastByTarget map[string]time.Time
+}
+
+// reserve checks both native cooldowns and, if the shoutout is allowed, marks it as sent.
+func (sc *ShoutoutCooldowns) reserve(target string, now time.Time) (bool, time.Duration) {
+	sc.mutex.Lock()
+	defer sc.mutex.Unlock()
+
+	if sc.lastByTarget == nil {
+		sc.lastByTarget = make(map[string]time.Time)
+	}
+
+	if wait := shoutoutGlobalCooldown - now.Sub(sc.lastShoutout); wait > 0 {
+		return false, wait
+	}
+	if last, ok := sc.lastByTarget[target]; ok {
+		if wait := shoutoutTargetCooldown - now.Sub(last); wait > 0 {
+			return false, wait
+		}
+	}
+
+	sc.lastShoutout = now
+	sc.lastByTarget[target] = now
+	return true, 0
+}
+
+// release undoes the reservation made at reservedAt, unless a newer one replaced it.
+// Before the reservation both cooldowns were over, so clearing them is the same as restoring them.
+func (sc *ShoutoutCooldowns) release(target string, reservedAt time.Time) {
+	sc.mutex.Lock()
+	defer sc.mutex.Unlock()
+
+	if sc.lastShoutout.Equal(reservedAt) {
+		sc.lastShoutout = time.Time{}
+	}
+	if last, ok := sc.lastByTarget[target]; ok && last.Equal(reservedAt) {
+		delete(sc.lastByTarget, target)
+	}
+}
+
+// Shoutout posts a formatted shoutout for the target channel and triggers the native /shoutout.
+// The cooldowns are checked first, a shoutout on cooldown returns ErrShoutoutOnCooldown without posting anything.
+func (tb *TwitchBot) Shoutout(target string) error {
+	target = strings.ToLower(strings.TrimPrefix(target, "@"))
+
+	reservedAt := time.Now()
+	allowed, wait := tb.Shoutouts.reserve(target, reservedAt)
+	if !allowed {
+		return fmt.Errorf("%w, wait %s", ErrShoutoutOnCooldown, wait.Round(time.Second))
+	}
+
+	if err := tb.postShoutout(target); err != nil {
+		tb.Shoutouts.release(target, reservedAt)
+		return err
+	}
+	return nil
+}
+
+func (tb *TwitchBot) postShoutout(target string) error {
+	targetUser, err := tb.Helix.GetUserByLogin(target)
+	if errors.Is(err, twBotCommands.ErrUserNotFound) {
+		return fmt.Errorf("%w: %s", ErrChannelNotFound, target)
+	}
+	if err != nil {
+		return fmt.Errorf("failed to find user %s: %w", target, err)
+	}
+
+	channelInfo, err := tb.Helix.GetChannelInfo(targetUser.ID)
+	if err != nil {
+		return fmt.Errorf("failed to get channel info for %s: %w", target, err)
+	}
+
+	SayAndLog(tb.Client, tb.Channel, formatShoutout(targetUser.DisplayName, channelInfo), tb.BotUsername)
+
+	if err := tb.sendNativeShoutout(targetUser.ID); err != nil {
+		if errors.Is(err, twBotCommands.ErrShoutoutCooldown) {
+			log.Printf("[%s] Native shoutout for %s rejected by Twitch: cooldown is still active.", time.Now().Format("15:04:05"), target)
+			return nil
+		}
+		return fmt.Errorf("%w: %w", ErrNativeShoutoutFailed, err)
+	}
+
+	return nil
+}
+
+func (tb *TwitchBot) sendNativeShoutout(targetID string) error {
+	broadcaster, err := tb.Helix.GetUserByLogin(tb.Channel)
+	if err != nil {
+		return fmt.Errorf("failed to get broadcaster: %w", err)
+	}
+
+	moderator, err := tb.Helix.GetUserByLogin(tb.BotUsername)
+	if err != nil {
+		return fmt.Errorf("failed to get bot user: %w", err)
+	}
+
+	return tb.Helix.SendShoutout(broadcaster.ID, targetID, moderator.ID)
+}
+
+func formatShoutout(displayName string, channelInfo *twBotCommands.ChannelInfo) string {
+	var message strings.Builder
+	message.WriteString(fmt.Sprintf("📣 Go check out @%s! ", displayName))
+
+	if channelInfo.GameName != "" {
+		message.WriteString(fmt.Sprintf("They were last seen playing %s", channelInfo.GameName))
+		if channelInfo.Title != "" {
+			message.WriteString(fmt.Sprintf(": \"%s\"", channelInfo.Title))
+		}
+		message.WriteString(". ")
+	}
+
+	message.WriteString(fmt.Sprintf("https://www.twitch.tv/%s", channelInfo.BroadcasterLogin))
+	return message.String()
+}
@@ -0,0 +1,52 @@
+package bot
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestShoutoutCooldowns(t *testing.T) {
+	var cooldowns ShoutoutCooldowns
+	now := time.Now()
+
+	allowed, _ := cooldowns.reserve("streamer", now)
+	require.True(t, allowed)
+
+	allowed, wait := cooldowns.reserve("other", now.Add(time.Minute))
+	require.False(t, allowed)
+	require.Equal(t, time.Minute, wait)
+
+	allowed, wait = cooldowns.reserve("streamer", now.Add(3*time.Minute))
+	require.False(t, allowed)
+	require.Equal(t, 57*time.Minute, wait)
+}
+
+func TestShoutoutCooldownsRelease(t *testing.T) {
+	var cooldowns ShoutoutCooldowns
+	now := time.Now()
+
+	allowed, _ := cooldowns.reserve("streamer", now)
+	require.True(t, allowed)
+	cooldowns.release("streamer", now)
+
+	//A failed shoutout doesn't take the cooldowns
+	allowed, _ = cooldowns.reserve("streamer", now.Add(time.Second))
+	require.True(t, allowed)
+
+	//An older reservation doesn't release the newer one
+	cooldowns.release("streamer", now)
+	allowed, _ = cooldowns.reserve("other", now.Add(2*time.Second))
+	require.False(t, allowed)
+}
+
+func TestShoutoutOnCooldownPostsNothing(t *testing.T) {
+	//No client and no Helix credentials, so anything past the cooldown check would fail
+	tb := &TwitchBot{}
+	tb.Shoutouts.reserve("streamer", time.Now())
+
+	err := tb.Shoutout("@Other")
+	require.ErrorIs(t, err, ErrShoutoutOnCooldown)
+	require.ErrorContains(t, err, "wait 2m0s")
+}
@@ -0,0 +1,231 @@
+package bot
+
+import (
+	db "TelTwBot/Internal/Database"
+	twBotCommands "TelTwBot/Internal/TwitchBot/Commands"
+	"context"
+	"database/sql"
+	"fmt"
+	"log"
+	"slices"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+const (
+	timersTickInterval = 30 * time.Second
+	minTimerInterval   = time.Minute
+)
+
+// TimerScheduler posts the timers stored in the database. Timers themselves live in Postgres,
+// so after restart they continue from their last_posted_at, only chat line counters start from zero.
+type TimerScheduler struct {
+	mutex           sync.Mutex
+	chatLines       int
+	linesAtLastPost map[int]int
+	//Timers are loaded once and again only after one is added or removed
+	timers  []db.Timer
+	loaded  bool
+	version int
+}
+
+func (ts *TimerScheduler) countLine() {
+	ts.mutex.Lock()
+	ts.chatLines++
+	ts.mutex.Unlock()
+}
+
+func (ts *TimerScheduler) linesSincePost(timerID int) int {
+	ts.mutex.Lock()
+	defer ts.mutex.Unlock()
+	return ts.chatLines - ts.linesAtLastPost[timerID]
+}
+
+func (ts *TimerScheduler) markPosted(timerID int, now time.Time) {
+	ts.mutex.Lock()
+	defer ts.mutex.Unlock()
+	if ts.linesAtLastPost == nil {
+		ts.linesAtLastPost = make(map[int]int)
+	}
+	ts.linesAtLastPost[timerID] = ts.chatLines
+
+	for i := range ts.timers {
+		if ts.timers[i].ID == timerID {
+			ts.timers[i].LastPostedAt = sql.NullTime{Time: now, Valid: true}
+		}
+	}
+}
+
+// cachedTimers returns the cached timers, load is called only if they aren't loaded yet or were invalidated.
+func (ts *TimerScheduler) cachedTimers(load func() ([]db.Timer, error)) ([]db.Timer, error) {
+	ts.mutex.Lock()
+	if ts.loaded {
+		timers := slices.Clone(ts.timers)
+		ts.mutex.Unlock()
+		return timers, nil
+	}
+	version := ts.version
+	ts.mutex.Unlock()
+
+	timers, err := load()
+	if err != nil {
+		return nil, err
+	}
+
+	ts.mutex.Lock()
+	defer ts.mutex.Unlock()
+	//A timer changed while loading, the next call loads again
+	if ts.version == version {
+		ts.timers = slices.Clone(timers)
+		ts.loaded = true
+	}
+	return timers, nil
+}
+
+// invalidate makes the next cachedTimers call reload the timers.
+func (ts *TimerScheduler) invalidate() {
+	ts.mutex.Lock()
+	defer ts.mutex.Unlock()
+	ts.loaded = false
+	ts.timers = nil
+	ts.version++
+}
+
+func (tb *TwitchBot) RunTimers() {
+	ticker := time.NewTicker(timersTickInterval)
+	defer ticker.Stop()
+
+	for range ticker.C {
+		tb.postDueTimers(time.Now())
+	}
+}
+
+func (tb *TwitchBot) postDueTimers(now time.Time) {
+	ctx := context.Background()
+	timers, err := tb.Timers.cachedTimers(func() ([]db.Timer, error) {
+		return tb.Repos.Timers.GetTimers(ctx)
+	})
+	if err != nil {
+		log.Printf("[%s]❌Failed to load timers: %v", now.Format("15:04:05"), err)
+		return
+	}
+
+	due := tb.Timers.dueTimers(timers, now)
+	if len(due) == 0 {
+		return
+	}
+
+	isLive, err := tb.Helix.IsStreamLive(tb.Channel)
+	if err != nil {
+		log.Printf("[%s]❌Failed to check stream status for timers: %v", now.Format("15:04:05"), err)
+		return
+	}
+	if !isLive {
+		return
+	}
+
+	for _, timer := range due {
+		SayAndLog(tb.Client, tb.Channel, twBotCommands.ExpandCounters(tb.Repos.Counters, tb.channelGame, timer.Message), tb.BotUsername)
+		tb.Timers.markPosted(timer.ID, now)
+		if err := tb.Repos.Timers.MarkTimerPosted(ctx, timer.ID, now); err != nil {
+			log.Printf("[%s]❌Failed to save timer %s: %v", now.Format("15:04:05"), timer.Name, err)
+		}
+	}
+}
+
+// dueTimers returns the enabled timers whose interval has passed and that have enough chat lines since the last post.
+func (ts *TimerScheduler) dueTimers(timers []db.Timer, now time.Time) []db.Timer {
+	var due []db.Timer
+	for _, timer := range timers {
+		if !timer.Enabled {
+			continue
+		}
+		if timer.LastPostedAt.Valid && now.Sub(timer.LastPostedAt.Time) < timer.Interval {
+			continue
+		}
+		if ts.linesSincePost(timer.ID) < timer.MinLines {
+			continue
+		}
+		due = append(due, timer)
+	}
+	return due
+}
+
+// HandleTimerCommand processes "add/remove/list" for both !timer and Telegram /timer and returns the reply text.
+func (tb *TwitchBot) HandleTimerCommand(args []string) (string, error) {
+	const usage = "Usage: timer add <name> <interval, e.g. 15m> <min chat lines> <message> | timer remove <name> | timer list"
+	if len(args) == 0 {
+		return usage, nil
+	}
+
+	ctx := context.Background()
+	switch strings.ToLower(args[0]) {
+	case "add":
+		if len(args) < 5 {
+			return usage, nil
+		}
+
+		interval, err := parseTimerInterval(args[2])
+		if err != nil {
+			return fmt.Sprintf("Invalid interval '%s', use e.g. 15m or 1h.", args[2]), nil
+		}
+		if interval < minTimerInterval {
+			return fmt.Sprintf("Interval should be at least %s.", minTimerInterval), nil
+		}
+
+		minLines, err := strconv.Atoi(args[3])
+		if err != nil || minLines < 0 {
+			return "Min chat lines should be a non-negative integer.", nil
+		}
+
+		timer, err := tb.Repos.Timers.SaveTimer(ctx, strings.ToLower(args[1]), strings.Join(args[4:], " "), interval, minLines)
+		if err != nil {
+			return "", err
+		}
+		tb.Timers.invalidate()
+		return fmt.Sprintf("Timer '%s' saved: every %s, at least %d chat lines.", timer.Name, timer.Interval, timer.MinLines), nil
+
+	case "remove":
+		if len(args) != 2 {
+			return usage, nil
+		}
+
+		removed, err := tb.Repos.Timers.RemoveTimer(ctx, strings.ToLower(args[1]))
+		if err != nil {
+			return "", err
+		}
+		tb.Timers.invalidate()
+		if !removed {
+			return fmt.Sprintf("Timer '%s' not found.", args[1]), nil
+		}
+		return fmt.Sprintf("Timer '%s' removed.", args[1]), nil
+
+	case "list":
+		timers, err := tb.Repos.Timers.GetTimers(ctx)
+		if err != nil {
+			return "", err
+		}
+		if len(timers) == 0 {
+			return "There are no timers.", nil
+		}
+
+		var entries []string
+		for _, timer := range timers {
+			entries = append(entries, fmt.Sprintf("%s (%s, %d lines)", timer.Name, timer.Interval, timer.MinLines))
+		}
+		return "Timers: " + strings.Join(entries, " | "), nil
+
+	default:
+		return usage, nil
+	}
+}
+
+// parseTimerInterval accepts Go durations (15m, 1h30m) or a plain number of minutes.
+func parseTimerInterval(value string) (time.Duration, error) {
+	if minutes, err := strconv.Atoi(value); err == nil {
+		return time.Duration(minutes) * time.Minute, nil
+	}
+	return time.ParseDuration(value)
+}
@@ -0,0 +1,66 @@
+package bot
+
+import (
+	db "TelTwBot/Internal/Database"
+	"database/sql"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestTimerSchedulerCachesTimers(t *testing.T) {
+	var scheduler TimerScheduler
+	loads := 0
+	load := func() ([]db.Timer, error) {
+		loads++
+		return []db.Timer{{ID: 1, Name: "discord", Enabled: true, Interval: time.Minute}}, nil
+	}
+
+	for range 3 {
+		timers, err := scheduler.cachedTimers(load)
+		require.NoError(t, err)
+		require.Len(t, timers, 1)
+	}
+	require.Equal(t, 1, loads)
+
+	scheduler.invalidate()
+	_, err := scheduler.cachedTimers(load)
+	require.NoError(t, err)
+	require.Equal(t, 2, loads)
+}
+
+func TestDueTimers(t *testing.T) {
+	now := time.Now()
+	var scheduler TimerScheduler
+	scheduler.countLine()
+	scheduler.countLine()
+
+	timers := []db.Timer{
+		{ID: 1, Name: "never posted", Enabled: true, Interval: time.Minute},
+		{ID: 2, Name: "disabled", Interval: time.Minute},
+		{ID: 3, Name: "too early", Enabled: true, Interval: time.Hour, LastPostedAt: sql.NullTime{Time: now.Add(-time.Minute), Valid: true}},
+		{ID: 4, Name: "quiet chat", Enabled: true, Interval: time.Minute, MinLines: 3},
+		{ID: 5, Name: "interval passed", Enabled: true, Interval: time.Minute, LastPostedAt: sql.NullTime{Time: now.Add(-time.Hour), Valid: true}},
+	}
+
+	var names []string
+	for _, timer := range scheduler.dueTimers(timers, now) {
+		names = append(names, timer.Name)
+	}
+	require.Equal(t, []string{"never posted", "interval passed"}, names)
+}
+
+func TestMarkPostedUpdatesCachedTimer(t *testing.T) {
+	now := time.Now()
+	var scheduler TimerScheduler
+	_, err := scheduler.cachedTimers(func() ([]db.Timer, error) {
+		return []db.Timer{{ID: 1, Enabled: true, Interval: time.Hour}}, nil
+	})
+	require.NoError(t, err)
+
+	scheduler.markPosted(1, now)
+	timers, err := scheduler.cachedTimers(nil)
+	require.NoError(t, err)
+	require.Empty(t, scheduler.dueTimers(timers, now.Add(time.Minute)))
+}
@@ -0,0 +1,267 @@
+package bot
+
+import (
+	achievements "TelTwBot/Internal/Achievements"
+	config "TelTwBot/Internal/Config"
+	"context"
+	"fmt"
+	"log"
+	"math/rand/v2"
+	"strings"
+	"time"
+	"unicode"
+
+	"github.com/gempir/go-twitch-irc/v4"
+)
+
+const (
+	triviaRoundDuration = 45 * time.Second
+	triviaHintsCount    = 2
+	triviaCooldown      = 30 * time.Second
+)
+
+var triviaRewards = map[string]int{
+	"easy":   1,
+	"medium": 1,
+	"hard":   2,
+}
+
+type TriviaRound struct {
+	Category string
+	Question config.TriviaQuestion
+	answers  []string
+	timers   []*time.Timer
+}
+
+func (tb *TwitchBot) handleTriviaCommand(message twitch.PrivateMessage) {
+	tb.TriviaMutex.Lock()
+	defer tb.TriviaMutex.Unlock()
+
+	if tb.CurrentTrivia != nil {
+		SayAndLog(tb.Client, tb.Channel, fmt.Sprintf("❓ %s", tb.CurrentTrivia.Question.Question), tb.BotUsername)
+		return
+	}
+
+	if remaining := time.Until(tb.LastTriviaTime.Add(triviaCooldown)); remaining > 0 {
+		SayAndLog(tb.Client, tb.Channel,
+			fmt.Sprintf("@%s, trivia is on cooldown. Please wait %s.", message.User.Name, remaining.Round(time.Second)),
+			tb.BotUsername)
+		return
+	}
+
+	category, question, ok := pickTriviaQuestion(tb.TriviaPacks, strings.Fields(strings.ToLower(message.Message)))
+	if !ok {
+		SayAndLog(tb.Client, tb.Channel, "There are no trivia questions for this category or difficulty.", tb.BotUsername)
+		return
+	}
+
+	round := &TriviaRound{
+		Category: category,
+		Question: question,
+	}
+	for _, answer := range question.Answers {
+		round.answers = append(round.answers, normalizeAnswer(answer))
+	}
+
+	hintInterval := triviaRoundDuration / (triviaHintsCount + 1)
+	for level := 1; level <= triviaHintsCount; level++ {
+		round.timers = append(round.timers, time.AfterFunc(hintInterval*time.Duration(level), func() {
+			tb.sayTriviaHint(round, level)
+		}))
+	}
+	round.timers = append(round.timers, time.AfterFunc(triviaRoundDuration, func() {
+		tb.expireTrivia(round)
+	}))
+	tb.CurrentTrivia = round
+
+	SayAndLog(tb.Client, tb.Channel,
+		fmt.Sprintf("❓ [%s, %s] %s You have %s to answer!", category, question.Difficulty, question.Question, triviaRoundDuration),
+		tb.BotUsername)
+	log.Printf("[%s] ✅Processed !trivia command for %s.", time.Now().Format("15:04:05"), message.User.Name)
+}
+
+// handleTriviaAnswer checks chat messages against the current question. It returns true if the message was a correct answer.
+func (tb *TwitchBot) handleTriviaAnswer(message twitch.PrivateMessage) bool {
+	tb.TriviaMutex.Lock()
+	round := tb.CurrentTrivia
+	if round == nil || !isCorrectAnswer(message.Message, round.answers) {
+		tb.TriviaMutex.Unlock()
+		return false
+	}
+	tb.finishTrivia(round)
+	tb.TriviaMutex.Unlock()
+
+	reward := triviaRewards[round.Question.Difficulty]
+	SayAndLog(tb.Client, tb.Channel,
+		fmt.Sprintf("🎉 @%s got it right! The answer is: %s. +%d free point(s)!", message.User.Name, round.Question.Answers[0], reward),
+		tb.BotUsername)
+
+	go tb.rewardTriviaWinner(message.User.Name, reward)
+	return true
+}
+
+// rewardTriviaWinner grants the free points, XP and achievement progress of a correct answer, it runs outside
+// of the IRC callback so the database doesn't hold up the chat.
+func (tb *TwitchBot) rewardTriviaWinner(username string, reward int) {
+	if _, err := tb.Repos.Stats.AddFreePoints(context.Background(), username, reward); err != nil {
+		log.Printf("[%s]❌Failed to reward trivia winner %s: %v", time.Now().Format("15:04:05"), username, err)
+	}
+	tb.GrantXP(username, tb.Levels.TriviaXP)
+	tb.trackAchievement(achievements.Event{Username: username, Type: achievements.EventTriviaWin})
+}
+
+func (tb *TwitchBot) sayTriviaHint(round *TriviaRound, level int) {
+	tb.TriviaMutex.Lock()
+	defer tb.TriviaMutex.Unlock()
+
+	if tb.CurrentTrivia != round {
+		return
+	}
+
+	SayAndLog(tb.Client, tb.Channel, fmt.Sprintf("💡 Hint: %s", triviaHint(round.Question.Answers[0], level)), tb.BotUsername)
+}
+
+func (tb *TwitchBot) expireTrivia(round *TriviaRound) {
+	tb.TriviaMutex.Lock()
+	defer tb.TriviaMutex.Unlock()
+
+	if tb.CurrentTrivia != round {
+		return
+	}
+	tb.finishTrivia(round)
+
+	SayAndLog(tb.Client, tb.Channel, fmt.Sprintf("⏰ Time's up! The answer was: %s.", round.Question.Answers[0]), tb.BotUsername)
+}
+
+// finishTrivia must be called with TriviaMutex locked.
+func (tb *TwitchBot) finishTrivia(round *TriviaRound) {
+	for _, timer := range round.timers {
+		timer.Stop()
+	}
+	tb.CurrentTrivia = nil
+	tb.LastTriviaTime = time.Now()
+}
+
+// pickTriviaQuestion picks a random question, filters are optional category and difficulty names in any order.
+func pickTriviaQuestion(packs []config.TriviaPack, filters []string) (string, config.TriviaQuestion, bool) {
+	type candidate struct {
+		category string
+		question config.TriviaQuestion
+	}
+
+	var candidates []candidate
+	for _, pack := range packs {
+		for _, question := range pack.Questions {
+			matches := true
+			for _, filter := range filters {
+				if filter != strings.ToLower(pack.Category) && filter != question.Difficulty {
+					matches = false
+					break
+				}
+			}
+			if matches {
+				candidates = append(candidates, candidate{pack.Category, question})
+			}
+		}
+	}
+
+	if len(candidates) == 0 {
+		return "", config.TriviaQuestion{}, false
+	}
+
+	picked := candidates[rand.IntN(len(candidates))]
+	return picked.category, picked.question, true
+}
+
+// normalizeAnswer lowercases the answer, drops punctuation and leading articles, so "The Nile!" matches "nile".
+func normalizeAnswer(answer string) string {
+	var normalized strings.Builder
+	for _, r := range strings.ToLower(answer) {
+		switch {
+		case unicode.IsLetter(r) || unicode.IsDigit(r):
+			normalized.WriteRune(r)
+		case unicode.IsSpace(r) || r == '-' || r == '_':
+			normalized.WriteRune(' ')
+		}
+	}
+
+	words := strings.Fields(normalized.String())
+	if len(words) > 1 && (words[0] == "the" || words[0] == "a" || words[0] == "an") {
+		words = words[1:]
+	}
+	return strings.Join(words, " ")
+}
+
+func isCorrectAnswer(message string, answers []string) bool {
+	guess := normalizeAnswer(message)
+	if guess == "" {
+		return false
+	}
+
+	for _, answer := range answers {
+		if levenshtein(guess, answer) <= allowedTypos(answer) {
+			return true
+		}
+	}
+	return false
+}
+
+// allowedTypos keeps short answers (and numbers) exact, otherwise "1994" would win for "1993".
+func allowedTypos(answer string) int {
+	if strings.IndexFunc(answer, unicode.IsLetter) < 0 {
+		return 0
+	}
+
+	switch length := len([]rune(answer)); {
+	case length <= 4:
+		return 0
+	case length <= 8:
+		return 1
+	default:
+		return 2
+	}
+}
+
+func levenshtein(a string, b string) int {
+	first, second := []rune(a), []rune(b)
+	previous := make([]int, len(second)+1)
+	current := make([]int, len(second)+1)
+	for j := range previous {
+		previous[j] = j
+	}
+
+	for i := 1; i <= len(first); i++ {
+		current[0] = i
+		for j := 1; j <= len(second); j++ {
+			cost := 1
+			if first[i-1] == second[j-1] {
+				cost = 0
+			}
+			current[j] = min(previous[j]+1, current[j-1]+1, previous[j-1]+cost)
+		}
+		previous, current = current, previous
+	}
+
+	return previous[len(second)]
+}
+
+// triviaHint masks the answer: level 1 shows the first letter of every word, level 2 also shows every third letter.
+func triviaHint(answer string, level int) string {
+	var hint strings.Builder
+	position := 0
+	for _, r := range answer {
+		if !unicode.IsLetter(r) && !unicode.IsDigit(r) {
+			hint.WriteRune(r)
+			position = 0
+			continue
+		}
+
+		if position == 0 || (level >= 2 && position%3 == 0) {
+			hint.WriteRune(r)
+		} else {
+			hint.WriteRune('_')
+		}
+		position++
+	}
+	return hint.String()
+}
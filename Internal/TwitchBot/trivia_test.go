@@ -0,0 +1,129 @@
+package bot
+
+import (
+	achievements "TelTwBot/Internal/Achievements"
+	config "TelTwBot/Internal/Config"
+	"context"
+	"testing"
+	"time"
+
+	"github.com/gempir/go-twitch-irc/v4"
+	"github.com/stretchr/testify/require"
+)
+
+func TestLevenshtein(t *testing.T) {
+	for _, tc := range []struct {
+		a, b     string
+		expected int
+	}{
+		{"", "", 0},
+		{"mario", "", 5},
+		{"", "mario", 5},
+		{"mario", "mario", 0},
+		{"mario", "maro", 1},
+		{"mario", "marion", 1},
+		{"mario", "nario", 1},
+		{"kitten", "sitting", 3},
+		{"pokémon", "pokemon", 1},
+	} {
+		t.Run(tc.a+"/"+tc.b, func(t *testing.T) {
+			require.Equal(t, tc.expected, levenshtein(tc.a, tc.b))
+			require.Equal(t, tc.expected, levenshtein(tc.b, tc.a))
+		})
+	}
+}
+
+func TestNormalizeAnswer(t *testing.T) {
+	for _, tc := range []struct {
+		input    string
+		expected string
+	}{
+		{"Mario", "mario"},
+		{"  Super   MARIO  ", "super mario"},
+		{"The Legend of Zelda", "legend of zelda"},
+		{"a Link to the Past", "link to the past"},
+		{"The", "the"},
+		{"Half-Life_2", "half life 2"},
+		{"Pokémon!", "pokémon"},
+		{"?!", ""},
+	} {
+		t.Run(tc.input, func(t *testing.T) {
+			require.Equal(t, tc.expected, normalizeAnswer(tc.input))
+		})
+	}
+}
+
+func TestIsCorrectAnswer(t *testing.T) {
+	for _, tc := range []struct {
+		message  string
+		answers  []string
+		expected bool
+	}{
+		{"Mario", []string{"mario"}, true},
+		{"the mario", []string{"mario"}, true},
+		{"", []string{"mario"}, false},
+		{"link", []string{"link"}, true},
+		//Short answers and numbers must be exact
+		{"lnk", []string{"link"}, false},
+		{"1999", []string{"1999"}, true},
+		{"1998", []string{"1999"}, false},
+		{"Zelad", []string{"zelda"}, false},
+		{"Zeld", []string{"zelda"}, true},
+		{"Minecraf", []string{"minecraft"}, true},
+		{"Mincraf", []string{"minecraft"}, true},
+		{"Mncraf", []string{"minecraft"}, false},
+		{"luigi", []string{"mario", "luigi"}, true},
+	} {
+		t.Run(tc.message, func(t *testing.T) {
+			require.Equal(t, tc.expected, isCorrectAnswer(tc.message, tc.answers))
+		})
+	}
+}
+
+func TestTriviaHint(t *testing.T) {
+	for _, tc := range []struct {
+		answer   string
+		level    int
+		expected string
+	}{
+		{"Mario", 1, "M____"},
+		{"Mario", 2, "M__i_"},
+		{"Super Mario", 1, "S____ M____"},
+		{"Half-Life 2", 1, "H___-L___ 2"},
+		{"Half-Life 2", 2, "H__f-L__e 2"},
+		{"1999", 1, "1___"},
+		{"", 1, ""},
+	} {
+		t.Run(tc.answer, func(t *testing.T) {
+			require.Equal(t, tc.expected, triviaHint(tc.answer, tc.level))
+		})
+	}
+}
+
+func TestTriviaRound(t *testing.T) {
+	tb, _ := newTestBot(nil)
+	tb.Achievements = achievements.NewEvaluator(nil, nil)
+	tb.TriviaPacks = []config.TriviaPack{{
+		Category:  "Games",
+		Questions: []config.TriviaQuestion{{Question: "Who saves Peach?", Answers: []string{"Mario"}, Difficulty: "hard"}},
+	}}
+
+	tb.handleTriviaCommand(twitch.PrivateMessage{User: twitch.User{Name: "viewer"}, Message: "games hard"})
+	require.NotNil(t, tb.CurrentTrivia)
+	require.Equal(t, "Games", tb.CurrentTrivia.Category)
+
+	require.False(t, tb.handleTriviaAnswer(twitch.PrivateMessage{User: twitch.User{Name: "viewer"}, Message: "luigi"}))
+	require.NotNil(t, tb.CurrentTrivia)
+	require.True(t, tb.handleTriviaAnswer(twitch.PrivateMessage{User: twitch.User{Name: "viewer"}, Message: "the mario"}))
+	require.Nil(t, tb.CurrentTrivia)
+	require.False(t, tb.handleTriviaAnswer(twitch.PrivateMessage{User: twitch.User{Name: "late"}, Message: "mario"}), "the round is over")
+
+	//Rewards are granted in the background, hard questions give 2 free points
+	require.Eventually(t, func() bool {
+		stats, err := tb.Repos.Stats.GetTwitchUserStats(context.Background(), "viewer")
+		return err == nil && statValue(stats, "free-points") == triviaRewards["hard"]
+	}, time.Second, 10*time.Millisecond)
+
+	tb.handleTriviaCommand(twitch.PrivateMessage{User: twitch.User{Name: "viewer"}})
+	require.Nil(t, tb.CurrentTrivia, "trivia is on cooldown")
+}
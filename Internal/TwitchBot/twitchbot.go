@@ -1,33 +1,80 @@
 package bot
 
 import (
+	achievements "TelTwBot/Internal/Achievements"
+	calc "TelTwBot/Internal/Calc"
 	config "TelTwBot/Internal/Config"
 	constants "TelTwBot/Internal/Config/Constants"
+	db "TelTwBot/Internal/Database"
+	hltb "TelTwBot/Internal/HLTB"
 	botInterfaces "TelTwBot/Internal/Interfaces"
+	twBotCommands "TelTwBot/Internal/TwitchBot/Commands"
 	"fmt"
 	"log"
 	"math/rand"
-	"os"
 	"strings"
 	"sync"
+	"sync/atomic"
 	"time"
 
 	"github.com/gempir/go-twitch-irc/v4"
 )
 
 type TwitchBot struct {
-	Client     *twitch.Client
+	Client *twitch.Client
+	//twitch.channel and twitch.bot_username from the settings
+	Channel     string
+	BotUsername string
+	Helix       *twBotCommands.TwitchAPI
+
 	Greeter    *Greeter
 	startTime  time.Time
 	streamLive bool
 	tgBot      botInterfaces.TelegramNotifierInterface
 	commands   []Command
 
-	Duels                []config.DuelMsg
+	//Duel templates and !who friends, replaced as a whole when the files are reloaded
+	duels                atomic.Pointer[[]config.DuelMsg]
+	friends              atomic.Pointer[[]string]
 	CurrentDuel          *DuelChallenge
 	DuelMutex            sync.Mutex
 	LastDuelTime         time.Time
 	IsDuelCooldownActive bool
+
+	Shoutouts    ShoutoutCooldowns
+	Timers       TimerScheduler
+	CounterNames CounterNames
+
+	CurrentPoll *ChatPoll
+	PollMutex   sync.Mutex
+
+	CurrentRaffle *Raffle
+	RaffleMutex   sync.Mutex
+
+	TriviaPacks    []config.TriviaPack
+	CurrentTrivia  *TriviaRound
+	TriviaMutex    sync.Mutex
+	LastTriviaTime time.Time
+
+	Bosses          []config.BossMsg
+	CurrentBossRaid *BossRaid
+	BossRaidMutex   sync.Mutex
+
+	Levels config.LevelConfig
+	XP     XPTracker
+
+	Items config.ItemCatalog
+
+	Achievements *achievements.Evaluator
+
+	WelcomeConfig config.WelcomeConfig
+	Welcome       Welcomer
+
+	Calc calc.Sessions
+
+	HLTB *hltb.Service
+
+	Repos db.Repositories
 }
 
 type DuelChallenge struct {
@@ -46,55 +93,88 @@ type Command struct {
 
 var _ botInterfaces.TwitchBotInterface = (*TwitchBot)(nil)
 
-func New(greeter *Greeter, duels []config.DuelMsg, tgNotifier botInterfaces.TelegramNotifierInterface) (*TwitchBot, error) {
-	tokenFilePath, err := config.ConfigPath(constants.TokenFile)
-	if err != nil {
-		log.Fatalf("Error getting token path: %v", err)
-	}
+func New(settings *config.Settings, greeter *Greeter, duels []config.DuelMsg, friends []string, triviaPacks []config.TriviaPack, bosses []config.BossMsg, levels config.LevelConfig, items config.ItemCatalog, achievementDefs []config.Achievement, welcome config.WelcomeConfig, repos db.Repositories, tgNotifier botInterfaces.TelegramNotifierInterface) (*TwitchBot, error) {
+	client := twitch.NewClient(settings.Twitch.BotUsername, settings.Twitch.Token)
+	client.SetIRCToken(settings.Twitch.Token)
 
-	tokenData, err := os.ReadFile(tokenFilePath)
-	if err != nil {
-		log.Fatalf("Error reading token file: %v", err)
+	tb := &TwitchBot{
+		Client:        client,
+		Channel:       settings.Twitch.Channel,
+		BotUsername:   settings.Twitch.BotUsername,
+		Helix:         twBotCommands.NewTwitchAPI(settings.Helix),
+		Greeter:       greeter,
+		startTime:     time.Now(),
+		streamLive:    false,
+		tgBot:         tgNotifier,
+		TriviaPacks:   triviaPacks,
+		Bosses:        bosses,
+		Levels:        levels,
+		Items:         items,
+		Achievements:  achievements.NewEvaluator(achievementDefs, repos.Achievements),
+		WelcomeConfig: welcome,
+		HLTB:          hltb.NewService(repos.HLTB, constants.HLTBCacheTTL, constants.HLTBMaxResults),
+		Repos:         repos,
 	}
-	client := twitch.NewClient(constants.BotUsername, string(tokenData))
-	client.SetIRCToken(string(tokenData))
-
-	return &TwitchBot{
-		Client:     client,
-		Greeter:    greeter,
-		startTime:  time.Now(),
-		streamLive: false,
-		tgBot:      tgNotifier,
-		Duels:      duels,
-	}, nil
+	tb.duels.Store(&duels)
+	tb.friends.Store(&friends)
+	return tb, nil
 }
 
 func (tb *TwitchBot) Connect() error {
 	tb.InitCommands()
+	go tb.RunTimers()
+	if err := tb.WatchConfigFiles(); err != nil {
+		log.Printf("%s⚠️Config files won't be reloaded on change, use !reload: %v", constants.Yellow, err)
+	}
 	tb.Client.OnConnect(func() {
 		log.Printf("%s✅Bot connected to Twitch IRC!", constants.Blue)
 		tb.tgBot.SendMessage(fmt.Sprintf("[%s] ✅Bot connected to Twitch IRC!", time.Now().Format("15:04:05")))
-		tb.Client.Join(constants.Channel)
+		tb.Client.Join(tb.Channel)
 		tb.streamLive = true
 		tb.startTime = time.Now()
+		tb.Welcome.startSession()
 	})
 	tb.Client.OnPrivateMessage(func(message twitch.PrivateMessage) {
-		cmdInput := strings.TrimSpace(strings.ToLower(message.Message))
-		for _, cmd := range tb.commands {
-			if strings.HasPrefix(cmdInput, cmd.Name) {
-				args := strings.Fields(cmdInput[len(cmd.Name):])
-				msgWithArgs := message
-				msgWithArgs.Message = strings.Join(args, " ")
-				cmd.Handler(tb, msgWithArgs)
-				break
-			}
+		tb.Timers.countLine()
+		go tb.welcomeChatter(message.User.Name)
+		go tb.grantChatXP(message.User.Name)
 
+		//Trivia goes first, a number answer during a poll must not be swallowed as a vote
+		if tb.handleTriviaAnswer(message) {
+			return
+		}
+		//During a poll chatters can vote just by typing the number, the vote is still logged as chat
+		if tb.registerPollVote(message.User.Name, message.Message) {
+			log.Printf("%s[%s] %s: %s\n", constants.White, message.Channel, message.User.Name, message.Message)
+			return
+		}
+		if tb.handleRaffleMessage(message) {
+			return
 		}
+
+		tb.dispatchCommand(message)
 		log.Printf("%s[%s] %s: %s\n", constants.White, message.Channel, message.User.Name, message.Message)
 	})
 
+	tb.Client.OnUserNoticeMessage(func(message twitch.UserNoticeMessage) {
+		if message.MsgID != "raid" {
+			return
+		}
+
+		raider := message.MsgParams["msg-param-login"]
+		log.Printf("[%s] Raid from %s with %s viewers.", time.Now().Format("15:04:05"), raider, message.MsgParams["msg-param-viewerCount"])
+		tb.Welcome.raidStarted(time.Now(), time.Duration(tb.WelcomeConfig.RaidQuietSeconds)*time.Second)
+
+		if !constants.AutoShoutoutOnRaid {
+			return
+		}
+		if err := tb.Shoutout(raider); err != nil {
+			log.Printf("[%s]❌Failed to shoutout raider %s: %v", time.Now().Format("15:04:05"), raider, err)
+		}
+	})
+
 	tb.Client.OnUserPartMessage(func(message twitch.UserPartMessage) {
-		if message.User == constants.Channel {
+		if message.User == tb.Channel {
 			tb.streamLive = false
 			log.Printf("Stream went offline at %s", time.Now().Format("15:04:05"))
 			log.Printf("Trying to reconnect...")
@@ -112,6 +192,32 @@ func (tb *TwitchBot) Connect() error {
 	return nil
 }
 
+// dispatchCommand runs the command named by the first word of the message, the handler gets only the arguments.
+// The whole word is matched case-insensitively, otherwise short commands like !so would also fire on !sorry, etc.
+// Arguments keep their case, because of free text like timer messages and quotes.
+func (tb *TwitchBot) dispatchCommand(message twitch.PrivateMessage) {
+	cmdInput := strings.Fields(message.Message)
+	if len(cmdInput) == 0 {
+		return
+	}
+
+	cmdName := strings.ToLower(cmdInput[0])
+	msgWithArgs := message
+	msgWithArgs.Message = strings.Join(cmdInput[1:], " ")
+
+	for _, cmd := range tb.commands {
+		if cmdName == cmd.Name {
+			cmd.Handler(tb, msgWithArgs)
+			return
+		}
+	}
+
+	//Counters are created at runtime, so they aren't in the commands list
+	if strings.HasPrefix(cmdName, "!") {
+		tb.handleCounterAlias(cmdName, msgWithArgs)
+	}
+}
+
 func ReconnectTwitch(tb *TwitchBot, maxRetries int) {
 	retryCount := 0
 	baseDelay := 5 * time.Second
@@ -155,6 +261,16 @@ func (tb *TwitchBot) GetStreamUptime() (string, error) {
 		int(uptime.Seconds())%60), nil
 }
 
+// channelGame returns the category set on the channel, it's known even if the stream is offline.
+func (tb *TwitchBot) channelGame() (string, error) {
+	return tb.Helix.GetChannelGameName(tb.Channel)
+}
+
+// streamGame returns the game of the live stream.
+func (tb *TwitchBot) streamGame() (string, error) {
+	return tb.Helix.GetCurrentGameName(tb.Channel)
+}
+
 func GetAllCommands(tb *TwitchBot) []string {
 
 	const (
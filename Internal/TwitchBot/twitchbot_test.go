@@ -0,0 +1,88 @@
+package bot
+
+import (
+	db "TelTwBot/Internal/Database"
+	memory "TelTwBot/Internal/Database/Memory"
+	"sync"
+	"testing"
+
+	"github.com/gempir/go-twitch-irc/v4"
+	"github.com/stretchr/testify/require"
+)
+
+// fakeNotifier collects the Telegram messages.
+type fakeNotifier struct {
+	mutex    sync.Mutex
+	messages []string
+}
+
+func (n *fakeNotifier) SendMessage(text string) error {
+	n.mutex.Lock()
+	defer n.mutex.Unlock()
+	n.messages = append(n.messages, text)
+	return nil
+}
+
+// newTestBot returns a bot with the memory repositories, replace the nil ones the test needs. The client isn't
+// connected, what the bot says is only queued.
+func newTestBot(configure func(repos *db.Repositories)) (*TwitchBot, *fakeNotifier) {
+	repos := memory.NewStore().Repositories()
+	if configure != nil {
+		configure(&repos)
+	}
+	notifier := &fakeNotifier{}
+	return &TwitchBot{
+		Client:      twitch.NewClient("bot", "oauth:test"),
+		Channel:     "channel",
+		BotUsername: "bot",
+		tgBot:       notifier,
+		Repos:       repos,
+	}, notifier
+}
+
+func statValue(stats []db.UserStats, name string) int {
+	for _, stat := range stats {
+		if stat.StatType == name {
+			return stat.Value
+		}
+	}
+	return 0
+}
+
+func TestDispatchCommand(t *testing.T) {
+	var called, args string
+	handler := func(name string) func(tb *TwitchBot, message twitch.PrivateMessage) {
+		return func(tb *TwitchBot, message twitch.PrivateMessage) {
+			called, args = name, message.Message
+		}
+	}
+
+	tb := &TwitchBot{commands: []Command{
+		{Name: "!so", Handler: handler("!so")},
+		{Name: "!sorry", Handler: handler("!sorry")},
+		{Name: "!timer", Handler: handler("!timer")},
+	}}
+	//No counters, so unknown commands don't look them up in the database
+	tb.CounterNames.loaded = true
+
+	for _, tc := range []struct {
+		message string
+		command string
+		args    string
+	}{
+		{message: "!so @Streamer", command: "!so", args: "@Streamer"},
+		{message: "!SO @Streamer", command: "!so", args: "@Streamer"},
+		{message: "!sorry", command: "!sorry", args: ""},
+		{message: "!soo", command: "", args: ""},
+		{message: "hello !so", command: "", args: ""},
+		{message: "  !timer   add  hydrate 10m 5   Drink   Water ", command: "!timer", args: "add hydrate 10m 5 Drink Water"},
+		{message: "", command: "", args: ""},
+	} {
+		t.Run(tc.message, func(t *testing.T) {
+			called, args = "", ""
+			tb.dispatchCommand(twitch.PrivateMessage{Message: tc.message})
+			require.Equal(t, tc.command, called)
+			require.Equal(t, tc.args, args)
+		})
+	}
+}
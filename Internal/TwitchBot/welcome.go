@@ -0,0 +1,147 @@
+package bot
+
+import (
+	config "TelTwBot/Internal/Config"
+	db "TelTwBot/Internal/Database"
+	"context"
+	"fmt"
+	"log"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/gempir/go-twitch-irc/v4"
+)
+
+// Welcomer remembers who already chatted in the stream session and slows greetings down after raids.
+type Welcomer struct {
+	mutex       sync.Mutex
+	seen        map[string]bool
+	raidUntil   time.Time
+	lastGreetAt time.Time
+}
+
+func (w *Welcomer) startSession() {
+	w.mutex.Lock()
+	defer w.mutex.Unlock()
+	w.seen = make(map[string]bool)
+}
+
+// firstInSession reports whether it's the first message of the user in the session. The user is marked right away,
+// so messages handled at the same time don't greet twice, forget undoes it if the visit couldn't be saved.
+func (w *Welcomer) firstInSession(username string) bool {
+	w.mutex.Lock()
+	defer w.mutex.Unlock()
+
+	if w.seen == nil {
+		w.seen = make(map[string]bool)
+	}
+	if w.seen[username] {
+		return false
+	}
+	w.seen[username] = true
+	return true
+}
+
+func (w *Welcomer) forget(username string) {
+	w.mutex.Lock()
+	defer w.mutex.Unlock()
+	delete(w.seen, username)
+}
+
+func (w *Welcomer) raidStarted(now time.Time, quietPeriod time.Duration) {
+	w.mutex.Lock()
+	defer w.mutex.Unlock()
+	w.raidUntil = now.Add(quietPeriod)
+}
+
+// allowGreeting always allows greetings, except right after a raid, when only one greeting per interval is sent.
+func (w *Welcomer) allowGreeting(now time.Time, interval time.Duration) bool {
+	w.mutex.Lock()
+	defer w.mutex.Unlock()
+
+	if now.Before(w.raidUntil) && now.Sub(w.lastGreetAt) < interval {
+		return false
+	}
+	w.lastGreetAt = now
+	return true
+}
+
+// welcomeChatter greets the user on the first message in the session, it's run in its own goroutine.
+func (tb *TwitchBot) welcomeChatter(username string) {
+	if username == tb.BotUsername || username == tb.Channel {
+		return
+	}
+	if !tb.WelcomeConfig.Enabled || !tb.Welcome.firstInSession(username) {
+		return
+	}
+
+	now := time.Now()
+	visit, err := tb.Repos.Greetings.RegisterChatter(context.Background(), username)
+	if err != nil {
+		//The next message tries again
+		tb.Welcome.forget(username)
+		log.Printf("[%s]❌Failed to register chatter %s: %v", now.Format("15:04:05"), username, err)
+		return
+	}
+	if visit.NoGreet {
+		return
+	}
+
+	template, days := welcomeTemplate(tb.WelcomeConfig, visit, now)
+	if template == "" {
+		return
+	}
+
+	if !tb.Welcome.allowGreeting(now, time.Duration(tb.WelcomeConfig.RaidGreetIntervalSeconds)*time.Second) {
+		log.Printf("[%s] Skipped greeting for %s, raid in progress.", now.Format("15:04:05"), username)
+		return
+	}
+
+	greeting := tb.preferredGreeting(username)
+	SayAndLog(tb.Client, tb.Channel, renderWelcome(template, username, greeting, days), tb.BotUsername)
+}
+
+// welcomeTemplate picks the template for the visit and returns the days since the previous one.
+func welcomeTemplate(welcome config.WelcomeConfig, visit *db.ChatterVisit, now time.Time) (string, int) {
+	switch {
+	case visit.IsNew:
+		return welcome.FirstTimeTemplate, 0
+	case visit.LastSeen.Valid:
+		days := int(now.Sub(visit.LastSeen.Time).Hours() / 24)
+		if days >= welcome.ReturningAfterDays {
+			return welcome.ReturningTemplate, days
+		}
+		return welcome.SessionTemplate, days
+	default:
+		return welcome.SessionTemplate, 0
+	}
+}
+
+func renderWelcome(template string, username string, greeting Greeting, days int) string {
+	return strings.NewReplacer(
+		"{user}", username,
+		"{greeting}", greeting.Text,
+		"{language}", greeting.Language,
+		"{days}", strconv.Itoa(days),
+	).Replace(template)
+}
+
+func (tb *TwitchBot) handleNoGreetCommand(message twitch.PrivateMessage) {
+	username := message.User.Name
+	noGreet := strings.ToLower(strings.TrimSpace(message.Message)) != "off"
+
+	if err := tb.Repos.Greetings.SetNoGreet(context.Background(), username, noGreet); err != nil {
+		log.Printf("[%s]❌Failed to save greeting opt-out for %s: %v", time.Now().Format("15:04:05"), username, err)
+		SayAndLog(tb.Client, tb.Channel, "Failed to save the setting.", tb.BotUsername)
+		return
+	}
+
+	if noGreet {
+		SayAndLog(tb.Client, tb.Channel, fmt.Sprintf("@%s, I won't greet you automatically anymore. Use !nogreet off to undo.", username), tb.BotUsername)
+	} else {
+		SayAndLog(tb.Client, tb.Channel, fmt.Sprintf("@%s, automatic greetings are back on.", username), tb.BotUsername)
+	}
+	log.Printf("[%s] ✅Processed !nogreet command for %s.", time.Now().Format("15:04:05"), username)
+}
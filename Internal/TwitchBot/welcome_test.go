@@ -0,0 +1,93 @@
+package bot
+
+import (
+	config "TelTwBot/Internal/Config"
+	db "TelTwBot/Internal/Database"
+	"database/sql"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestWelcomerSession(t *testing.T) {
+	var welcomer Welcomer
+
+	require.True(t, welcomer.firstInSession("viewer"))
+	require.False(t, welcomer.firstInSession("viewer"))
+	require.True(t, welcomer.firstInSession("other"))
+
+	//A failed visit is greeted on the next message
+	welcomer.forget("viewer")
+	require.True(t, welcomer.firstInSession("viewer"))
+
+	welcomer.startSession()
+	require.True(t, welcomer.firstInSession("viewer"))
+	require.True(t, welcomer.firstInSession("other"))
+}
+
+func TestWelcomerRaid(t *testing.T) {
+	var welcomer Welcomer
+	now := time.Now()
+	interval := 30 * time.Second
+
+	require.True(t, welcomer.allowGreeting(now, interval))
+	require.True(t, welcomer.allowGreeting(now.Add(time.Second), interval), "greetings aren't limited without a raid")
+
+	welcomer.raidStarted(now, 3*time.Minute)
+	require.True(t, welcomer.allowGreeting(now.Add(time.Minute), interval))
+	require.False(t, welcomer.allowGreeting(now.Add(time.Minute+10*time.Second), interval))
+	require.True(t, welcomer.allowGreeting(now.Add(time.Minute+30*time.Second), interval))
+
+	require.True(t, welcomer.allowGreeting(now.Add(3*time.Minute), interval), "the quiet period is over")
+	require.True(t, welcomer.allowGreeting(now.Add(3*time.Minute+time.Second), interval))
+}
+
+func TestWelcomeTemplate(t *testing.T) {
+	welcome := config.WelcomeConfig{
+		FirstTimeTemplate:  "first",
+		SessionTemplate:    "session",
+		ReturningTemplate:  "returning",
+		ReturningAfterDays: 14,
+	}
+	now := time.Date(2025, 5, 17, 20, 0, 0, 0, time.UTC)
+	seen := func(ago time.Duration) sql.NullTime { return sql.NullTime{Time: now.Add(-ago), Valid: true} }
+
+	for _, tc := range []struct {
+		name     string
+		visit    db.ChatterVisit
+		template string
+		days     int
+	}{
+		{"first time", db.ChatterVisit{IsNew: true}, "first", 0},
+		{"seen yesterday", db.ChatterVisit{LastSeen: seen(30 * time.Hour)}, "session", 1},
+		{"almost returning", db.ChatterVisit{LastSeen: seen(14*24*time.Hour - time.Minute)}, "session", 13},
+		{"returning", db.ChatterVisit{LastSeen: seen(14 * 24 * time.Hour)}, "returning", 14},
+		{"unknown last visit", db.ChatterVisit{}, "session", 0},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			template, days := welcomeTemplate(welcome, &tc.visit, now)
+			require.Equal(t, tc.template, template)
+			require.Equal(t, tc.days, days)
+		})
+	}
+}
+
+func TestRenderWelcome(t *testing.T) {
+	greeting := Greeting{Language: "Spanish", Text: "Hola"}
+
+	for _, tc := range []struct {
+		template string
+		expected string
+	}{
+		{"{greeting}, @{user}!", "Hola, @viewer!"},
+		{"Welcome back after {days} days, @{user}! {greeting} means 'hello' in {language}", "Welcome back after 20 days, @viewer! Hola means 'hello' in Spanish"},
+		{"{user} {user}", "viewer viewer"},
+		{"No placeholders", "No placeholders"},
+		{"{unknown} stays", "{unknown} stays"},
+	} {
+		t.Run(tc.template, func(t *testing.T) {
+			require.Equal(t, tc.expected, renderWelcome(tc.template, "viewer", greeting, 20))
+		})
+	}
+}